@@ -77,6 +77,52 @@ func (OrderType) EnumDescriptor() ([]byte, []int) {
 	return file_api_grpc_order_proto_rawDescGZIP(), []int{0}
 }
 
+type PostOnlyMode int32
+
+const (
+	PostOnlyMode_POST_ONLY_REJECT PostOnlyMode = 0 // Reject if the order would cross
+	PostOnlyMode_POST_ONLY_SLIDE  PostOnlyMode = 1 // Re-price one tick behind the opposite best
+)
+
+// Enum value maps for PostOnlyMode.
+var (
+	PostOnlyMode_name = map[int32]string{
+		0: "POST_ONLY_REJECT",
+		1: "POST_ONLY_SLIDE",
+	}
+	PostOnlyMode_value = map[string]int32{
+		"POST_ONLY_REJECT": 0,
+		"POST_ONLY_SLIDE":  1,
+	}
+)
+
+func (x PostOnlyMode) Enum() *PostOnlyMode {
+	p := new(PostOnlyMode)
+	*p = x
+	return p
+}
+
+func (x PostOnlyMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PostOnlyMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_grpc_order_proto_enumTypes[1].Descriptor()
+}
+
+func (PostOnlyMode) Type() protoreflect.EnumType {
+	return &file_api_grpc_order_proto_enumTypes[1]
+}
+
+func (x PostOnlyMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PostOnlyMode.Descriptor instead.
+func (PostOnlyMode) EnumDescriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{1}
+}
+
 type OrderSide int32
 
 const (
@@ -107,11 +153,11 @@ func (x OrderSide) String() string {
 }
 
 func (OrderSide) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_grpc_order_proto_enumTypes[1].Descriptor()
+	return file_api_grpc_order_proto_enumTypes[2].Descriptor()
 }
 
 func (OrderSide) Type() protoreflect.EnumType {
-	return &file_api_grpc_order_proto_enumTypes[1]
+	return &file_api_grpc_order_proto_enumTypes[2]
 }
 
 func (x OrderSide) Number() protoreflect.EnumNumber {
@@ -120,7 +166,7 @@ func (x OrderSide) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OrderSide.Descriptor instead.
 func (OrderSide) EnumDescriptor() ([]byte, []int) {
-	return file_api_grpc_order_proto_rawDescGZIP(), []int{1}
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{2}
 }
 
 type OrderStatus int32
@@ -162,11 +208,11 @@ func (x OrderStatus) String() string {
 }
 
 func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_grpc_order_proto_enumTypes[2].Descriptor()
+	return file_api_grpc_order_proto_enumTypes[3].Descriptor()
 }
 
 func (OrderStatus) Type() protoreflect.EnumType {
-	return &file_api_grpc_order_proto_enumTypes[2]
+	return &file_api_grpc_order_proto_enumTypes[3]
 }
 
 func (x OrderStatus) Number() protoreflect.EnumNumber {
@@ -175,7 +221,120 @@ func (x OrderStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OrderStatus.Descriptor instead.
 func (OrderStatus) EnumDescriptor() ([]byte, []int) {
-	return file_api_grpc_order_proto_rawDescGZIP(), []int{2}
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{3}
+}
+
+type RejectReason int32
+
+const (
+	RejectReason_NO_REJECT              RejectReason = 0
+	RejectReason_INVALID_ORDER          RejectReason = 1
+	RejectReason_INSUFFICIENT_LIQUIDITY RejectReason = 2 // FOK could not be filled in full
+	RejectReason_POST_ONLY_WOULD_CROSS  RejectReason = 3
+	RejectReason_INVALID_PRECISION      RejectReason = 4
+	RejectReason_INSTRUMENT_NOT_TRADING RejectReason = 5
+	RejectReason_EXPIRED                RejectReason = 6 // Market or IOC remainder left unfilled
+	RejectReason_NOTIONAL_OUT_OF_RANGE  RejectReason = 7 // A fill's value would not fit the order's filled value
+)
+
+// Enum value maps for RejectReason.
+var (
+	RejectReason_name = map[int32]string{
+		0: "NO_REJECT",
+		1: "INVALID_ORDER",
+		2: "INSUFFICIENT_LIQUIDITY",
+		3: "POST_ONLY_WOULD_CROSS",
+		4: "INVALID_PRECISION",
+		5: "INSTRUMENT_NOT_TRADING",
+		6: "EXPIRED",
+		7: "NOTIONAL_OUT_OF_RANGE",
+	}
+	RejectReason_value = map[string]int32{
+		"NO_REJECT":              0,
+		"INVALID_ORDER":          1,
+		"INSUFFICIENT_LIQUIDITY": 2,
+		"POST_ONLY_WOULD_CROSS":  3,
+		"INVALID_PRECISION":      4,
+		"INSTRUMENT_NOT_TRADING": 5,
+		"EXPIRED":                6,
+		"NOTIONAL_OUT_OF_RANGE":  7,
+	}
+)
+
+func (x RejectReason) Enum() *RejectReason {
+	p := new(RejectReason)
+	*p = x
+	return p
+}
+
+func (x RejectReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RejectReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_grpc_order_proto_enumTypes[4].Descriptor()
+}
+
+func (RejectReason) Type() protoreflect.EnumType {
+	return &file_api_grpc_order_proto_enumTypes[4]
+}
+
+func (x RejectReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RejectReason.Descriptor instead.
+func (RejectReason) EnumDescriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{4}
+}
+
+type InstrumentStatus int32
+
+const (
+	InstrumentStatus_TRADING  InstrumentStatus = 0
+	InstrumentStatus_HALTED   InstrumentStatus = 1
+	InstrumentStatus_DELISTED InstrumentStatus = 2
+)
+
+// Enum value maps for InstrumentStatus.
+var (
+	InstrumentStatus_name = map[int32]string{
+		0: "TRADING",
+		1: "HALTED",
+		2: "DELISTED",
+	}
+	InstrumentStatus_value = map[string]int32{
+		"TRADING":  0,
+		"HALTED":   1,
+		"DELISTED": 2,
+	}
+)
+
+func (x InstrumentStatus) Enum() *InstrumentStatus {
+	p := new(InstrumentStatus)
+	*p = x
+	return p
+}
+
+func (x InstrumentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InstrumentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_grpc_order_proto_enumTypes[5].Descriptor()
+}
+
+func (InstrumentStatus) Type() protoreflect.EnumType {
+	return &file_api_grpc_order_proto_enumTypes[5]
+}
+
+func (x InstrumentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InstrumentStatus.Descriptor instead.
+func (InstrumentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{5}
 }
 
 type MarketDataType int32
@@ -184,6 +343,7 @@ const (
 	MarketDataType_TRADE             MarketDataType = 0
 	MarketDataType_ORDER_BOOK_UPDATE MarketDataType = 1
 	MarketDataType_HEARTBEAT         MarketDataType = 2
+	MarketDataType_CANDLE            MarketDataType = 3
 )
 
 // Enum value maps for MarketDataType.
@@ -192,11 +352,13 @@ var (
 		0: "TRADE",
 		1: "ORDER_BOOK_UPDATE",
 		2: "HEARTBEAT",
+		3: "CANDLE",
 	}
 	MarketDataType_value = map[string]int32{
 		"TRADE":             0,
 		"ORDER_BOOK_UPDATE": 1,
 		"HEARTBEAT":         2,
+		"CANDLE":            3,
 	}
 )
 
@@ -211,11 +373,11 @@ func (x MarketDataType) String() string {
 }
 
 func (MarketDataType) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_grpc_order_proto_enumTypes[3].Descriptor()
+	return file_api_grpc_order_proto_enumTypes[6].Descriptor()
 }
 
 func (MarketDataType) Type() protoreflect.EnumType {
-	return &file_api_grpc_order_proto_enumTypes[3]
+	return &file_api_grpc_order_proto_enumTypes[6]
 }
 
 func (x MarketDataType) Number() protoreflect.EnumNumber {
@@ -224,19 +386,75 @@ func (x MarketDataType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use MarketDataType.Descriptor instead.
 func (MarketDataType) EnumDescriptor() ([]byte, []int) {
-	return file_api_grpc_order_proto_rawDescGZIP(), []int{3}
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{6}
+}
+
+type CandleInterval int32
+
+const (
+	CandleInterval_INTERVAL_1S CandleInterval = 0
+	CandleInterval_INTERVAL_1M CandleInterval = 1
+	CandleInterval_INTERVAL_5M CandleInterval = 2
+	CandleInterval_INTERVAL_1H CandleInterval = 3
+)
+
+// Enum value maps for CandleInterval.
+var (
+	CandleInterval_name = map[int32]string{
+		0: "INTERVAL_1S",
+		1: "INTERVAL_1M",
+		2: "INTERVAL_5M",
+		3: "INTERVAL_1H",
+	}
+	CandleInterval_value = map[string]int32{
+		"INTERVAL_1S": 0,
+		"INTERVAL_1M": 1,
+		"INTERVAL_5M": 2,
+		"INTERVAL_1H": 3,
+	}
+)
+
+func (x CandleInterval) Enum() *CandleInterval {
+	p := new(CandleInterval)
+	*p = x
+	return p
+}
+
+func (x CandleInterval) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CandleInterval) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_grpc_order_proto_enumTypes[7].Descriptor()
+}
+
+func (CandleInterval) Type() protoreflect.EnumType {
+	return &file_api_grpc_order_proto_enumTypes[7]
+}
+
+func (x CandleInterval) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CandleInterval.Descriptor instead.
+func (CandleInterval) EnumDescriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{7}
 }
 
 // Order messages
 type OrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	ClientOrderId string                 `protobuf:"bytes,2,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity      float64                `protobuf:"fixed64,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	OrderType     OrderType              `protobuf:"varint,5,opt,name=order_type,json=orderType,proto3,enum=aeromatch.OrderType" json:"order_type,omitempty"`
-	Side          OrderSide              `protobuf:"varint,6,opt,name=side,proto3,enum=aeromatch.OrderSide" json:"side,omitempty"`
-	Instrument    string                 `protobuf:"bytes,7,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in api/grpc/order.proto.
+	OrderId       uint64       `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                    // Ignored: the engine assigns order IDs
+	ClientOrderId string       `protobuf:"bytes,2,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"` // Reusing one within the dedupe window returns the original ack
+	Price         string       `protobuf:"bytes,11,opt,name=price,proto3" json:"price,omitempty"`                                       // Empty for market orders
+	Quantity      string       `protobuf:"bytes,12,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	OrderType     OrderType    `protobuf:"varint,5,opt,name=order_type,json=orderType,proto3,enum=aeromatch.OrderType" json:"order_type,omitempty"`
+	Side          OrderSide    `protobuf:"varint,6,opt,name=side,proto3,enum=aeromatch.OrderSide" json:"side,omitempty"`
+	Instrument    string       `protobuf:"bytes,7,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	PostOnlyMode  PostOnlyMode `protobuf:"varint,8,opt,name=post_only_mode,json=postOnlyMode,proto3,enum=aeromatch.PostOnlyMode" json:"post_only_mode,omitempty"` // Only used by POST_ONLY orders
+	Account       string       `protobuf:"bytes,9,opt,name=account,proto3" json:"account,omitempty"`                                                              // Owner, whose OrderUpdates stream reports the order
+	WaitForResult bool         `protobuf:"varint,10,opt,name=wait_for_result,json=waitForResult,proto3" json:"wait_for_result,omitempty"`                         // Reply once the order has been matched, within the call's deadline
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -271,6 +489,7 @@ func (*OrderRequest) Descriptor() ([]byte, []int) {
 	return file_api_grpc_order_proto_rawDescGZIP(), []int{0}
 }
 
+// Deprecated: Marked as deprecated in api/grpc/order.proto.
 func (x *OrderRequest) GetOrderId() uint64 {
 	if x != nil {
 		return x.OrderId
@@ -285,18 +504,18 @@ func (x *OrderRequest) GetClientOrderId() string {
 	return ""
 }
 
-func (x *OrderRequest) GetPrice() float64 {
+func (x *OrderRequest) GetPrice() string {
 	if x != nil {
 		return x.Price
 	}
-	return 0
+	return ""
 }
 
-func (x *OrderRequest) GetQuantity() float64 {
+func (x *OrderRequest) GetQuantity() string {
 	if x != nil {
 		return x.Quantity
 	}
-	return 0
+	return ""
 }
 
 func (x *OrderRequest) GetOrderType() OrderType {
@@ -320,14 +539,42 @@ func (x *OrderRequest) GetInstrument() string {
 	return ""
 }
 
+func (x *OrderRequest) GetPostOnlyMode() PostOnlyMode {
+	if x != nil {
+		return x.PostOnlyMode
+	}
+	return PostOnlyMode_POST_ONLY_REJECT
+}
+
+func (x *OrderRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *OrderRequest) GetWaitForResult() bool {
+	if x != nil {
+		return x.WaitForResult
+	}
+	return false
+}
+
 type OrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Status        OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=aeromatch.OrderStatus" json:"status,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	OrderId      uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status       OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=aeromatch.OrderStatus" json:"status,omitempty"`
+	Timestamp    int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Error        string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	RejectReason RejectReason           `protobuf:"varint,5,opt,name=reject_reason,json=rejectReason,proto3,enum=aeromatch.RejectReason" json:"reject_reason,omitempty"` // Set when status is REJECTED or CANCELLED by the engine
+	// Set only for requests that waited for the result
+	FilledQuantity    string   `protobuf:"bytes,6,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"`
+	RemainingQuantity string   `protobuf:"bytes,7,opt,name=remaining_quantity,json=remainingQuantity,proto3" json:"remaining_quantity,omitempty"` // Resting if status is PENDING or PARTIALLY_FILLED, otherwise cancelled
+	Fills             []*Trade `protobuf:"bytes,8,rep,name=fills,proto3" json:"fills,omitempty"`
+	RoundTripNanos    int64    `protobuf:"varint,9,opt,name=round_trip_nanos,json=roundTripNanos,proto3" json:"round_trip_nanos,omitempty"` // Time from handing the order to the engine until it was matched
+	Duplicate         bool     `protobuf:"varint,10,opt,name=duplicate,proto3" json:"duplicate,omitempty"`                                  // The client order ID was already used; this is the original order's ack
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *OrderResponse) Reset() {
@@ -388,29 +635,71 @@ func (x *OrderResponse) GetError() string {
 	return ""
 }
 
-// Order book messages
-type OrderBookRequest struct {
+func (x *OrderResponse) GetRejectReason() RejectReason {
+	if x != nil {
+		return x.RejectReason
+	}
+	return RejectReason_NO_REJECT
+}
+
+func (x *OrderResponse) GetFilledQuantity() string {
+	if x != nil {
+		return x.FilledQuantity
+	}
+	return ""
+}
+
+func (x *OrderResponse) GetRemainingQuantity() string {
+	if x != nil {
+		return x.RemainingQuantity
+	}
+	return ""
+}
+
+func (x *OrderResponse) GetFills() []*Trade {
+	if x != nil {
+		return x.Fills
+	}
+	return nil
+}
+
+func (x *OrderResponse) GetRoundTripNanos() int64 {
+	if x != nil {
+		return x.RoundTripNanos
+	}
+	return 0
+}
+
+func (x *OrderResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+type CancelOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Instrument    string                 `protobuf:"bytes,1,opt,name=instrument,proto3" json:"instrument,omitempty"`
-	Depth         uint32                 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"` // Number of price levels to return
+	OrderId       uint64                 `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Account       string                 `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"` // Must own the order
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderBookRequest) Reset() {
-	*x = OrderBookRequest{}
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
 	mi := &file_api_grpc_order_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderBookRequest) String() string {
+func (x *CancelOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderBookRequest) ProtoMessage() {}
+func (*CancelOrderRequest) ProtoMessage() {}
 
-func (x *OrderBookRequest) ProtoReflect() protoreflect.Message {
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_grpc_order_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -422,49 +711,56 @@ func (x *OrderBookRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderBookRequest.ProtoReflect.Descriptor instead.
-func (*OrderBookRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
 	return file_api_grpc_order_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *OrderBookRequest) GetInstrument() string {
+func (x *CancelOrderRequest) GetInstrument() string {
 	if x != nil {
 		return x.Instrument
 	}
 	return ""
 }
 
-func (x *OrderBookRequest) GetDepth() uint32 {
+func (x *CancelOrderRequest) GetOrderId() uint64 {
 	if x != nil {
-		return x.Depth
+		return x.OrderId
 	}
 	return 0
 }
 
-type OrderBookResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Instrument    string                 `protobuf:"bytes,1,opt,name=instrument,proto3" json:"instrument,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Bids          []*PriceLevel          `protobuf:"bytes,3,rep,name=bids,proto3" json:"bids,omitempty"`
-	Asks          []*PriceLevel          `protobuf:"bytes,4,rep,name=asks,proto3" json:"asks,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *CancelOrderRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
 }
 
-func (x *OrderBookResponse) Reset() {
-	*x = OrderBookResponse{}
+type CancelOrderResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OrderId           uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status            OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=aeromatch.OrderStatus" json:"status,omitempty"`
+	RemainingQuantity string                 `protobuf:"bytes,3,opt,name=remaining_quantity,json=remainingQuantity,proto3" json:"remaining_quantity,omitempty"` // Quantity left unfilled at cancellation
+	Timestamp         int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
 	mi := &file_api_grpc_order_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderBookResponse) String() string {
+func (x *CancelOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderBookResponse) ProtoMessage() {}
+func (*CancelOrderResponse) ProtoMessage() {}
 
-func (x *OrderBookResponse) ProtoReflect() protoreflect.Message {
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_grpc_order_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -476,62 +772,64 @@ func (x *OrderBookResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderBookResponse.ProtoReflect.Descriptor instead.
-func (*OrderBookResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
 	return file_api_grpc_order_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *OrderBookResponse) GetInstrument() string {
+func (x *CancelOrderResponse) GetOrderId() uint64 {
 	if x != nil {
-		return x.Instrument
+		return x.OrderId
 	}
-	return ""
+	return 0
 }
 
-func (x *OrderBookResponse) GetTimestamp() int64 {
+func (x *CancelOrderResponse) GetStatus() OrderStatus {
 	if x != nil {
-		return x.Timestamp
+		return x.Status
 	}
-	return 0
+	return OrderStatus_PENDING
 }
 
-func (x *OrderBookResponse) GetBids() []*PriceLevel {
+func (x *CancelOrderResponse) GetRemainingQuantity() string {
 	if x != nil {
-		return x.Bids
+		return x.RemainingQuantity
 	}
-	return nil
+	return ""
 }
 
-func (x *OrderBookResponse) GetAsks() []*PriceLevel {
+func (x *CancelOrderResponse) GetTimestamp() int64 {
 	if x != nil {
-		return x.Asks
+		return x.Timestamp
 	}
-	return nil
+	return 0
 }
 
-type PriceLevel struct {
+type AmendOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Price         float64                `protobuf:"fixed64,1,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity      float64                `protobuf:"fixed64,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	OrderCount    uint32                 `protobuf:"varint,3,opt,name=order_count,json=orderCount,proto3" json:"order_count,omitempty"`
+	Instrument    string                 `protobuf:"bytes,1,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	OrderId       uint64                 `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Price         string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`       // New limit price, empty keeps the current price
+	Quantity      string                 `protobuf:"bytes,4,opt,name=quantity,proto3" json:"quantity,omitempty"` // New total quantity, empty keeps the current quantity
+	Account       string                 `protobuf:"bytes,5,opt,name=account,proto3" json:"account,omitempty"`   // Must own the order
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PriceLevel) Reset() {
-	*x = PriceLevel{}
+func (x *AmendOrderRequest) Reset() {
+	*x = AmendOrderRequest{}
 	mi := &file_api_grpc_order_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PriceLevel) String() string {
+func (x *AmendOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PriceLevel) ProtoMessage() {}
+func (*AmendOrderRequest) ProtoMessage() {}
 
-func (x *PriceLevel) ProtoReflect() protoreflect.Message {
+func (x *AmendOrderRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_grpc_order_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -543,55 +841,1977 @@ func (x *PriceLevel) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PriceLevel.ProtoReflect.Descriptor instead.
-func (*PriceLevel) Descriptor() ([]byte, []int) {
+// Deprecated: Use AmendOrderRequest.ProtoReflect.Descriptor instead.
+func (*AmendOrderRequest) Descriptor() ([]byte, []int) {
 	return file_api_grpc_order_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *PriceLevel) GetPrice() float64 {
+func (x *AmendOrderRequest) GetInstrument() string {
 	if x != nil {
-		return x.Price
+		return x.Instrument
 	}
-	return 0
+	return ""
 }
 
-func (x *PriceLevel) GetQuantity() float64 {
+func (x *AmendOrderRequest) GetOrderId() uint64 {
 	if x != nil {
-		return x.Quantity
+		return x.OrderId
 	}
 	return 0
 }
 
-func (x *PriceLevel) GetOrderCount() uint32 {
+func (x *AmendOrderRequest) GetPrice() string {
 	if x != nil {
-		return x.OrderCount
+		return x.Price
 	}
-	return 0
-}
-
-// Market data messages
-type MarketDataRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Instrument    string                 `protobuf:"bytes,1,opt,name=instrument,proto3" json:"instrument,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return ""
 }
 
-func (x *MarketDataRequest) Reset() {
-	*x = MarketDataRequest{}
+func (x *AmendOrderRequest) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *AmendOrderRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+type AmendOrderResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OrderId           uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status            OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=aeromatch.OrderStatus" json:"status,omitempty"`
+	Price             string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity          string                 `protobuf:"bytes,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	RemainingQuantity string                 `protobuf:"bytes,5,opt,name=remaining_quantity,json=remainingQuantity,proto3" json:"remaining_quantity,omitempty"`
+	Timestamp         int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AmendOrderResponse) Reset() {
+	*x = AmendOrderResponse{}
+	mi := &file_api_grpc_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmendOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendOrderResponse) ProtoMessage() {}
+
+func (x *AmendOrderResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_grpc_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmendOrderResponse.ProtoReflect.Descriptor instead.
+func (*AmendOrderResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AmendOrderResponse) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *AmendOrderResponse) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_PENDING
+}
+
+func (x *AmendOrderResponse) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *AmendOrderResponse) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *AmendOrderResponse) GetRemainingQuantity() string {
+	if x != nil {
+		return x.RemainingQuantity
+	}
+	return ""
+}
+
+func (x *AmendOrderResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// The account is the caller's, taken from the call's "account" metadata
+type OrderUpdatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderUpdatesRequest) Reset() {
+	*x = OrderUpdatesRequest{}
+	mi := &file_api_grpc_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderUpdatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderUpdatesRequest) ProtoMessage() {}
+
+func (x *OrderUpdatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderUpdatesRequest.ProtoReflect.Descriptor instead.
+func (*OrderUpdatesRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{6}
+}
+
+// A change to one of the account's orders: accepted, filled, amended, cancelled,
+// expired or rejected
+type ExecutionReport struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	OrderId            uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ClientOrderId      string                 `protobuf:"bytes,2,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	Instrument         string                 `protobuf:"bytes,3,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	Side               OrderSide              `protobuf:"varint,4,opt,name=side,proto3,enum=aeromatch.OrderSide" json:"side,omitempty"`
+	Status             OrderStatus            `protobuf:"varint,5,opt,name=status,proto3,enum=aeromatch.OrderStatus" json:"status,omitempty"`
+	OldStatus          OrderStatus            `protobuf:"varint,6,opt,name=old_status,json=oldStatus,proto3,enum=aeromatch.OrderStatus" json:"old_status,omitempty"`
+	Price              string                 `protobuf:"bytes,7,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity           string                 `protobuf:"bytes,8,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	CumulativeQuantity string                 `protobuf:"bytes,9,opt,name=cumulative_quantity,json=cumulativeQuantity,proto3" json:"cumulative_quantity,omitempty"` // Filled so far
+	RemainingQuantity  string                 `protobuf:"bytes,10,opt,name=remaining_quantity,json=remainingQuantity,proto3" json:"remaining_quantity,omitempty"`
+	LastQuantity       string                 `protobuf:"bytes,11,opt,name=last_quantity,json=lastQuantity,proto3" json:"last_quantity,omitempty"`                              // Fills only: size of this fill
+	LastPrice          string                 `protobuf:"bytes,12,opt,name=last_price,json=lastPrice,proto3" json:"last_price,omitempty"`                                       // Fills only: price of this fill
+	TradeId            uint64                 `protobuf:"varint,13,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`                                            // Fills only
+	ExecutionId        uint64                 `protobuf:"varint,14,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`                                // Fills only
+	RejectReason       RejectReason           `protobuf:"varint,15,opt,name=reject_reason,json=rejectReason,proto3,enum=aeromatch.RejectReason" json:"reject_reason,omitempty"` // Set when the engine rejected, killed or expired the order
+	Timestamp          int64                  `protobuf:"varint,16,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	AveragePrice       string                 `protobuf:"bytes,17,opt,name=average_price,json=averagePrice,proto3" json:"average_price,omitempty"` // Of all fills so far; empty before the first
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ExecutionReport) Reset() {
+	*x = ExecutionReport{}
+	mi := &file_api_grpc_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionReport) ProtoMessage() {}
+
+func (x *ExecutionReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionReport.ProtoReflect.Descriptor instead.
+func (*ExecutionReport) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExecutionReport) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *ExecutionReport) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetInstrument() string {
+	if x != nil {
+		return x.Instrument
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetSide() OrderSide {
+	if x != nil {
+		return x.Side
+	}
+	return OrderSide_BUY
+}
+
+func (x *ExecutionReport) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_PENDING
+}
+
+func (x *ExecutionReport) GetOldStatus() OrderStatus {
+	if x != nil {
+		return x.OldStatus
+	}
+	return OrderStatus_PENDING
+}
+
+func (x *ExecutionReport) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetCumulativeQuantity() string {
+	if x != nil {
+		return x.CumulativeQuantity
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetRemainingQuantity() string {
+	if x != nil {
+		return x.RemainingQuantity
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetLastQuantity() string {
+	if x != nil {
+		return x.LastQuantity
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetLastPrice() string {
+	if x != nil {
+		return x.LastPrice
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetTradeId() uint64 {
+	if x != nil {
+		return x.TradeId
+	}
+	return 0
+}
+
+func (x *ExecutionReport) GetExecutionId() uint64 {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return 0
+}
+
+func (x *ExecutionReport) GetRejectReason() RejectReason {
+	if x != nil {
+		return x.RejectReason
+	}
+	return RejectReason_NO_REJECT
+}
+
+func (x *ExecutionReport) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ExecutionReport) GetAveragePrice() string {
+	if x != nil {
+		return x.AveragePrice
+	}
+	return ""
+}
+
+type GetOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Id:
+	//
+	//	*GetOrderRequest_OrderId
+	//	*GetOrderRequest_ClientOrderId
+	Id            isGetOrderRequest_Id `protobuf_oneof:"id"`
+	Account       string               `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_api_grpc_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetOrderRequest) GetId() isGetOrderRequest_Id {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *GetOrderRequest) GetOrderId() uint64 {
+	if x != nil {
+		if x, ok := x.Id.(*GetOrderRequest_OrderId); ok {
+			return x.OrderId
+		}
+	}
+	return 0
+}
+
+func (x *GetOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		if x, ok := x.Id.(*GetOrderRequest_ClientOrderId); ok {
+			return x.ClientOrderId
+		}
+	}
+	return ""
+}
+
+func (x *GetOrderRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+type isGetOrderRequest_Id interface {
+	isGetOrderRequest_Id()
+}
+
+type GetOrderRequest_OrderId struct {
+	OrderId uint64 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3,oneof"`
+}
+
+type GetOrderRequest_ClientOrderId struct {
+	ClientOrderId string `protobuf:"bytes,2,opt,name=client_order_id,json=clientOrderId,proto3,oneof"` // Looked up within account
+}
+
+func (*GetOrderRequest_OrderId) isGetOrderRequest_Id() {}
+
+func (*GetOrderRequest_ClientOrderId) isGetOrderRequest_Id() {}
+
+type ListOpenOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Instrument    string                 `protobuf:"bytes,2,opt,name=instrument,proto3" json:"instrument,omitempty"` // Empty for every instrument
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOpenOrdersRequest) Reset() {
+	*x = ListOpenOrdersRequest{}
+	mi := &file_api_grpc_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOpenOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOpenOrdersRequest) ProtoMessage() {}
+
+func (x *ListOpenOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOpenOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOpenOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListOpenOrdersRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *ListOpenOrdersRequest) GetInstrument() string {
+	if x != nil {
+		return x.Instrument
+	}
+	return ""
+}
+
+type ListOpenOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*OrderState          `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"` // By instrument, then order ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOpenOrdersResponse) Reset() {
+	*x = ListOpenOrdersResponse{}
+	mi := &file_api_grpc_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOpenOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOpenOrdersResponse) ProtoMessage() {}
+
+func (x *ListOpenOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOpenOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOpenOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListOpenOrdersResponse) GetOrders() []*OrderState {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+// The latest state of an order that is open or recently closed
+type OrderState struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OrderId           uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ClientOrderId     string                 `protobuf:"bytes,2,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	Instrument        string                 `protobuf:"bytes,3,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	Account           string                 `protobuf:"bytes,4,opt,name=account,proto3" json:"account,omitempty"`
+	Side              OrderSide              `protobuf:"varint,5,opt,name=side,proto3,enum=aeromatch.OrderSide" json:"side,omitempty"`
+	OrderType         OrderType              `protobuf:"varint,6,opt,name=order_type,json=orderType,proto3,enum=aeromatch.OrderType" json:"order_type,omitempty"`
+	Status            OrderStatus            `protobuf:"varint,7,opt,name=status,proto3,enum=aeromatch.OrderStatus" json:"status,omitempty"`
+	Price             string                 `protobuf:"bytes,8,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity          string                 `protobuf:"bytes,9,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	FilledQuantity    string                 `protobuf:"bytes,10,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"`
+	RemainingQuantity string                 `protobuf:"bytes,11,opt,name=remaining_quantity,json=remainingQuantity,proto3" json:"remaining_quantity,omitempty"`
+	AveragePrice      string                 `protobuf:"bytes,12,opt,name=average_price,json=averagePrice,proto3" json:"average_price,omitempty"` // Of all fills so far; empty before the first
+	Timestamp         int64                  `protobuf:"varint,13,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                          // When the order was submitted
+	LastUpdated       int64                  `protobuf:"varint,14,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *OrderState) Reset() {
+	*x = OrderState{}
+	mi := &file_api_grpc_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderState) ProtoMessage() {}
+
+func (x *OrderState) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderState.ProtoReflect.Descriptor instead.
+func (*OrderState) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *OrderState) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *OrderState) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *OrderState) GetInstrument() string {
+	if x != nil {
+		return x.Instrument
+	}
+	return ""
+}
+
+func (x *OrderState) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *OrderState) GetSide() OrderSide {
+	if x != nil {
+		return x.Side
+	}
+	return OrderSide_BUY
+}
+
+func (x *OrderState) GetOrderType() OrderType {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_LIMIT
+}
+
+func (x *OrderState) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_PENDING
+}
+
+func (x *OrderState) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *OrderState) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *OrderState) GetFilledQuantity() string {
+	if x != nil {
+		return x.FilledQuantity
+	}
+	return ""
+}
+
+func (x *OrderState) GetRemainingQuantity() string {
+	if x != nil {
+		return x.RemainingQuantity
+	}
+	return ""
+}
+
+func (x *OrderState) GetAveragePrice() string {
+	if x != nil {
+		return x.AveragePrice
+	}
+	return ""
+}
+
+func (x *OrderState) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *OrderState) GetLastUpdated() int64 {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return 0
+}
+
+// Order book messages
+type OrderBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instrument    string                 `protobuf:"bytes,1,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	Depth         uint32                 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"` // Number of price levels to return
+	Fresh         bool                   `protobuf:"varint,3,opt,name=fresh,proto3" json:"fresh,omitempty"` // Read the live book instead of the latest periodic snapshot
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderBookRequest) Reset() {
+	*x = OrderBookRequest{}
+	mi := &file_api_grpc_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBookRequest) ProtoMessage() {}
+
+func (x *OrderBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBookRequest.ProtoReflect.Descriptor instead.
+func (*OrderBookRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OrderBookRequest) GetInstrument() string {
+	if x != nil {
+		return x.Instrument
+	}
+	return ""
+}
+
+func (x *OrderBookRequest) GetDepth() uint32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *OrderBookRequest) GetFresh() bool {
+	if x != nil {
+		return x.Fresh
+	}
+	return false
+}
+
+type OrderBookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instrument    string                 `protobuf:"bytes,1,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Bids          []*PriceLevel          `protobuf:"bytes,3,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks          []*PriceLevel          `protobuf:"bytes,4,rep,name=asks,proto3" json:"asks,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"` // Last engine command reflected in the levels
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderBookResponse) Reset() {
+	*x = OrderBookResponse{}
+	mi := &file_api_grpc_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderBookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBookResponse) ProtoMessage() {}
+
+func (x *OrderBookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBookResponse.ProtoReflect.Descriptor instead.
+func (*OrderBookResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *OrderBookResponse) GetInstrument() string {
+	if x != nil {
+		return x.Instrument
+	}
+	return ""
+}
+
+func (x *OrderBookResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *OrderBookResponse) GetBids() []*PriceLevel {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *OrderBookResponse) GetAsks() []*PriceLevel {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+func (x *OrderBookResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type PriceLevel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Price         string                 `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      string                 `protobuf:"bytes,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	OrderCount    uint32                 `protobuf:"varint,3,opt,name=order_count,json=orderCount,proto3" json:"order_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceLevel) Reset() {
+	*x = PriceLevel{}
+	mi := &file_api_grpc_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceLevel) ProtoMessage() {}
+
+func (x *PriceLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceLevel.ProtoReflect.Descriptor instead.
+func (*PriceLevel) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PriceLevel) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *PriceLevel) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *PriceLevel) GetOrderCount() uint32 {
+	if x != nil {
+		return x.OrderCount
+	}
+	return 0
+}
+
+// Market data messages
+type MarketDataRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Instrument      string                 `protobuf:"bytes,1,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	CandleIntervals []CandleInterval       `protobuf:"varint,2,rep,packed,name=candle_intervals,json=candleIntervals,proto3,enum=aeromatch.CandleInterval" json:"candle_intervals,omitempty"` // Also stream candles at these intervals
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MarketDataRequest) Reset() {
+	*x = MarketDataRequest{}
+	mi := &file_api_grpc_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketDataRequest) ProtoMessage() {}
+
+func (x *MarketDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketDataRequest.ProtoReflect.Descriptor instead.
+func (*MarketDataRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MarketDataRequest) GetInstrument() string {
+	if x != nil {
+		return x.Instrument
+	}
+	return ""
+}
+
+func (x *MarketDataRequest) GetCandleIntervals() []CandleInterval {
+	if x != nil {
+		return x.CandleIntervals
+	}
+	return nil
+}
+
+type MarketDataUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          MarketDataType         `protobuf:"varint,1,opt,name=type,proto3,enum=aeromatch.MarketDataType" json:"type,omitempty"`
+	Trade         *Trade                 `protobuf:"bytes,2,opt,name=trade,proto3" json:"trade,omitempty"`
+	Orderbook     *OrderBookUpdate       `protobuf:"bytes,3,opt,name=orderbook,proto3" json:"orderbook,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Candle        *Candle                `protobuf:"bytes,5,opt,name=candle,proto3" json:"candle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarketDataUpdate) Reset() {
+	*x = MarketDataUpdate{}
+	mi := &file_api_grpc_order_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketDataUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketDataUpdate) ProtoMessage() {}
+
+func (x *MarketDataUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketDataUpdate.ProtoReflect.Descriptor instead.
+func (*MarketDataUpdate) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MarketDataUpdate) GetType() MarketDataType {
+	if x != nil {
+		return x.Type
+	}
+	return MarketDataType_TRADE
+}
+
+func (x *MarketDataUpdate) GetTrade() *Trade {
+	if x != nil {
+		return x.Trade
+	}
+	return nil
+}
+
+func (x *MarketDataUpdate) GetOrderbook() *OrderBookUpdate {
+	if x != nil {
+		return x.Orderbook
+	}
+	return nil
+}
+
+func (x *MarketDataUpdate) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *MarketDataUpdate) GetCandle() *Candle {
+	if x != nil {
+		return x.Candle
+	}
+	return nil
+}
+
+// L2 book update. The first one on a stream is a snapshot; the rest hold only the
+// levels that changed, with quantity "0" for removed levels. Sequence increases by
+// one per update; depending on the server's slow consumer policy, a stream that
+// falls behind skips ahead, gets a fresh snapshot, or is ended.
+type OrderBookUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bids          []*PriceLevel          `protobuf:"bytes,1,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks          []*PriceLevel          `protobuf:"bytes,2,rep,name=asks,proto3" json:"asks,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Snapshot      bool                   `protobuf:"varint,4,opt,name=snapshot,proto3" json:"snapshot,omitempty"` // Levels replace the whole book
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderBookUpdate) Reset() {
+	*x = OrderBookUpdate{}
+	mi := &file_api_grpc_order_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderBookUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBookUpdate) ProtoMessage() {}
+
+func (x *OrderBookUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBookUpdate.ProtoReflect.Descriptor instead.
+func (*OrderBookUpdate) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *OrderBookUpdate) GetBids() []*PriceLevel {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *OrderBookUpdate) GetAsks() []*PriceLevel {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+func (x *OrderBookUpdate) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *OrderBookUpdate) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+// L3 order-by-order update. The first one on a stream is a snapshot of every
+// resting order; the rest carry the events of one engine command, in order.
+// Sequence increases by one per update, as for OrderBookUpdate.
+type L3Update struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instrument    string                 `protobuf:"bytes,1,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Snapshot      *L3Snapshot            `protobuf:"bytes,4,opt,name=snapshot,proto3" json:"snapshot,omitempty"` // Set instead of events when the levels replace the whole book
+	Events        []*L3Event             `protobuf:"bytes,5,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *L3Update) Reset() {
+	*x = L3Update{}
+	mi := &file_api_grpc_order_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *L3Update) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*L3Update) ProtoMessage() {}
+
+func (x *L3Update) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use L3Update.ProtoReflect.Descriptor instead.
+func (*L3Update) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *L3Update) GetInstrument() string {
+	if x != nil {
+		return x.Instrument
+	}
+	return ""
+}
+
+func (x *L3Update) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *L3Update) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *L3Update) GetSnapshot() *L3Snapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *L3Update) GetEvents() []*L3Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type L3Snapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bids          []*RestingOrder        `protobuf:"bytes,1,rep,name=bids,proto3" json:"bids,omitempty"` // Best price first, in queue order within a price
+	Asks          []*RestingOrder        `protobuf:"bytes,2,rep,name=asks,proto3" json:"asks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *L3Snapshot) Reset() {
+	*x = L3Snapshot{}
+	mi := &file_api_grpc_order_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *L3Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*L3Snapshot) ProtoMessage() {}
+
+func (x *L3Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use L3Snapshot.ProtoReflect.Descriptor instead.
+func (*L3Snapshot) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *L3Snapshot) GetBids() []*RestingOrder {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *L3Snapshot) GetAsks() []*RestingOrder {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+type RestingOrder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Price         string                 `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      string                 `protobuf:"bytes,3,opt,name=quantity,proto3" json:"quantity,omitempty"` // Size left resting
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestingOrder) Reset() {
+	*x = RestingOrder{}
+	mi := &file_api_grpc_order_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestingOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestingOrder) ProtoMessage() {}
+
+func (x *RestingOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestingOrder.ProtoReflect.Descriptor instead.
+func (*RestingOrder) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RestingOrder) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *RestingOrder) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *RestingOrder) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+type L3Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*L3Event_Added
+	//	*L3Event_Modified
+	//	*L3Event_Deleted
+	//	*L3Event_Executed
+	Event         isL3Event_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *L3Event) Reset() {
+	*x = L3Event{}
+	mi := &file_api_grpc_order_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *L3Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*L3Event) ProtoMessage() {}
+
+func (x *L3Event) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use L3Event.ProtoReflect.Descriptor instead.
+func (*L3Event) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *L3Event) GetEvent() isL3Event_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *L3Event) GetAdded() *OrderAdded {
+	if x != nil {
+		if x, ok := x.Event.(*L3Event_Added); ok {
+			return x.Added
+		}
+	}
+	return nil
+}
+
+func (x *L3Event) GetModified() *OrderModified {
+	if x != nil {
+		if x, ok := x.Event.(*L3Event_Modified); ok {
+			return x.Modified
+		}
+	}
+	return nil
+}
+
+func (x *L3Event) GetDeleted() *OrderDeleted {
+	if x != nil {
+		if x, ok := x.Event.(*L3Event_Deleted); ok {
+			return x.Deleted
+		}
+	}
+	return nil
+}
+
+func (x *L3Event) GetExecuted() *OrderExecuted {
+	if x != nil {
+		if x, ok := x.Event.(*L3Event_Executed); ok {
+			return x.Executed
+		}
+	}
+	return nil
+}
+
+type isL3Event_Event interface {
+	isL3Event_Event()
+}
+
+type L3Event_Added struct {
+	Added *OrderAdded `protobuf:"bytes,1,opt,name=added,proto3,oneof"`
+}
+
+type L3Event_Modified struct {
+	Modified *OrderModified `protobuf:"bytes,2,opt,name=modified,proto3,oneof"`
+}
+
+type L3Event_Deleted struct {
+	Deleted *OrderDeleted `protobuf:"bytes,3,opt,name=deleted,proto3,oneof"`
+}
+
+type L3Event_Executed struct {
+	Executed *OrderExecuted `protobuf:"bytes,4,opt,name=executed,proto3,oneof"`
+}
+
+func (*L3Event_Added) isL3Event_Event() {}
+
+func (*L3Event_Modified) isL3Event_Event() {}
+
+func (*L3Event_Deleted) isL3Event_Event() {}
+
+func (*L3Event_Executed) isL3Event_Event() {}
+
+// The order rests at the back of its price level
+type OrderAdded struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Side          OrderSide              `protobuf:"varint,2,opt,name=side,proto3,enum=aeromatch.OrderSide" json:"side,omitempty"`
+	Price         string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      string                 `protobuf:"bytes,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderAdded) Reset() {
+	*x = OrderAdded{}
+	mi := &file_api_grpc_order_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderAdded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderAdded) ProtoMessage() {}
+
+func (x *OrderAdded) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderAdded.ProtoReflect.Descriptor instead.
+func (*OrderAdded) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *OrderAdded) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *OrderAdded) GetSide() OrderSide {
+	if x != nil {
+		return x.Side
+	}
+	return OrderSide_BUY
+}
+
+func (x *OrderAdded) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *OrderAdded) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+// The order's size was reduced in place, keeping its queue position
+type OrderModified struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Quantity      string                 `protobuf:"bytes,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // New size left resting
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderModified) Reset() {
+	*x = OrderModified{}
+	mi := &file_api_grpc_order_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderModified) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderModified) ProtoMessage() {}
+
+func (x *OrderModified) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderModified.ProtoReflect.Descriptor instead.
+func (*OrderModified) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *OrderModified) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *OrderModified) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+// The order left the book without trading
+type OrderDeleted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderDeleted) Reset() {
+	*x = OrderDeleted{}
+	mi := &file_api_grpc_order_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderDeleted) ProtoMessage() {}
+
+func (x *OrderDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderDeleted.ProtoReflect.Descriptor instead.
+func (*OrderDeleted) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *OrderDeleted) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+// The order traded; it leaves the book once nothing remains
+type OrderExecuted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Price         string                 `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      string                 `protobuf:"bytes,3,opt,name=quantity,proto3" json:"quantity,omitempty"` // Size traded
+	TradeId       uint64                 `protobuf:"varint,4,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderExecuted) Reset() {
+	*x = OrderExecuted{}
+	mi := &file_api_grpc_order_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderExecuted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderExecuted) ProtoMessage() {}
+
+func (x *OrderExecuted) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderExecuted.ProtoReflect.Descriptor instead.
+func (*OrderExecuted) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *OrderExecuted) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *OrderExecuted) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *OrderExecuted) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *OrderExecuted) GetTradeId() uint64 {
+	if x != nil {
+		return x.TradeId
+	}
+	return 0
+}
+
+type Trade struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TradeId       uint64                 `protobuf:"varint,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	ExecutionId   uint64                 `protobuf:"varint,2,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	Price         string                 `protobuf:"bytes,10,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      string                 `protobuf:"bytes,11,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	MakerOrderId  uint64                 `protobuf:"varint,6,opt,name=maker_order_id,json=makerOrderId,proto3" json:"maker_order_id,omitempty"`
+	TakerOrderId  uint64                 `protobuf:"varint,7,opt,name=taker_order_id,json=takerOrderId,proto3" json:"taker_order_id,omitempty"`
+	Instrument    string                 `protobuf:"bytes,8,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	Side          OrderSide              `protobuf:"varint,9,opt,name=side,proto3,enum=aeromatch.OrderSide" json:"side,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Trade) Reset() {
+	*x = Trade{}
+	mi := &file_api_grpc_order_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trade) ProtoMessage() {}
+
+func (x *Trade) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Trade) GetTradeId() uint64 {
+	if x != nil {
+		return x.TradeId
+	}
+	return 0
+}
+
+func (x *Trade) GetExecutionId() uint64 {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return 0
+}
+
+func (x *Trade) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Trade) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *Trade) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Trade) GetMakerOrderId() uint64 {
+	if x != nil {
+		return x.MakerOrderId
+	}
+	return 0
+}
+
+func (x *Trade) GetTakerOrderId() uint64 {
+	if x != nil {
+		return x.TakerOrderId
+	}
+	return 0
+}
+
+func (x *Trade) GetInstrument() string {
+	if x != nil {
+		return x.Instrument
+	}
+	return ""
+}
+
+func (x *Trade) GetSide() OrderSide {
+	if x != nil {
+		return x.Side
+	}
+	return OrderSide_BUY
+}
+
+// Up to limit of the instrument's newest trades, newest first; zero for all the
+// server keeps
+type RecentTradesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instrument    string                 `protobuf:"bytes,1,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	Limit         uint32                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecentTradesRequest) Reset() {
+	*x = RecentTradesRequest{}
+	mi := &file_api_grpc_order_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecentTradesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecentTradesRequest) ProtoMessage() {}
+
+func (x *RecentTradesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecentTradesRequest.ProtoReflect.Descriptor instead.
+func (*RecentTradesRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RecentTradesRequest) GetInstrument() string {
+	if x != nil {
+		return x.Instrument
+	}
+	return ""
+}
+
+func (x *RecentTradesRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RecentTradesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trades        []*Trade               `protobuf:"bytes,1,rep,name=trades,proto3" json:"trades,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecentTradesResponse) Reset() {
+	*x = RecentTradesResponse{}
+	mi := &file_api_grpc_order_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecentTradesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecentTradesResponse) ProtoMessage() {}
+
+func (x *RecentTradesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecentTradesResponse.ProtoReflect.Descriptor instead.
+func (*RecentTradesResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RecentTradesResponse) GetTrades() []*Trade {
+	if x != nil {
+		return x.Trades
+	}
+	return nil
+}
+
+// Up to limit of the newest candles starting in [start_time, end_time), oldest
+// first. Zero times leave that end open and a zero limit returns every candle the
+// server keeps.
+type CandlesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instrument    string                 `protobuf:"bytes,1,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	Interval      CandleInterval         `protobuf:"varint,2,opt,name=interval,proto3,enum=aeromatch.CandleInterval" json:"interval,omitempty"`
+	StartTime     int64                  `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       int64                  `protobuf:"varint,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Limit         uint32                 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CandlesRequest) Reset() {
+	*x = CandlesRequest{}
+	mi := &file_api_grpc_order_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CandlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CandlesRequest) ProtoMessage() {}
+
+func (x *CandlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CandlesRequest.ProtoReflect.Descriptor instead.
+func (*CandlesRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CandlesRequest) GetInstrument() string {
+	if x != nil {
+		return x.Instrument
+	}
+	return ""
+}
+
+func (x *CandlesRequest) GetInterval() CandleInterval {
+	if x != nil {
+		return x.Interval
+	}
+	return CandleInterval_INTERVAL_1S
+}
+
+func (x *CandlesRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *CandlesRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *CandlesRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type CandlesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Candles       []*Candle              `protobuf:"bytes,1,rep,name=candles,proto3" json:"candles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CandlesResponse) Reset() {
+	*x = CandlesResponse{}
+	mi := &file_api_grpc_order_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CandlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CandlesResponse) ProtoMessage() {}
+
+func (x *CandlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CandlesResponse.ProtoReflect.Descriptor instead.
+func (*CandlesResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CandlesResponse) GetCandles() []*Candle {
+	if x != nil {
+		return x.Candles
+	}
+	return nil
+}
+
+// OHLCV bar. Intervals without trades have no candle. On a market data stream a
+// candle is sent after every trade in it, so the latest one for an interval may
+// still change until one with a later start_time arrives.
+type Candle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instrument    string                 `protobuf:"bytes,1,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	Interval      CandleInterval         `protobuf:"varint,2,opt,name=interval,proto3,enum=aeromatch.CandleInterval" json:"interval,omitempty"`
+	StartTime     int64                  `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	Open          string                 `protobuf:"bytes,4,opt,name=open,proto3" json:"open,omitempty"`
+	High          string                 `protobuf:"bytes,5,opt,name=high,proto3" json:"high,omitempty"`
+	Low           string                 `protobuf:"bytes,6,opt,name=low,proto3" json:"low,omitempty"`
+	Close         string                 `protobuf:"bytes,7,opt,name=close,proto3" json:"close,omitempty"`
+	Volume        string                 `protobuf:"bytes,8,opt,name=volume,proto3" json:"volume,omitempty"`
+	TradeCount    uint32                 `protobuf:"varint,9,opt,name=trade_count,json=tradeCount,proto3" json:"trade_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Candle) Reset() {
+	*x = Candle{}
+	mi := &file_api_grpc_order_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MarketDataRequest) String() string {
+func (x *Candle) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MarketDataRequest) ProtoMessage() {}
+func (*Candle) ProtoMessage() {}
 
-func (x *MarketDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_order_proto_msgTypes[5]
+func (x *Candle) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -602,43 +2822,107 @@ func (x *MarketDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MarketDataRequest.ProtoReflect.Descriptor instead.
-func (*MarketDataRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_order_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use Candle.ProtoReflect.Descriptor instead.
+func (*Candle) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *MarketDataRequest) GetInstrument() string {
+func (x *Candle) GetInstrument() string {
 	if x != nil {
 		return x.Instrument
 	}
 	return ""
 }
 
-type MarketDataUpdate struct {
+func (x *Candle) GetInterval() CandleInterval {
+	if x != nil {
+		return x.Interval
+	}
+	return CandleInterval_INTERVAL_1S
+}
+
+func (x *Candle) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *Candle) GetOpen() string {
+	if x != nil {
+		return x.Open
+	}
+	return ""
+}
+
+func (x *Candle) GetHigh() string {
+	if x != nil {
+		return x.High
+	}
+	return ""
+}
+
+func (x *Candle) GetLow() string {
+	if x != nil {
+		return x.Low
+	}
+	return ""
+}
+
+func (x *Candle) GetClose() string {
+	if x != nil {
+		return x.Close
+	}
+	return ""
+}
+
+func (x *Candle) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+func (x *Candle) GetTradeCount() uint32 {
+	if x != nil {
+		return x.TradeCount
+	}
+	return 0
+}
+
+// Admin messages
+type Instrument struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          MarketDataType         `protobuf:"varint,1,opt,name=type,proto3,enum=aeromatch.MarketDataType" json:"type,omitempty"`
-	Trade         *Trade                 `protobuf:"bytes,2,opt,name=trade,proto3" json:"trade,omitempty"`
-	Orderbook     *OrderBookUpdate       `protobuf:"bytes,3,opt,name=orderbook,proto3" json:"orderbook,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	BaseCurrency  string                 `protobuf:"bytes,2,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty"`
+	QuoteCurrency string                 `protobuf:"bytes,3,opt,name=quote_currency,json=quoteCurrency,proto3" json:"quote_currency,omitempty"`
+	TickSize      string                 `protobuf:"bytes,4,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	LotSize       string                 `protobuf:"bytes,5,opt,name=lot_size,json=lotSize,proto3" json:"lot_size,omitempty"`
+	MinQuantity   string                 `protobuf:"bytes,6,opt,name=min_quantity,json=minQuantity,proto3" json:"min_quantity,omitempty"`
+	MaxQuantity   string                 `protobuf:"bytes,7,opt,name=max_quantity,json=maxQuantity,proto3" json:"max_quantity,omitempty"` // Empty or zero for no limit
+	MinNotional   string                 `protobuf:"bytes,8,opt,name=min_notional,json=minNotional,proto3" json:"min_notional,omitempty"`
+	MinPrice      string                 `protobuf:"bytes,9,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice      string                 `protobuf:"bytes,10,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"` // Empty or zero for no limit
+	Status        InstrumentStatus       `protobuf:"varint,11,opt,name=status,proto3,enum=aeromatch.InstrumentStatus" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MarketDataUpdate) Reset() {
-	*x = MarketDataUpdate{}
-	mi := &file_api_grpc_order_proto_msgTypes[6]
+func (x *Instrument) Reset() {
+	*x = Instrument{}
+	mi := &file_api_grpc_order_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MarketDataUpdate) String() string {
+func (x *Instrument) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MarketDataUpdate) ProtoMessage() {}
+func (*Instrument) ProtoMessage() {}
 
-func (x *MarketDataUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_order_proto_msgTypes[6]
+func (x *Instrument) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -649,62 +2933,110 @@ func (x *MarketDataUpdate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MarketDataUpdate.ProtoReflect.Descriptor instead.
-func (*MarketDataUpdate) Descriptor() ([]byte, []int) {
-	return file_api_grpc_order_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use Instrument.ProtoReflect.Descriptor instead.
+func (*Instrument) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *MarketDataUpdate) GetType() MarketDataType {
+func (x *Instrument) GetSymbol() string {
 	if x != nil {
-		return x.Type
+		return x.Symbol
 	}
-	return MarketDataType_TRADE
+	return ""
 }
 
-func (x *MarketDataUpdate) GetTrade() *Trade {
+func (x *Instrument) GetBaseCurrency() string {
 	if x != nil {
-		return x.Trade
+		return x.BaseCurrency
 	}
-	return nil
+	return ""
 }
 
-func (x *MarketDataUpdate) GetOrderbook() *OrderBookUpdate {
+func (x *Instrument) GetQuoteCurrency() string {
 	if x != nil {
-		return x.Orderbook
+		return x.QuoteCurrency
 	}
-	return nil
+	return ""
 }
 
-func (x *MarketDataUpdate) GetTimestamp() int64 {
+func (x *Instrument) GetTickSize() string {
 	if x != nil {
-		return x.Timestamp
+		return x.TickSize
 	}
-	return 0
+	return ""
 }
 
-type OrderBookUpdate struct {
+func (x *Instrument) GetLotSize() string {
+	if x != nil {
+		return x.LotSize
+	}
+	return ""
+}
+
+func (x *Instrument) GetMinQuantity() string {
+	if x != nil {
+		return x.MinQuantity
+	}
+	return ""
+}
+
+func (x *Instrument) GetMaxQuantity() string {
+	if x != nil {
+		return x.MaxQuantity
+	}
+	return ""
+}
+
+func (x *Instrument) GetMinNotional() string {
+	if x != nil {
+		return x.MinNotional
+	}
+	return ""
+}
+
+func (x *Instrument) GetMinPrice() string {
+	if x != nil {
+		return x.MinPrice
+	}
+	return ""
+}
+
+func (x *Instrument) GetMaxPrice() string {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return ""
+}
+
+func (x *Instrument) GetStatus() InstrumentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return InstrumentStatus_TRADING
+}
+
+type InstrumentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Bids          []*PriceLevel          `protobuf:"bytes,1,rep,name=bids,proto3" json:"bids,omitempty"`
-	Asks          []*PriceLevel          `protobuf:"bytes,2,rep,name=asks,proto3" json:"asks,omitempty"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderBookUpdate) Reset() {
-	*x = OrderBookUpdate{}
-	mi := &file_api_grpc_order_proto_msgTypes[7]
+func (x *InstrumentRequest) Reset() {
+	*x = InstrumentRequest{}
+	mi := &file_api_grpc_order_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderBookUpdate) String() string {
+func (x *InstrumentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderBookUpdate) ProtoMessage() {}
+func (*InstrumentRequest) ProtoMessage() {}
 
-func (x *OrderBookUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_order_proto_msgTypes[7]
+func (x *InstrumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -715,55 +3047,76 @@ func (x *OrderBookUpdate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderBookUpdate.ProtoReflect.Descriptor instead.
-func (*OrderBookUpdate) Descriptor() ([]byte, []int) {
-	return file_api_grpc_order_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use InstrumentRequest.ProtoReflect.Descriptor instead.
+func (*InstrumentRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *OrderBookUpdate) GetBids() []*PriceLevel {
+func (x *InstrumentRequest) GetSymbol() string {
 	if x != nil {
-		return x.Bids
+		return x.Symbol
 	}
-	return nil
+	return ""
 }
 
-func (x *OrderBookUpdate) GetAsks() []*PriceLevel {
+type ListInstrumentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInstrumentsRequest) Reset() {
+	*x = ListInstrumentsRequest{}
+	mi := &file_api_grpc_order_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInstrumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInstrumentsRequest) ProtoMessage() {}
+
+func (x *ListInstrumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[34]
 	if x != nil {
-		return x.Asks
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-type Trade struct {
+// Deprecated: Use ListInstrumentsRequest.ProtoReflect.Descriptor instead.
+func (*ListInstrumentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{34}
+}
+
+type ListInstrumentsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TradeId       uint64                 `protobuf:"varint,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
-	ExecutionId   uint64                 `protobuf:"varint,2,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity      float64                `protobuf:"fixed64,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	MakerOrderId  uint64                 `protobuf:"varint,6,opt,name=maker_order_id,json=makerOrderId,proto3" json:"maker_order_id,omitempty"`
-	TakerOrderId  uint64                 `protobuf:"varint,7,opt,name=taker_order_id,json=takerOrderId,proto3" json:"taker_order_id,omitempty"`
-	Instrument    string                 `protobuf:"bytes,8,opt,name=instrument,proto3" json:"instrument,omitempty"`
-	Side          OrderSide              `protobuf:"varint,9,opt,name=side,proto3,enum=aeromatch.OrderSide" json:"side,omitempty"`
+	Instruments   []*Instrument          `protobuf:"bytes,1,rep,name=instruments,proto3" json:"instruments,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Trade) Reset() {
-	*x = Trade{}
-	mi := &file_api_grpc_order_proto_msgTypes[8]
+func (x *ListInstrumentsResponse) Reset() {
+	*x = ListInstrumentsResponse{}
+	mi := &file_api_grpc_order_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Trade) String() string {
+func (x *ListInstrumentsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Trade) ProtoMessage() {}
+func (*ListInstrumentsResponse) ProtoMessage() {}
 
-func (x *Trade) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_order_proto_msgTypes[8]
+func (x *ListInstrumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -774,144 +3127,335 @@ func (x *Trade) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
-func (*Trade) Descriptor() ([]byte, []int) {
-	return file_api_grpc_order_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ListInstrumentsResponse.ProtoReflect.Descriptor instead.
+func (*ListInstrumentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *Trade) GetTradeId() uint64 {
+func (x *ListInstrumentsResponse) GetInstruments() []*Instrument {
 	if x != nil {
-		return x.TradeId
+		return x.Instruments
 	}
-	return 0
+	return nil
 }
 
-func (x *Trade) GetExecutionId() uint64 {
-	if x != nil {
-		return x.ExecutionId
-	}
-	return 0
+type DelistInstrumentResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Symbol            string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	CancelledOrderIds []uint64               `protobuf:"varint,2,rep,packed,name=cancelled_order_ids,json=cancelledOrderIds,proto3" json:"cancelled_order_ids,omitempty"` // Resting orders cancelled by the delist
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *Trade) GetPrice() float64 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
+func (x *DelistInstrumentResponse) Reset() {
+	*x = DelistInstrumentResponse{}
+	mi := &file_api_grpc_order_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Trade) GetQuantity() float64 {
-	if x != nil {
-		return x.Quantity
-	}
-	return 0
+func (x *DelistInstrumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Trade) GetTimestamp() int64 {
-	if x != nil {
-		return x.Timestamp
-	}
-	return 0
-}
+func (*DelistInstrumentResponse) ProtoMessage() {}
 
-func (x *Trade) GetMakerOrderId() uint64 {
+func (x *DelistInstrumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_order_proto_msgTypes[36]
 	if x != nil {
-		return x.MakerOrderId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Trade) GetTakerOrderId() uint64 {
-	if x != nil {
-		return x.TakerOrderId
-	}
-	return 0
+// Deprecated: Use DelistInstrumentResponse.ProtoReflect.Descriptor instead.
+func (*DelistInstrumentResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_order_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *Trade) GetInstrument() string {
+func (x *DelistInstrumentResponse) GetSymbol() string {
 	if x != nil {
-		return x.Instrument
+		return x.Symbol
 	}
 	return ""
 }
 
-func (x *Trade) GetSide() OrderSide {
+func (x *DelistInstrumentResponse) GetCancelledOrderIds() []uint64 {
 	if x != nil {
-		return x.Side
+		return x.CancelledOrderIds
 	}
-	return OrderSide_BUY
+	return nil
 }
 
 var File_api_grpc_order_proto protoreflect.FileDescriptor
 
 const file_api_grpc_order_proto_rawDesc = "" +
 	"\n" +
-	"\x14api/grpc/order.proto\x12\taeromatch\"\x82\x02\n" +
-	"\fOrderRequest\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\x04R\aorderId\x12&\n" +
+	"\x14api/grpc/order.proto\x12\taeromatch\"\x93\x03\n" +
+	"\fOrderRequest\x12\x1d\n" +
+	"\border_id\x18\x01 \x01(\x04B\x02\x18\x01R\aorderId\x12&\n" +
 	"\x0fclient_order_id\x18\x02 \x01(\tR\rclientOrderId\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x04 \x01(\x01R\bquantity\x123\n" +
+	"\x05price\x18\v \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\f \x01(\tR\bquantity\x123\n" +
 	"\n" +
 	"order_type\x18\x05 \x01(\x0e2\x14.aeromatch.OrderTypeR\torderType\x12(\n" +
 	"\x04side\x18\x06 \x01(\x0e2\x14.aeromatch.OrderSideR\x04side\x12\x1e\n" +
 	"\n" +
 	"instrument\x18\a \x01(\tR\n" +
-	"instrument\"\x8e\x01\n" +
+	"instrument\x12=\n" +
+	"\x0epost_only_mode\x18\b \x01(\x0e2\x17.aeromatch.PostOnlyModeR\fpostOnlyMode\x12\x18\n" +
+	"\aaccount\x18\t \x01(\tR\aaccount\x12&\n" +
+	"\x0fwait_for_result\x18\n" +
+	" \x01(\bR\rwaitForResultJ\x04\b\x03\x10\x04J\x04\b\x04\x10\x05\"\x94\x03\n" +
 	"\rOrderResponse\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\x04R\aorderId\x12.\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x16.aeromatch.OrderStatusR\x06status\x12\x1c\n" +
 	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12\x14\n" +
-	"\x05error\x18\x04 \x01(\tR\x05error\"H\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12<\n" +
+	"\rreject_reason\x18\x05 \x01(\x0e2\x17.aeromatch.RejectReasonR\frejectReason\x12'\n" +
+	"\x0ffilled_quantity\x18\x06 \x01(\tR\x0efilledQuantity\x12-\n" +
+	"\x12remaining_quantity\x18\a \x01(\tR\x11remainingQuantity\x12&\n" +
+	"\x05fills\x18\b \x03(\v2\x10.aeromatch.TradeR\x05fills\x12(\n" +
+	"\x10round_trip_nanos\x18\t \x01(\x03R\x0eroundTripNanos\x12\x1c\n" +
+	"\tduplicate\x18\n" +
+	" \x01(\bR\tduplicate\"i\n" +
+	"\x12CancelOrderRequest\x12\x1e\n" +
+	"\n" +
+	"instrument\x18\x01 \x01(\tR\n" +
+	"instrument\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\x04R\aorderId\x12\x18\n" +
+	"\aaccount\x18\x03 \x01(\tR\aaccount\"\xad\x01\n" +
+	"\x13CancelOrderResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\x12.\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x16.aeromatch.OrderStatusR\x06status\x12-\n" +
+	"\x12remaining_quantity\x18\x03 \x01(\tR\x11remainingQuantity\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\"\x9a\x01\n" +
+	"\x11AmendOrderRequest\x12\x1e\n" +
+	"\n" +
+	"instrument\x18\x01 \x01(\tR\n" +
+	"instrument\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\x04R\aorderId\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\tR\bquantity\x12\x18\n" +
+	"\aaccount\x18\x05 \x01(\tR\aaccount\"\xde\x01\n" +
+	"\x12AmendOrderResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\x12.\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x16.aeromatch.OrderStatusR\x06status\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\tR\bquantity\x12-\n" +
+	"\x12remaining_quantity\x18\x05 \x01(\tR\x11remainingQuantity\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\x03R\ttimestamp\"$\n" +
+	"\x13OrderUpdatesRequestJ\x04\b\x01\x10\x02R\aaccount\"\x9a\x05\n" +
+	"\x0fExecutionReport\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\x12&\n" +
+	"\x0fclient_order_id\x18\x02 \x01(\tR\rclientOrderId\x12\x1e\n" +
+	"\n" +
+	"instrument\x18\x03 \x01(\tR\n" +
+	"instrument\x12(\n" +
+	"\x04side\x18\x04 \x01(\x0e2\x14.aeromatch.OrderSideR\x04side\x12.\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x16.aeromatch.OrderStatusR\x06status\x125\n" +
+	"\n" +
+	"old_status\x18\x06 \x01(\x0e2\x16.aeromatch.OrderStatusR\toldStatus\x12\x14\n" +
+	"\x05price\x18\a \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\b \x01(\tR\bquantity\x12/\n" +
+	"\x13cumulative_quantity\x18\t \x01(\tR\x12cumulativeQuantity\x12-\n" +
+	"\x12remaining_quantity\x18\n" +
+	" \x01(\tR\x11remainingQuantity\x12#\n" +
+	"\rlast_quantity\x18\v \x01(\tR\flastQuantity\x12\x1d\n" +
+	"\n" +
+	"last_price\x18\f \x01(\tR\tlastPrice\x12\x19\n" +
+	"\btrade_id\x18\r \x01(\x04R\atradeId\x12!\n" +
+	"\fexecution_id\x18\x0e \x01(\x04R\vexecutionId\x12<\n" +
+	"\rreject_reason\x18\x0f \x01(\x0e2\x17.aeromatch.RejectReasonR\frejectReason\x12\x1c\n" +
+	"\ttimestamp\x18\x10 \x01(\x03R\ttimestamp\x12#\n" +
+	"\raverage_price\x18\x11 \x01(\tR\faveragePrice\"x\n" +
+	"\x0fGetOrderRequest\x12\x1b\n" +
+	"\border_id\x18\x01 \x01(\x04H\x00R\aorderId\x12(\n" +
+	"\x0fclient_order_id\x18\x02 \x01(\tH\x00R\rclientOrderId\x12\x18\n" +
+	"\aaccount\x18\x03 \x01(\tR\aaccountB\x04\n" +
+	"\x02id\"Q\n" +
+	"\x15ListOpenOrdersRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x1e\n" +
+	"\n" +
+	"instrument\x18\x02 \x01(\tR\n" +
+	"instrument\"G\n" +
+	"\x16ListOpenOrdersResponse\x12-\n" +
+	"\x06orders\x18\x01 \x03(\v2\x15.aeromatch.OrderStateR\x06orders\"\x88\x04\n" +
+	"\n" +
+	"OrderState\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\x12&\n" +
+	"\x0fclient_order_id\x18\x02 \x01(\tR\rclientOrderId\x12\x1e\n" +
+	"\n" +
+	"instrument\x18\x03 \x01(\tR\n" +
+	"instrument\x12\x18\n" +
+	"\aaccount\x18\x04 \x01(\tR\aaccount\x12(\n" +
+	"\x04side\x18\x05 \x01(\x0e2\x14.aeromatch.OrderSideR\x04side\x123\n" +
+	"\n" +
+	"order_type\x18\x06 \x01(\x0e2\x14.aeromatch.OrderTypeR\torderType\x12.\n" +
+	"\x06status\x18\a \x01(\x0e2\x16.aeromatch.OrderStatusR\x06status\x12\x14\n" +
+	"\x05price\x18\b \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\t \x01(\tR\bquantity\x12'\n" +
+	"\x0ffilled_quantity\x18\n" +
+	" \x01(\tR\x0efilledQuantity\x12-\n" +
+	"\x12remaining_quantity\x18\v \x01(\tR\x11remainingQuantity\x12#\n" +
+	"\raverage_price\x18\f \x01(\tR\faveragePrice\x12\x1c\n" +
+	"\ttimestamp\x18\r \x01(\x03R\ttimestamp\x12!\n" +
+	"\flast_updated\x18\x0e \x01(\x03R\vlastUpdated\"^\n" +
 	"\x10OrderBookRequest\x12\x1e\n" +
 	"\n" +
 	"instrument\x18\x01 \x01(\tR\n" +
 	"instrument\x12\x14\n" +
-	"\x05depth\x18\x02 \x01(\rR\x05depth\"\xa7\x01\n" +
+	"\x05depth\x18\x02 \x01(\rR\x05depth\x12\x14\n" +
+	"\x05fresh\x18\x03 \x01(\bR\x05fresh\"\xc3\x01\n" +
 	"\x11OrderBookResponse\x12\x1e\n" +
 	"\n" +
 	"instrument\x18\x01 \x01(\tR\n" +
 	"instrument\x12\x1c\n" +
 	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12)\n" +
 	"\x04bids\x18\x03 \x03(\v2\x15.aeromatch.PriceLevelR\x04bids\x12)\n" +
-	"\x04asks\x18\x04 \x03(\v2\x15.aeromatch.PriceLevelR\x04asks\"_\n" +
+	"\x04asks\x18\x04 \x03(\v2\x15.aeromatch.PriceLevelR\x04asks\x12\x1a\n" +
+	"\bsequence\x18\x05 \x01(\x04R\bsequence\"k\n" +
 	"\n" +
 	"PriceLevel\x12\x14\n" +
-	"\x05price\x18\x01 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x01R\bquantity\x12\x1f\n" +
+	"\x05price\x18\x04 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\tR\bquantity\x12\x1f\n" +
 	"\vorder_count\x18\x03 \x01(\rR\n" +
-	"orderCount\"3\n" +
+	"orderCountJ\x04\b\x01\x10\x02J\x04\b\x02\x10\x03\"y\n" +
 	"\x11MarketDataRequest\x12\x1e\n" +
 	"\n" +
 	"instrument\x18\x01 \x01(\tR\n" +
-	"instrument\"\xc1\x01\n" +
+	"instrument\x12D\n" +
+	"\x10candle_intervals\x18\x02 \x03(\x0e2\x19.aeromatch.CandleIntervalR\x0fcandleIntervals\"\xec\x01\n" +
 	"\x10MarketDataUpdate\x12-\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x19.aeromatch.MarketDataTypeR\x04type\x12&\n" +
 	"\x05trade\x18\x02 \x01(\v2\x10.aeromatch.TradeR\x05trade\x128\n" +
 	"\torderbook\x18\x03 \x01(\v2\x1a.aeromatch.OrderBookUpdateR\torderbook\x12\x1c\n" +
-	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\"g\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12)\n" +
+	"\x06candle\x18\x05 \x01(\v2\x11.aeromatch.CandleR\x06candle\"\x9f\x01\n" +
 	"\x0fOrderBookUpdate\x12)\n" +
 	"\x04bids\x18\x01 \x03(\v2\x15.aeromatch.PriceLevelR\x04bids\x12)\n" +
-	"\x04asks\x18\x02 \x03(\v2\x15.aeromatch.PriceLevelR\x04asks\"\xab\x02\n" +
+	"\x04asks\x18\x02 \x03(\v2\x15.aeromatch.PriceLevelR\x04asks\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x04R\bsequence\x12\x1a\n" +
+	"\bsnapshot\x18\x04 \x01(\bR\bsnapshot\"\xc3\x01\n" +
+	"\bL3Update\x12\x1e\n" +
+	"\n" +
+	"instrument\x18\x01 \x01(\tR\n" +
+	"instrument\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x04R\bsequence\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x121\n" +
+	"\bsnapshot\x18\x04 \x01(\v2\x15.aeromatch.L3SnapshotR\bsnapshot\x12*\n" +
+	"\x06events\x18\x05 \x03(\v2\x12.aeromatch.L3EventR\x06events\"f\n" +
+	"\n" +
+	"L3Snapshot\x12+\n" +
+	"\x04bids\x18\x01 \x03(\v2\x17.aeromatch.RestingOrderR\x04bids\x12+\n" +
+	"\x04asks\x18\x02 \x03(\v2\x17.aeromatch.RestingOrderR\x04asks\"[\n" +
+	"\fRestingOrder\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\tR\bquantity\"\xe6\x01\n" +
+	"\aL3Event\x12-\n" +
+	"\x05added\x18\x01 \x01(\v2\x15.aeromatch.OrderAddedH\x00R\x05added\x126\n" +
+	"\bmodified\x18\x02 \x01(\v2\x18.aeromatch.OrderModifiedH\x00R\bmodified\x123\n" +
+	"\adeleted\x18\x03 \x01(\v2\x17.aeromatch.OrderDeletedH\x00R\adeleted\x126\n" +
+	"\bexecuted\x18\x04 \x01(\v2\x18.aeromatch.OrderExecutedH\x00R\bexecutedB\a\n" +
+	"\x05event\"\x83\x01\n" +
+	"\n" +
+	"OrderAdded\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\x12(\n" +
+	"\x04side\x18\x02 \x01(\x0e2\x14.aeromatch.OrderSideR\x04side\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\tR\bquantity\"F\n" +
+	"\rOrderModified\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\tR\bquantity\")\n" +
+	"\fOrderDeleted\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\"w\n" +
+	"\rOrderExecuted\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\tR\bquantity\x12\x19\n" +
+	"\btrade_id\x18\x04 \x01(\x04R\atradeId\"\xb7\x02\n" +
 	"\x05Trade\x12\x19\n" +
 	"\btrade_id\x18\x01 \x01(\x04R\atradeId\x12!\n" +
 	"\fexecution_id\x18\x02 \x01(\x04R\vexecutionId\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x04 \x01(\x01R\bquantity\x12\x1c\n" +
+	"\x05price\x18\n" +
+	" \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\v \x01(\tR\bquantity\x12\x1c\n" +
 	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\x12$\n" +
 	"\x0emaker_order_id\x18\x06 \x01(\x04R\fmakerOrderId\x12$\n" +
 	"\x0etaker_order_id\x18\a \x01(\x04R\ftakerOrderId\x12\x1e\n" +
 	"\n" +
 	"instrument\x18\b \x01(\tR\n" +
 	"instrument\x12(\n" +
-	"\x04side\x18\t \x01(\x0e2\x14.aeromatch.OrderSideR\x04side*C\n" +
+	"\x04side\x18\t \x01(\x0e2\x14.aeromatch.OrderSideR\x04sideJ\x04\b\x03\x10\x04J\x04\b\x04\x10\x05\"K\n" +
+	"\x13RecentTradesRequest\x12\x1e\n" +
+	"\n" +
+	"instrument\x18\x01 \x01(\tR\n" +
+	"instrument\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\rR\x05limit\"@\n" +
+	"\x14RecentTradesResponse\x12(\n" +
+	"\x06trades\x18\x01 \x03(\v2\x10.aeromatch.TradeR\x06trades\"\xb7\x01\n" +
+	"\x0eCandlesRequest\x12\x1e\n" +
+	"\n" +
+	"instrument\x18\x01 \x01(\tR\n" +
+	"instrument\x125\n" +
+	"\binterval\x18\x02 \x01(\x0e2\x19.aeromatch.CandleIntervalR\binterval\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x04 \x01(\x03R\aendTime\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\rR\x05limit\">\n" +
+	"\x0fCandlesResponse\x12+\n" +
+	"\acandles\x18\x01 \x03(\v2\x11.aeromatch.CandleR\acandles\"\x87\x02\n" +
+	"\x06Candle\x12\x1e\n" +
+	"\n" +
+	"instrument\x18\x01 \x01(\tR\n" +
+	"instrument\x125\n" +
+	"\binterval\x18\x02 \x01(\x0e2\x19.aeromatch.CandleIntervalR\binterval\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\x03R\tstartTime\x12\x12\n" +
+	"\x04open\x18\x04 \x01(\tR\x04open\x12\x12\n" +
+	"\x04high\x18\x05 \x01(\tR\x04high\x12\x10\n" +
+	"\x03low\x18\x06 \x01(\tR\x03low\x12\x14\n" +
+	"\x05close\x18\a \x01(\tR\x05close\x12\x16\n" +
+	"\x06volume\x18\b \x01(\tR\x06volume\x12\x1f\n" +
+	"\vtrade_count\x18\t \x01(\rR\n" +
+	"tradeCount\"\x80\x03\n" +
+	"\n" +
+	"Instrument\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12#\n" +
+	"\rbase_currency\x18\x02 \x01(\tR\fbaseCurrency\x12%\n" +
+	"\x0equote_currency\x18\x03 \x01(\tR\rquoteCurrency\x12\x1b\n" +
+	"\ttick_size\x18\x04 \x01(\tR\btickSize\x12\x19\n" +
+	"\blot_size\x18\x05 \x01(\tR\alotSize\x12!\n" +
+	"\fmin_quantity\x18\x06 \x01(\tR\vminQuantity\x12!\n" +
+	"\fmax_quantity\x18\a \x01(\tR\vmaxQuantity\x12!\n" +
+	"\fmin_notional\x18\b \x01(\tR\vminNotional\x12\x1b\n" +
+	"\tmin_price\x18\t \x01(\tR\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\n" +
+	" \x01(\tR\bmaxPrice\x123\n" +
+	"\x06status\x18\v \x01(\x0e2\x1b.aeromatch.InstrumentStatusR\x06status\"+\n" +
+	"\x11InstrumentRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"\x18\n" +
+	"\x16ListInstrumentsRequest\"R\n" +
+	"\x17ListInstrumentsResponse\x127\n" +
+	"\vinstruments\x18\x01 \x03(\v2\x15.aeromatch.InstrumentR\vinstruments\"b\n" +
+	"\x18DelistInstrumentResponse\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12.\n" +
+	"\x13cancelled_order_ids\x18\x02 \x03(\x04R\x11cancelledOrderIds*C\n" +
 	"\tOrderType\x12\t\n" +
 	"\x05LIMIT\x10\x00\x12\n" +
 	"\n" +
 	"\x06MARKET\x10\x01\x12\a\n" +
 	"\x03IOC\x10\x02\x12\a\n" +
 	"\x03FOK\x10\x03\x12\r\n" +
-	"\tPOST_ONLY\x10\x04*\x1e\n" +
+	"\tPOST_ONLY\x10\x04*9\n" +
+	"\fPostOnlyMode\x12\x14\n" +
+	"\x10POST_ONLY_REJECT\x10\x00\x12\x13\n" +
+	"\x0fPOST_ONLY_SLIDE\x10\x01*\x1e\n" +
 	"\tOrderSide\x12\a\n" +
 	"\x03BUY\x10\x00\x12\b\n" +
 	"\x04SELL\x10\x01*Y\n" +
@@ -921,16 +3465,53 @@ const file_api_grpc_order_proto_rawDesc = "" +
 	"\x06FILLED\x10\x01\x12\x14\n" +
 	"\x10PARTIALLY_FILLED\x10\x02\x12\r\n" +
 	"\tCANCELLED\x10\x03\x12\f\n" +
-	"\bREJECTED\x10\x04*A\n" +
+	"\bREJECTED\x10\x04*\xc2\x01\n" +
+	"\fRejectReason\x12\r\n" +
+	"\tNO_REJECT\x10\x00\x12\x11\n" +
+	"\rINVALID_ORDER\x10\x01\x12\x1a\n" +
+	"\x16INSUFFICIENT_LIQUIDITY\x10\x02\x12\x19\n" +
+	"\x15POST_ONLY_WOULD_CROSS\x10\x03\x12\x15\n" +
+	"\x11INVALID_PRECISION\x10\x04\x12\x1a\n" +
+	"\x16INSTRUMENT_NOT_TRADING\x10\x05\x12\v\n" +
+	"\aEXPIRED\x10\x06\x12\x19\n" +
+	"\x15NOTIONAL_OUT_OF_RANGE\x10\a*9\n" +
+	"\x10InstrumentStatus\x12\v\n" +
+	"\aTRADING\x10\x00\x12\n" +
+	"\n" +
+	"\x06HALTED\x10\x01\x12\f\n" +
+	"\bDELISTED\x10\x02*M\n" +
 	"\x0eMarketDataType\x12\t\n" +
 	"\x05TRADE\x10\x00\x12\x15\n" +
 	"\x11ORDER_BOOK_UPDATE\x10\x01\x12\r\n" +
-	"\tHEARTBEAT\x10\x022\xbb\x02\n" +
+	"\tHEARTBEAT\x10\x02\x12\n" +
+	"\n" +
+	"\x06CANDLE\x10\x03*T\n" +
+	"\x0eCandleInterval\x12\x0f\n" +
+	"\vINTERVAL_1S\x10\x00\x12\x0f\n" +
+	"\vINTERVAL_1M\x10\x01\x12\x0f\n" +
+	"\vINTERVAL_5M\x10\x02\x12\x0f\n" +
+	"\vINTERVAL_1H\x10\x032\xab\a\n" +
 	"\aTrading\x12B\n" +
 	"\vSubmitOrder\x12\x17.aeromatch.OrderRequest\x1a\x18.aeromatch.OrderResponse\"\x00\x12L\n" +
-	"\x11SubmitOrderStream\x12\x17.aeromatch.OrderRequest\x1a\x18.aeromatch.OrderResponse\"\x00(\x010\x01\x12K\n" +
+	"\x11SubmitOrderStream\x12\x17.aeromatch.OrderRequest\x1a\x18.aeromatch.OrderResponse\"\x00(\x010\x01\x12N\n" +
+	"\vCancelOrder\x12\x1d.aeromatch.CancelOrderRequest\x1a\x1e.aeromatch.CancelOrderResponse\"\x00\x12K\n" +
+	"\n" +
+	"AmendOrder\x12\x1c.aeromatch.AmendOrderRequest\x1a\x1d.aeromatch.AmendOrderResponse\"\x00\x12K\n" +
 	"\fGetOrderBook\x12\x1b.aeromatch.OrderBookRequest\x1a\x1c.aeromatch.OrderBookResponse\"\x00\x12Q\n" +
-	"\x10MarketDataStream\x12\x1c.aeromatch.MarketDataRequest\x1a\x1b.aeromatch.MarketDataUpdate\"\x000\x01B\x1fZ\x1dgithub.com/aeromatch/api/grpcb\x06proto3"
+	"\x10MarketDataStream\x12\x1c.aeromatch.MarketDataRequest\x1a\x1b.aeromatch.MarketDataUpdate\"\x000\x01\x12J\n" +
+	"\x11OrderBookL3Stream\x12\x1c.aeromatch.MarketDataRequest\x1a\x13.aeromatch.L3Update\"\x000\x01\x12N\n" +
+	"\fOrderUpdates\x12\x1e.aeromatch.OrderUpdatesRequest\x1a\x1a.aeromatch.ExecutionReport\"\x000\x01\x12?\n" +
+	"\bGetOrder\x12\x1a.aeromatch.GetOrderRequest\x1a\x15.aeromatch.OrderState\"\x00\x12W\n" +
+	"\x0eListOpenOrders\x12 .aeromatch.ListOpenOrdersRequest\x1a!.aeromatch.ListOpenOrdersResponse\"\x00\x12T\n" +
+	"\x0fGetRecentTrades\x12\x1e.aeromatch.RecentTradesRequest\x1a\x1f.aeromatch.RecentTradesResponse\"\x00\x12E\n" +
+	"\n" +
+	"GetCandles\x12\x19.aeromatch.CandlesRequest\x1a\x1a.aeromatch.CandlesResponse\"\x002\x91\x03\n" +
+	"\x05Admin\x12Z\n" +
+	"\x0fListInstruments\x12!.aeromatch.ListInstrumentsRequest\x1a\".aeromatch.ListInstrumentsResponse\"\x00\x12?\n" +
+	"\rAddInstrument\x12\x15.aeromatch.Instrument\x1a\x15.aeromatch.Instrument\"\x00\x12G\n" +
+	"\x0eHaltInstrument\x12\x1c.aeromatch.InstrumentRequest\x1a\x15.aeromatch.Instrument\"\x00\x12I\n" +
+	"\x10ResumeInstrument\x12\x1c.aeromatch.InstrumentRequest\x1a\x15.aeromatch.Instrument\"\x00\x12W\n" +
+	"\x10DelistInstrument\x12\x1c.aeromatch.InstrumentRequest\x1a#.aeromatch.DelistInstrumentResponse\"\x00B\x1fZ\x1dgithub.com/aeromatch/api/grpcb\x06proto3"
 
 var (
 	file_api_grpc_order_proto_rawDescOnce sync.Once
@@ -944,48 +3525,136 @@ func file_api_grpc_order_proto_rawDescGZIP() []byte {
 	return file_api_grpc_order_proto_rawDescData
 }
 
-var file_api_grpc_order_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_api_grpc_order_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_api_grpc_order_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_api_grpc_order_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_api_grpc_order_proto_goTypes = []any{
-	(OrderType)(0),            // 0: aeromatch.OrderType
-	(OrderSide)(0),            // 1: aeromatch.OrderSide
-	(OrderStatus)(0),          // 2: aeromatch.OrderStatus
-	(MarketDataType)(0),       // 3: aeromatch.MarketDataType
-	(*OrderRequest)(nil),      // 4: aeromatch.OrderRequest
-	(*OrderResponse)(nil),     // 5: aeromatch.OrderResponse
-	(*OrderBookRequest)(nil),  // 6: aeromatch.OrderBookRequest
-	(*OrderBookResponse)(nil), // 7: aeromatch.OrderBookResponse
-	(*PriceLevel)(nil),        // 8: aeromatch.PriceLevel
-	(*MarketDataRequest)(nil), // 9: aeromatch.MarketDataRequest
-	(*MarketDataUpdate)(nil),  // 10: aeromatch.MarketDataUpdate
-	(*OrderBookUpdate)(nil),   // 11: aeromatch.OrderBookUpdate
-	(*Trade)(nil),             // 12: aeromatch.Trade
+	(OrderType)(0),                   // 0: aeromatch.OrderType
+	(PostOnlyMode)(0),                // 1: aeromatch.PostOnlyMode
+	(OrderSide)(0),                   // 2: aeromatch.OrderSide
+	(OrderStatus)(0),                 // 3: aeromatch.OrderStatus
+	(RejectReason)(0),                // 4: aeromatch.RejectReason
+	(InstrumentStatus)(0),            // 5: aeromatch.InstrumentStatus
+	(MarketDataType)(0),              // 6: aeromatch.MarketDataType
+	(CandleInterval)(0),              // 7: aeromatch.CandleInterval
+	(*OrderRequest)(nil),             // 8: aeromatch.OrderRequest
+	(*OrderResponse)(nil),            // 9: aeromatch.OrderResponse
+	(*CancelOrderRequest)(nil),       // 10: aeromatch.CancelOrderRequest
+	(*CancelOrderResponse)(nil),      // 11: aeromatch.CancelOrderResponse
+	(*AmendOrderRequest)(nil),        // 12: aeromatch.AmendOrderRequest
+	(*AmendOrderResponse)(nil),       // 13: aeromatch.AmendOrderResponse
+	(*OrderUpdatesRequest)(nil),      // 14: aeromatch.OrderUpdatesRequest
+	(*ExecutionReport)(nil),          // 15: aeromatch.ExecutionReport
+	(*GetOrderRequest)(nil),          // 16: aeromatch.GetOrderRequest
+	(*ListOpenOrdersRequest)(nil),    // 17: aeromatch.ListOpenOrdersRequest
+	(*ListOpenOrdersResponse)(nil),   // 18: aeromatch.ListOpenOrdersResponse
+	(*OrderState)(nil),               // 19: aeromatch.OrderState
+	(*OrderBookRequest)(nil),         // 20: aeromatch.OrderBookRequest
+	(*OrderBookResponse)(nil),        // 21: aeromatch.OrderBookResponse
+	(*PriceLevel)(nil),               // 22: aeromatch.PriceLevel
+	(*MarketDataRequest)(nil),        // 23: aeromatch.MarketDataRequest
+	(*MarketDataUpdate)(nil),         // 24: aeromatch.MarketDataUpdate
+	(*OrderBookUpdate)(nil),          // 25: aeromatch.OrderBookUpdate
+	(*L3Update)(nil),                 // 26: aeromatch.L3Update
+	(*L3Snapshot)(nil),               // 27: aeromatch.L3Snapshot
+	(*RestingOrder)(nil),             // 28: aeromatch.RestingOrder
+	(*L3Event)(nil),                  // 29: aeromatch.L3Event
+	(*OrderAdded)(nil),               // 30: aeromatch.OrderAdded
+	(*OrderModified)(nil),            // 31: aeromatch.OrderModified
+	(*OrderDeleted)(nil),             // 32: aeromatch.OrderDeleted
+	(*OrderExecuted)(nil),            // 33: aeromatch.OrderExecuted
+	(*Trade)(nil),                    // 34: aeromatch.Trade
+	(*RecentTradesRequest)(nil),      // 35: aeromatch.RecentTradesRequest
+	(*RecentTradesResponse)(nil),     // 36: aeromatch.RecentTradesResponse
+	(*CandlesRequest)(nil),           // 37: aeromatch.CandlesRequest
+	(*CandlesResponse)(nil),          // 38: aeromatch.CandlesResponse
+	(*Candle)(nil),                   // 39: aeromatch.Candle
+	(*Instrument)(nil),               // 40: aeromatch.Instrument
+	(*InstrumentRequest)(nil),        // 41: aeromatch.InstrumentRequest
+	(*ListInstrumentsRequest)(nil),   // 42: aeromatch.ListInstrumentsRequest
+	(*ListInstrumentsResponse)(nil),  // 43: aeromatch.ListInstrumentsResponse
+	(*DelistInstrumentResponse)(nil), // 44: aeromatch.DelistInstrumentResponse
 }
 var file_api_grpc_order_proto_depIdxs = []int32{
 	0,  // 0: aeromatch.OrderRequest.order_type:type_name -> aeromatch.OrderType
-	1,  // 1: aeromatch.OrderRequest.side:type_name -> aeromatch.OrderSide
-	2,  // 2: aeromatch.OrderResponse.status:type_name -> aeromatch.OrderStatus
-	8,  // 3: aeromatch.OrderBookResponse.bids:type_name -> aeromatch.PriceLevel
-	8,  // 4: aeromatch.OrderBookResponse.asks:type_name -> aeromatch.PriceLevel
-	3,  // 5: aeromatch.MarketDataUpdate.type:type_name -> aeromatch.MarketDataType
-	12, // 6: aeromatch.MarketDataUpdate.trade:type_name -> aeromatch.Trade
-	11, // 7: aeromatch.MarketDataUpdate.orderbook:type_name -> aeromatch.OrderBookUpdate
-	8,  // 8: aeromatch.OrderBookUpdate.bids:type_name -> aeromatch.PriceLevel
-	8,  // 9: aeromatch.OrderBookUpdate.asks:type_name -> aeromatch.PriceLevel
-	1,  // 10: aeromatch.Trade.side:type_name -> aeromatch.OrderSide
-	4,  // 11: aeromatch.Trading.SubmitOrder:input_type -> aeromatch.OrderRequest
-	4,  // 12: aeromatch.Trading.SubmitOrderStream:input_type -> aeromatch.OrderRequest
-	6,  // 13: aeromatch.Trading.GetOrderBook:input_type -> aeromatch.OrderBookRequest
-	9,  // 14: aeromatch.Trading.MarketDataStream:input_type -> aeromatch.MarketDataRequest
-	5,  // 15: aeromatch.Trading.SubmitOrder:output_type -> aeromatch.OrderResponse
-	5,  // 16: aeromatch.Trading.SubmitOrderStream:output_type -> aeromatch.OrderResponse
-	7,  // 17: aeromatch.Trading.GetOrderBook:output_type -> aeromatch.OrderBookResponse
-	10, // 18: aeromatch.Trading.MarketDataStream:output_type -> aeromatch.MarketDataUpdate
-	15, // [15:19] is the sub-list for method output_type
-	11, // [11:15] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	2,  // 1: aeromatch.OrderRequest.side:type_name -> aeromatch.OrderSide
+	1,  // 2: aeromatch.OrderRequest.post_only_mode:type_name -> aeromatch.PostOnlyMode
+	3,  // 3: aeromatch.OrderResponse.status:type_name -> aeromatch.OrderStatus
+	4,  // 4: aeromatch.OrderResponse.reject_reason:type_name -> aeromatch.RejectReason
+	34, // 5: aeromatch.OrderResponse.fills:type_name -> aeromatch.Trade
+	3,  // 6: aeromatch.CancelOrderResponse.status:type_name -> aeromatch.OrderStatus
+	3,  // 7: aeromatch.AmendOrderResponse.status:type_name -> aeromatch.OrderStatus
+	2,  // 8: aeromatch.ExecutionReport.side:type_name -> aeromatch.OrderSide
+	3,  // 9: aeromatch.ExecutionReport.status:type_name -> aeromatch.OrderStatus
+	3,  // 10: aeromatch.ExecutionReport.old_status:type_name -> aeromatch.OrderStatus
+	4,  // 11: aeromatch.ExecutionReport.reject_reason:type_name -> aeromatch.RejectReason
+	19, // 12: aeromatch.ListOpenOrdersResponse.orders:type_name -> aeromatch.OrderState
+	2,  // 13: aeromatch.OrderState.side:type_name -> aeromatch.OrderSide
+	0,  // 14: aeromatch.OrderState.order_type:type_name -> aeromatch.OrderType
+	3,  // 15: aeromatch.OrderState.status:type_name -> aeromatch.OrderStatus
+	22, // 16: aeromatch.OrderBookResponse.bids:type_name -> aeromatch.PriceLevel
+	22, // 17: aeromatch.OrderBookResponse.asks:type_name -> aeromatch.PriceLevel
+	7,  // 18: aeromatch.MarketDataRequest.candle_intervals:type_name -> aeromatch.CandleInterval
+	6,  // 19: aeromatch.MarketDataUpdate.type:type_name -> aeromatch.MarketDataType
+	34, // 20: aeromatch.MarketDataUpdate.trade:type_name -> aeromatch.Trade
+	25, // 21: aeromatch.MarketDataUpdate.orderbook:type_name -> aeromatch.OrderBookUpdate
+	39, // 22: aeromatch.MarketDataUpdate.candle:type_name -> aeromatch.Candle
+	22, // 23: aeromatch.OrderBookUpdate.bids:type_name -> aeromatch.PriceLevel
+	22, // 24: aeromatch.OrderBookUpdate.asks:type_name -> aeromatch.PriceLevel
+	27, // 25: aeromatch.L3Update.snapshot:type_name -> aeromatch.L3Snapshot
+	29, // 26: aeromatch.L3Update.events:type_name -> aeromatch.L3Event
+	28, // 27: aeromatch.L3Snapshot.bids:type_name -> aeromatch.RestingOrder
+	28, // 28: aeromatch.L3Snapshot.asks:type_name -> aeromatch.RestingOrder
+	30, // 29: aeromatch.L3Event.added:type_name -> aeromatch.OrderAdded
+	31, // 30: aeromatch.L3Event.modified:type_name -> aeromatch.OrderModified
+	32, // 31: aeromatch.L3Event.deleted:type_name -> aeromatch.OrderDeleted
+	33, // 32: aeromatch.L3Event.executed:type_name -> aeromatch.OrderExecuted
+	2,  // 33: aeromatch.OrderAdded.side:type_name -> aeromatch.OrderSide
+	2,  // 34: aeromatch.Trade.side:type_name -> aeromatch.OrderSide
+	34, // 35: aeromatch.RecentTradesResponse.trades:type_name -> aeromatch.Trade
+	7,  // 36: aeromatch.CandlesRequest.interval:type_name -> aeromatch.CandleInterval
+	39, // 37: aeromatch.CandlesResponse.candles:type_name -> aeromatch.Candle
+	7,  // 38: aeromatch.Candle.interval:type_name -> aeromatch.CandleInterval
+	5,  // 39: aeromatch.Instrument.status:type_name -> aeromatch.InstrumentStatus
+	40, // 40: aeromatch.ListInstrumentsResponse.instruments:type_name -> aeromatch.Instrument
+	8,  // 41: aeromatch.Trading.SubmitOrder:input_type -> aeromatch.OrderRequest
+	8,  // 42: aeromatch.Trading.SubmitOrderStream:input_type -> aeromatch.OrderRequest
+	10, // 43: aeromatch.Trading.CancelOrder:input_type -> aeromatch.CancelOrderRequest
+	12, // 44: aeromatch.Trading.AmendOrder:input_type -> aeromatch.AmendOrderRequest
+	20, // 45: aeromatch.Trading.GetOrderBook:input_type -> aeromatch.OrderBookRequest
+	23, // 46: aeromatch.Trading.MarketDataStream:input_type -> aeromatch.MarketDataRequest
+	23, // 47: aeromatch.Trading.OrderBookL3Stream:input_type -> aeromatch.MarketDataRequest
+	14, // 48: aeromatch.Trading.OrderUpdates:input_type -> aeromatch.OrderUpdatesRequest
+	16, // 49: aeromatch.Trading.GetOrder:input_type -> aeromatch.GetOrderRequest
+	17, // 50: aeromatch.Trading.ListOpenOrders:input_type -> aeromatch.ListOpenOrdersRequest
+	35, // 51: aeromatch.Trading.GetRecentTrades:input_type -> aeromatch.RecentTradesRequest
+	37, // 52: aeromatch.Trading.GetCandles:input_type -> aeromatch.CandlesRequest
+	42, // 53: aeromatch.Admin.ListInstruments:input_type -> aeromatch.ListInstrumentsRequest
+	40, // 54: aeromatch.Admin.AddInstrument:input_type -> aeromatch.Instrument
+	41, // 55: aeromatch.Admin.HaltInstrument:input_type -> aeromatch.InstrumentRequest
+	41, // 56: aeromatch.Admin.ResumeInstrument:input_type -> aeromatch.InstrumentRequest
+	41, // 57: aeromatch.Admin.DelistInstrument:input_type -> aeromatch.InstrumentRequest
+	9,  // 58: aeromatch.Trading.SubmitOrder:output_type -> aeromatch.OrderResponse
+	9,  // 59: aeromatch.Trading.SubmitOrderStream:output_type -> aeromatch.OrderResponse
+	11, // 60: aeromatch.Trading.CancelOrder:output_type -> aeromatch.CancelOrderResponse
+	13, // 61: aeromatch.Trading.AmendOrder:output_type -> aeromatch.AmendOrderResponse
+	21, // 62: aeromatch.Trading.GetOrderBook:output_type -> aeromatch.OrderBookResponse
+	24, // 63: aeromatch.Trading.MarketDataStream:output_type -> aeromatch.MarketDataUpdate
+	26, // 64: aeromatch.Trading.OrderBookL3Stream:output_type -> aeromatch.L3Update
+	15, // 65: aeromatch.Trading.OrderUpdates:output_type -> aeromatch.ExecutionReport
+	19, // 66: aeromatch.Trading.GetOrder:output_type -> aeromatch.OrderState
+	18, // 67: aeromatch.Trading.ListOpenOrders:output_type -> aeromatch.ListOpenOrdersResponse
+	36, // 68: aeromatch.Trading.GetRecentTrades:output_type -> aeromatch.RecentTradesResponse
+	38, // 69: aeromatch.Trading.GetCandles:output_type -> aeromatch.CandlesResponse
+	43, // 70: aeromatch.Admin.ListInstruments:output_type -> aeromatch.ListInstrumentsResponse
+	40, // 71: aeromatch.Admin.AddInstrument:output_type -> aeromatch.Instrument
+	40, // 72: aeromatch.Admin.HaltInstrument:output_type -> aeromatch.Instrument
+	40, // 73: aeromatch.Admin.ResumeInstrument:output_type -> aeromatch.Instrument
+	44, // 74: aeromatch.Admin.DelistInstrument:output_type -> aeromatch.DelistInstrumentResponse
+	58, // [58:75] is the sub-list for method output_type
+	41, // [41:58] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
 }
 
 func init() { file_api_grpc_order_proto_init() }
@@ -993,15 +3662,25 @@ func file_api_grpc_order_proto_init() {
 	if File_api_grpc_order_proto != nil {
 		return
 	}
+	file_api_grpc_order_proto_msgTypes[8].OneofWrappers = []any{
+		(*GetOrderRequest_OrderId)(nil),
+		(*GetOrderRequest_ClientOrderId)(nil),
+	}
+	file_api_grpc_order_proto_msgTypes[21].OneofWrappers = []any{
+		(*L3Event_Added)(nil),
+		(*L3Event_Modified)(nil),
+		(*L3Event_Deleted)(nil),
+		(*L3Event_Executed)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_grpc_order_proto_rawDesc), len(file_api_grpc_order_proto_rawDesc)),
-			NumEnums:      4,
-			NumMessages:   9,
+			NumEnums:      8,
+			NumMessages:   37,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_api_grpc_order_proto_goTypes,
 		DependencyIndexes: file_api_grpc_order_proto_depIdxs,
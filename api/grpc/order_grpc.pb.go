@@ -21,8 +21,16 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	Trading_SubmitOrder_FullMethodName       = "/aeromatch.Trading/SubmitOrder"
 	Trading_SubmitOrderStream_FullMethodName = "/aeromatch.Trading/SubmitOrderStream"
+	Trading_CancelOrder_FullMethodName       = "/aeromatch.Trading/CancelOrder"
+	Trading_AmendOrder_FullMethodName        = "/aeromatch.Trading/AmendOrder"
 	Trading_GetOrderBook_FullMethodName      = "/aeromatch.Trading/GetOrderBook"
 	Trading_MarketDataStream_FullMethodName  = "/aeromatch.Trading/MarketDataStream"
+	Trading_OrderBookL3Stream_FullMethodName = "/aeromatch.Trading/OrderBookL3Stream"
+	Trading_OrderUpdates_FullMethodName      = "/aeromatch.Trading/OrderUpdates"
+	Trading_GetOrder_FullMethodName          = "/aeromatch.Trading/GetOrder"
+	Trading_ListOpenOrders_FullMethodName    = "/aeromatch.Trading/ListOpenOrders"
+	Trading_GetRecentTrades_FullMethodName   = "/aeromatch.Trading/GetRecentTrades"
+	Trading_GetCandles_FullMethodName        = "/aeromatch.Trading/GetCandles"
 )
 
 // TradingClient is the client API for Trading service.
@@ -31,8 +39,16 @@ const (
 type TradingClient interface {
 	SubmitOrder(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*OrderResponse, error)
 	SubmitOrderStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[OrderRequest, OrderResponse], error)
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
+	AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (*AmendOrderResponse, error)
 	GetOrderBook(ctx context.Context, in *OrderBookRequest, opts ...grpc.CallOption) (*OrderBookResponse, error)
 	MarketDataStream(ctx context.Context, in *MarketDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MarketDataUpdate], error)
+	OrderBookL3Stream(ctx context.Context, in *MarketDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[L3Update], error)
+	OrderUpdates(ctx context.Context, in *OrderUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecutionReport], error)
+	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*OrderState, error)
+	ListOpenOrders(ctx context.Context, in *ListOpenOrdersRequest, opts ...grpc.CallOption) (*ListOpenOrdersResponse, error)
+	GetRecentTrades(ctx context.Context, in *RecentTradesRequest, opts ...grpc.CallOption) (*RecentTradesResponse, error)
+	GetCandles(ctx context.Context, in *CandlesRequest, opts ...grpc.CallOption) (*CandlesResponse, error)
 }
 
 type tradingClient struct {
@@ -66,6 +82,26 @@ func (c *tradingClient) SubmitOrderStream(ctx context.Context, opts ...grpc.Call
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Trading_SubmitOrderStreamClient = grpc.BidiStreamingClient[OrderRequest, OrderResponse]
 
+func (c *tradingClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelOrderResponse)
+	err := c.cc.Invoke(ctx, Trading_CancelOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradingClient) AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (*AmendOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AmendOrderResponse)
+	err := c.cc.Invoke(ctx, Trading_AmendOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tradingClient) GetOrderBook(ctx context.Context, in *OrderBookRequest, opts ...grpc.CallOption) (*OrderBookResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(OrderBookResponse)
@@ -95,14 +131,100 @@ func (c *tradingClient) MarketDataStream(ctx context.Context, in *MarketDataRequ
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Trading_MarketDataStreamClient = grpc.ServerStreamingClient[MarketDataUpdate]
 
+func (c *tradingClient) OrderBookL3Stream(ctx context.Context, in *MarketDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[L3Update], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Trading_ServiceDesc.Streams[2], Trading_OrderBookL3Stream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[MarketDataRequest, L3Update]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Trading_OrderBookL3StreamClient = grpc.ServerStreamingClient[L3Update]
+
+func (c *tradingClient) OrderUpdates(ctx context.Context, in *OrderUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecutionReport], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Trading_ServiceDesc.Streams[3], Trading_OrderUpdates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[OrderUpdatesRequest, ExecutionReport]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Trading_OrderUpdatesClient = grpc.ServerStreamingClient[ExecutionReport]
+
+func (c *tradingClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*OrderState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderState)
+	err := c.cc.Invoke(ctx, Trading_GetOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradingClient) ListOpenOrders(ctx context.Context, in *ListOpenOrdersRequest, opts ...grpc.CallOption) (*ListOpenOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOpenOrdersResponse)
+	err := c.cc.Invoke(ctx, Trading_ListOpenOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradingClient) GetRecentTrades(ctx context.Context, in *RecentTradesRequest, opts ...grpc.CallOption) (*RecentTradesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecentTradesResponse)
+	err := c.cc.Invoke(ctx, Trading_GetRecentTrades_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradingClient) GetCandles(ctx context.Context, in *CandlesRequest, opts ...grpc.CallOption) (*CandlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CandlesResponse)
+	err := c.cc.Invoke(ctx, Trading_GetCandles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TradingServer is the server API for Trading service.
 // All implementations must embed UnimplementedTradingServer
 // for forward compatibility.
 type TradingServer interface {
 	SubmitOrder(context.Context, *OrderRequest) (*OrderResponse, error)
 	SubmitOrderStream(grpc.BidiStreamingServer[OrderRequest, OrderResponse]) error
+	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error)
+	AmendOrder(context.Context, *AmendOrderRequest) (*AmendOrderResponse, error)
 	GetOrderBook(context.Context, *OrderBookRequest) (*OrderBookResponse, error)
 	MarketDataStream(*MarketDataRequest, grpc.ServerStreamingServer[MarketDataUpdate]) error
+	OrderBookL3Stream(*MarketDataRequest, grpc.ServerStreamingServer[L3Update]) error
+	OrderUpdates(*OrderUpdatesRequest, grpc.ServerStreamingServer[ExecutionReport]) error
+	GetOrder(context.Context, *GetOrderRequest) (*OrderState, error)
+	ListOpenOrders(context.Context, *ListOpenOrdersRequest) (*ListOpenOrdersResponse, error)
+	GetRecentTrades(context.Context, *RecentTradesRequest) (*RecentTradesResponse, error)
+	GetCandles(context.Context, *CandlesRequest) (*CandlesResponse, error)
 	mustEmbedUnimplementedTradingServer()
 }
 
@@ -119,12 +241,36 @@ func (UnimplementedTradingServer) SubmitOrder(context.Context, *OrderRequest) (*
 func (UnimplementedTradingServer) SubmitOrderStream(grpc.BidiStreamingServer[OrderRequest, OrderResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method SubmitOrderStream not implemented")
 }
+func (UnimplementedTradingServer) CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedTradingServer) AmendOrder(context.Context, *AmendOrderRequest) (*AmendOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AmendOrder not implemented")
+}
 func (UnimplementedTradingServer) GetOrderBook(context.Context, *OrderBookRequest) (*OrderBookResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrderBook not implemented")
 }
 func (UnimplementedTradingServer) MarketDataStream(*MarketDataRequest, grpc.ServerStreamingServer[MarketDataUpdate]) error {
 	return status.Errorf(codes.Unimplemented, "method MarketDataStream not implemented")
 }
+func (UnimplementedTradingServer) OrderBookL3Stream(*MarketDataRequest, grpc.ServerStreamingServer[L3Update]) error {
+	return status.Errorf(codes.Unimplemented, "method OrderBookL3Stream not implemented")
+}
+func (UnimplementedTradingServer) OrderUpdates(*OrderUpdatesRequest, grpc.ServerStreamingServer[ExecutionReport]) error {
+	return status.Errorf(codes.Unimplemented, "method OrderUpdates not implemented")
+}
+func (UnimplementedTradingServer) GetOrder(context.Context, *GetOrderRequest) (*OrderState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (UnimplementedTradingServer) ListOpenOrders(context.Context, *ListOpenOrdersRequest) (*ListOpenOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOpenOrders not implemented")
+}
+func (UnimplementedTradingServer) GetRecentTrades(context.Context, *RecentTradesRequest) (*RecentTradesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecentTrades not implemented")
+}
+func (UnimplementedTradingServer) GetCandles(context.Context, *CandlesRequest) (*CandlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCandles not implemented")
+}
 func (UnimplementedTradingServer) mustEmbedUnimplementedTradingServer() {}
 func (UnimplementedTradingServer) testEmbeddedByValue()                 {}
 
@@ -171,6 +317,42 @@ func _Trading_SubmitOrderStream_Handler(srv interface{}, stream grpc.ServerStrea
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Trading_SubmitOrderStreamServer = grpc.BidiStreamingServer[OrderRequest, OrderResponse]
 
+func _Trading_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradingServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Trading_CancelOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradingServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trading_AmendOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AmendOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradingServer).AmendOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Trading_AmendOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradingServer).AmendOrder(ctx, req.(*AmendOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Trading_GetOrderBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(OrderBookRequest)
 	if err := dec(in); err != nil {
@@ -200,6 +382,100 @@ func _Trading_MarketDataStream_Handler(srv interface{}, stream grpc.ServerStream
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Trading_MarketDataStreamServer = grpc.ServerStreamingServer[MarketDataUpdate]
 
+func _Trading_OrderBookL3Stream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MarketDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TradingServer).OrderBookL3Stream(m, &grpc.GenericServerStream[MarketDataRequest, L3Update]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Trading_OrderBookL3StreamServer = grpc.ServerStreamingServer[L3Update]
+
+func _Trading_OrderUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(OrderUpdatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TradingServer).OrderUpdates(m, &grpc.GenericServerStream[OrderUpdatesRequest, ExecutionReport]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Trading_OrderUpdatesServer = grpc.ServerStreamingServer[ExecutionReport]
+
+func _Trading_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradingServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Trading_GetOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradingServer).GetOrder(ctx, req.(*GetOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trading_ListOpenOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOpenOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradingServer).ListOpenOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Trading_ListOpenOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradingServer).ListOpenOrders(ctx, req.(*ListOpenOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trading_GetRecentTrades_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecentTradesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradingServer).GetRecentTrades(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Trading_GetRecentTrades_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradingServer).GetRecentTrades(ctx, req.(*RecentTradesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trading_GetCandles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CandlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradingServer).GetCandles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Trading_GetCandles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradingServer).GetCandles(ctx, req.(*CandlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Trading_ServiceDesc is the grpc.ServiceDesc for Trading service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -211,10 +487,34 @@ var Trading_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SubmitOrder",
 			Handler:    _Trading_SubmitOrder_Handler,
 		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _Trading_CancelOrder_Handler,
+		},
+		{
+			MethodName: "AmendOrder",
+			Handler:    _Trading_AmendOrder_Handler,
+		},
 		{
 			MethodName: "GetOrderBook",
 			Handler:    _Trading_GetOrderBook_Handler,
 		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _Trading_GetOrder_Handler,
+		},
+		{
+			MethodName: "ListOpenOrders",
+			Handler:    _Trading_ListOpenOrders_Handler,
+		},
+		{
+			MethodName: "GetRecentTrades",
+			Handler:    _Trading_GetRecentTrades_Handler,
+		},
+		{
+			MethodName: "GetCandles",
+			Handler:    _Trading_GetCandles_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -228,6 +528,274 @@ var Trading_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Trading_MarketDataStream_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "OrderBookL3Stream",
+			Handler:       _Trading_OrderBookL3Stream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "OrderUpdates",
+			Handler:       _Trading_OrderUpdates_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/grpc/order.proto",
+}
+
+const (
+	Admin_ListInstruments_FullMethodName  = "/aeromatch.Admin/ListInstruments"
+	Admin_AddInstrument_FullMethodName    = "/aeromatch.Admin/AddInstrument"
+	Admin_HaltInstrument_FullMethodName   = "/aeromatch.Admin/HaltInstrument"
+	Admin_ResumeInstrument_FullMethodName = "/aeromatch.Admin/ResumeInstrument"
+	Admin_DelistInstrument_FullMethodName = "/aeromatch.Admin/DelistInstrument"
+)
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Instrument lifecycle on a running engine
+type AdminClient interface {
+	ListInstruments(ctx context.Context, in *ListInstrumentsRequest, opts ...grpc.CallOption) (*ListInstrumentsResponse, error)
+	AddInstrument(ctx context.Context, in *Instrument, opts ...grpc.CallOption) (*Instrument, error)
+	HaltInstrument(ctx context.Context, in *InstrumentRequest, opts ...grpc.CallOption) (*Instrument, error)
+	ResumeInstrument(ctx context.Context, in *InstrumentRequest, opts ...grpc.CallOption) (*Instrument, error)
+	DelistInstrument(ctx context.Context, in *InstrumentRequest, opts ...grpc.CallOption) (*DelistInstrumentResponse, error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) ListInstruments(ctx context.Context, in *ListInstrumentsRequest, opts ...grpc.CallOption) (*ListInstrumentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInstrumentsResponse)
+	err := c.cc.Invoke(ctx, Admin_ListInstruments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) AddInstrument(ctx context.Context, in *Instrument, opts ...grpc.CallOption) (*Instrument, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Instrument)
+	err := c.cc.Invoke(ctx, Admin_AddInstrument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) HaltInstrument(ctx context.Context, in *InstrumentRequest, opts ...grpc.CallOption) (*Instrument, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Instrument)
+	err := c.cc.Invoke(ctx, Admin_HaltInstrument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ResumeInstrument(ctx context.Context, in *InstrumentRequest, opts ...grpc.CallOption) (*Instrument, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Instrument)
+	err := c.cc.Invoke(ctx, Admin_ResumeInstrument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) DelistInstrument(ctx context.Context, in *InstrumentRequest, opts ...grpc.CallOption) (*DelistInstrumentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DelistInstrumentResponse)
+	err := c.cc.Invoke(ctx, Admin_DelistInstrument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+// All implementations must embed UnimplementedAdminServer
+// for forward compatibility.
+//
+// Instrument lifecycle on a running engine
+type AdminServer interface {
+	ListInstruments(context.Context, *ListInstrumentsRequest) (*ListInstrumentsResponse, error)
+	AddInstrument(context.Context, *Instrument) (*Instrument, error)
+	HaltInstrument(context.Context, *InstrumentRequest) (*Instrument, error)
+	ResumeInstrument(context.Context, *InstrumentRequest) (*Instrument, error)
+	DelistInstrument(context.Context, *InstrumentRequest) (*DelistInstrumentResponse, error)
+	mustEmbedUnimplementedAdminServer()
+}
+
+// UnimplementedAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServer struct{}
+
+func (UnimplementedAdminServer) ListInstruments(context.Context, *ListInstrumentsRequest) (*ListInstrumentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInstruments not implemented")
+}
+func (UnimplementedAdminServer) AddInstrument(context.Context, *Instrument) (*Instrument, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddInstrument not implemented")
+}
+func (UnimplementedAdminServer) HaltInstrument(context.Context, *InstrumentRequest) (*Instrument, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HaltInstrument not implemented")
+}
+func (UnimplementedAdminServer) ResumeInstrument(context.Context, *InstrumentRequest) (*Instrument, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeInstrument not implemented")
+}
+func (UnimplementedAdminServer) DelistInstrument(context.Context, *InstrumentRequest) (*DelistInstrumentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DelistInstrument not implemented")
+}
+func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
+func (UnimplementedAdminServer) testEmbeddedByValue()               {}
+
+// UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServer will
+// result in compilation errors.
+type UnsafeAdminServer interface {
+	mustEmbedUnimplementedAdminServer()
+}
+
+func RegisterAdminServer(s grpc.ServiceRegistrar, srv AdminServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Admin_ServiceDesc, srv)
+}
+
+func _Admin_ListInstruments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInstrumentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListInstruments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ListInstruments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListInstruments(ctx, req.(*ListInstrumentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_AddInstrument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Instrument)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).AddInstrument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_AddInstrument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).AddInstrument(ctx, req.(*Instrument))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_HaltInstrument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstrumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).HaltInstrument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_HaltInstrument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).HaltInstrument(ctx, req.(*InstrumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ResumeInstrument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstrumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ResumeInstrument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ResumeInstrument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ResumeInstrument(ctx, req.(*InstrumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_DelistInstrument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstrumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).DelistInstrument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_DelistInstrument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).DelistInstrument(ctx, req.(*InstrumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Admin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "aeromatch.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListInstruments",
+			Handler:    _Admin_ListInstruments_Handler,
+		},
+		{
+			MethodName: "AddInstrument",
+			Handler:    _Admin_AddInstrument_Handler,
+		},
+		{
+			MethodName: "HaltInstrument",
+			Handler:    _Admin_HaltInstrument_Handler,
+		},
+		{
+			MethodName: "ResumeInstrument",
+			Handler:    _Admin_ResumeInstrument_Handler,
+		},
+		{
+			MethodName: "DelistInstrument",
+			Handler:    _Admin_DelistInstrument_Handler,
+		},
 	},
+	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/grpc/order.proto",
 }
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aeromatch/internal/store"
 	"github.com/joho/godotenv"
 )
 
@@ -21,12 +22,14 @@ type Config struct {
 
 // ServerConfig holds server-related configuration
 type ServerConfig struct {
-	GRPCPort       int
-	WSPort         int
-	MetricsPort    int
-	PProfPort      int
-	EnablePProf    bool
-	MaxMessageSize int
+	GRPCPort           int
+	WSPort             int
+	MetricsPort        int
+	PProfPort          int
+	EnablePProf        bool
+	MaxMessageSize     int
+	MarketDataBuffer   int    // Messages queued per market data stream
+	SlowConsumerPolicy string // What a full stream queue does: drop, disconnect or conflate
 }
 
 // EngineConfig holds matching engine configuration
@@ -36,15 +39,27 @@ type EngineConfig struct {
 	SnapshotInterval    time.Duration
 	MaxOrderBookDepth   int
 	MatchTimeout        time.Duration
+	InstrumentsFile     string        // JSON list of instrument definitions; empty uses the built-in set
+	WaitStrategy        string        // Ingestion queue wait strategy: spin, yield or park
+	NodeID              int           // Order ID generator node, distinct per engine sharing an ID space
+	ClientOrderIDWindow time.Duration // How long a repeated client order ID returns the original ack; zero disables
 }
 
 // StorageConfig holds storage configuration
 type StorageConfig struct {
-	Enabled        bool
-	Type           string
-	DSN            string
-	LoadOnStartup  bool
-	SaveOnShutdown bool
+	Enabled             bool
+	Type                string // memory, file or kv for snapshots; postgres or sqlite also record trades and orders
+	DSN                 string // Connection string for postgres, database file for sqlite
+	LoadOnStartup       bool
+	SaveOnShutdown      bool
+	JournalFile         string        // Write-ahead journal of engine inputs and trades
+	JournalSyncInterval time.Duration // Longest an acknowledgement waits for fsync; zero syncs every entry
+	JournalSyncBatch    int           // Pending entries that trigger an early fsync
+	SnapshotDir         string        // Where file and kv snapshot stores keep full book snapshots
+	SnapshotRetain      int           // Snapshots kept per instrument
+	SnapshotFormat      string        // Snapshot encoding: binary, or json for debugging
+	SQLBatchSize        int           // Rows per INSERT written by the SQL store
+	SQLFlushInterval    time.Duration // Longest a recorded row waits before it is written
 }
 
 // LoggingConfig holds logging configuration
@@ -60,28 +75,34 @@ type MetricsConfig struct {
 	RefreshInterval time.Duration
 }
 
-// LoadConfig loads configuration from environment variables
+// LoadConfig loads configuration from environment variables and validates it
 func LoadConfig() (*Config, error) {
 	_ = godotenv.Load() // Ignore error if .env doesn't exist
 
-	return &Config{
+	cfg := &Config{
 		Server:  loadServerConfig(),
 		Engine:  loadEngineConfig(),
 		Storage: loadStorageConfig(),
 		Logging: loadLoggingConfig(),
 		Metrics: loadMetricsConfig(),
-	}, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
 }
 
 // loadServerConfig loads server-related configuration
 func loadServerConfig() ServerConfig {
 	return ServerConfig{
-		GRPCPort:       getEnvInt("AEROMATCH_GRPC_PORT", 50051),
-		WSPort:         getEnvInt("AEROMATCH_WS_PORT", 8080),
-		MetricsPort:    getEnvInt("AEROMATCH_METRICS_PORT", 9090),
-		PProfPort:      getEnvInt("AEROMATCH_PPROF_PORT", 6060),
-		EnablePProf:    getEnvBool("AEROMATCH_ENABLE_PPROF", false),
-		MaxMessageSize: getEnvInt("AEROMATCH_MAX_MESSAGE_SIZE", 64*1024*1024), // 64MB
+		GRPCPort:           getEnvInt("AEROMATCH_GRPC_PORT", 50051),
+		WSPort:             getEnvInt("AEROMATCH_WS_PORT", 8080),
+		MetricsPort:        getEnvInt("AEROMATCH_METRICS_PORT", 9090),
+		PProfPort:          getEnvInt("AEROMATCH_PPROF_PORT", 6060),
+		EnablePProf:        getEnvBool("AEROMATCH_ENABLE_PPROF", false),
+		MaxMessageSize:     getEnvInt("AEROMATCH_MAX_MESSAGE_SIZE", 64*1024*1024), // 64MB
+		MarketDataBuffer:   getEnvInt("AEROMATCH_MARKET_DATA_BUFFER", 1024),
+		SlowConsumerPolicy: getEnvString("AEROMATCH_SLOW_CONSUMER_POLICY", "conflate"),
 	}
 }
 
@@ -93,17 +114,29 @@ func loadEngineConfig() EngineConfig {
 		SnapshotInterval:    getEnvDuration("AEROMATCH_SNAPSHOT_INTERVAL", 100*time.Millisecond),
 		MaxOrderBookDepth:   getEnvInt("AEROMATCH_MAX_ORDER_BOOK_DEPTH", 100),
 		MatchTimeout:        getEnvDuration("AEROMATCH_MATCH_TIMEOUT", 10*time.Millisecond),
+		InstrumentsFile:     getEnvString("AEROMATCH_INSTRUMENTS_FILE", ""),
+		WaitStrategy:        getEnvString("AEROMATCH_WAIT_STRATEGY", "park"),
+		NodeID:              getEnvInt("AEROMATCH_NODE_ID", 0),
+		ClientOrderIDWindow: getEnvDuration("AEROMATCH_CLIENT_ORDER_ID_WINDOW", 10*time.Minute),
 	}
 }
 
 // loadStorageConfig loads storage-related configuration
 func loadStorageConfig() StorageConfig {
 	return StorageConfig{
-		Enabled:        getEnvBool("AEROMATCH_STORAGE_ENABLED", false),
-		Type:           getEnvString("AEROMATCH_STORAGE_TYPE", "memory"),
-		DSN:            getEnvString("AEROMATCH_STORAGE_DSN", ""),
-		LoadOnStartup:  getEnvBool("AEROMATCH_STORAGE_LOAD_ON_STARTUP", false),
-		SaveOnShutdown: getEnvBool("AEROMATCH_STORAGE_SAVE_ON_SHUTDOWN", false),
+		Enabled:             getEnvBool("AEROMATCH_STORAGE_ENABLED", false),
+		Type:                getEnvString("AEROMATCH_STORAGE_TYPE", "memory"),
+		DSN:                 getEnvString("AEROMATCH_STORAGE_DSN", ""),
+		LoadOnStartup:       getEnvBool("AEROMATCH_STORAGE_LOAD_ON_STARTUP", false),
+		SaveOnShutdown:      getEnvBool("AEROMATCH_STORAGE_SAVE_ON_SHUTDOWN", false),
+		JournalFile:         getEnvString("AEROMATCH_JOURNAL_FILE", "data/journal.log"),
+		JournalSyncInterval: getEnvDuration("AEROMATCH_JOURNAL_SYNC_INTERVAL", 2*time.Millisecond),
+		JournalSyncBatch:    getEnvInt("AEROMATCH_JOURNAL_SYNC_BATCH", 256),
+		SnapshotDir:         getEnvString("AEROMATCH_SNAPSHOT_DIR", "data/snapshots"),
+		SnapshotRetain:      getEnvInt("AEROMATCH_SNAPSHOT_RETAIN", 3),
+		SnapshotFormat:      getEnvString("AEROMATCH_SNAPSHOT_FORMAT", "binary"),
+		SQLBatchSize:        getEnvInt("AEROMATCH_SQL_BATCH_SIZE", 500),
+		SQLFlushInterval:    getEnvDuration("AEROMATCH_SQL_FLUSH_INTERVAL", 50*time.Millisecond),
 	}
 }
 
@@ -177,8 +210,13 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid buffer size: %d", c.Engine.BufferSize)
 	}
 
-	if c.Storage.Enabled && c.Storage.DSN == "" && c.Storage.Type != "memory" {
-		return fmt.Errorf("DSN required for non-memory storage")
+	if c.Storage.Enabled {
+		if err := store.CheckStorageType(c.Storage.Type); err != nil {
+			return err
+		}
+		if c.Storage.DSN == "" && (c.Storage.Type == store.StoragePostgres || c.Storage.Type == store.StorageSQLite) {
+			return fmt.Errorf("DSN required for %s storage", c.Storage.Type)
+		}
 	}
 
 	return nil
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aeromatch/internal/models"
+)
+
+// LoadInstruments reads instrument definitions from a JSON file, falling back
+// to the built-in set when path is empty
+func LoadInstruments(path string) ([]*models.Instrument, error) {
+	if path == "" {
+		return DefaultInstruments(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read instruments file: %w", err)
+	}
+
+	var instruments []*models.Instrument
+	if err := json.Unmarshal(data, &instruments); err != nil {
+		return nil, fmt.Errorf("failed to parse instruments file: %w", err)
+	}
+
+	seen := make(map[string]bool, len(instruments))
+	for _, instrument := range instruments {
+		if err := instrument.Validate(); err != nil {
+			return nil, err
+		}
+		if seen[instrument.Symbol] {
+			return nil, fmt.Errorf("duplicate instrument %s", instrument.Symbol)
+		}
+		seen[instrument.Symbol] = true
+	}
+
+	return instruments, nil
+}
+
+// DefaultInstruments returns the instruments traded when no file is configured
+func DefaultInstruments() []*models.Instrument {
+	return []*models.Instrument{
+		{
+			Symbol:        "BTC-USD",
+			BaseCurrency:  "BTC",
+			QuoteCurrency: "USD",
+			TickSize:      models.MustParseDecimal("0.01"),
+			LotSize:       models.MustParseDecimal("0.00000001"),
+			MinQuantity:   models.MustParseDecimal("0.00001"),
+			MaxQuantity:   models.MustParseDecimal("1000"),
+			MinNotional:   models.MustParseDecimal("1.00"),
+		},
+		{
+			Symbol:        "ETH-USD",
+			BaseCurrency:  "ETH",
+			QuoteCurrency: "USD",
+			TickSize:      models.MustParseDecimal("0.01"),
+			LotSize:       models.MustParseDecimal("0.00000001"),
+			MinQuantity:   models.MustParseDecimal("0.0001"),
+			MaxQuantity:   models.MustParseDecimal("10000"),
+			MinNotional:   models.MustParseDecimal("1.00"),
+		},
+		{
+			Symbol:        "AAPL",
+			BaseCurrency:  "AAPL",
+			QuoteCurrency: "USD",
+			TickSize:      models.MustParseDecimal("0.01"),
+			LotSize:       models.MustParseDecimal("1"),
+			MinQuantity:   models.MustParseDecimal("1"),
+			MaxQuantity:   models.MustParseDecimal("100000"),
+		},
+		{
+			Symbol:        "GOOGL",
+			BaseCurrency:  "GOOGL",
+			QuoteCurrency: "USD",
+			TickSize:      models.MustParseDecimal("0.01"),
+			LotSize:       models.MustParseDecimal("1"),
+			MinQuantity:   models.MustParseDecimal("1"),
+			MaxQuantity:   models.MustParseDecimal("100000"),
+		},
+	}
+}
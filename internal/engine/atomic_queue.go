@@ -0,0 +1,236 @@
+package engine
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// WaitStrategy decides what a producer or consumer does while the queue is full or empty
+type WaitStrategy uint8
+
+const (
+	WaitBusySpin WaitStrategy = iota // Lowest latency; burns a core per waiting goroutine, needs spare cores
+	WaitYield                        // Spin but let other goroutines run between attempts
+	WaitPark                         // Spin briefly, then sleep until woken
+)
+
+const (
+	parkSpins    = 64   // Spins before a parking goroutine goes to sleep
+	busySpinSpan = 4096 // Busy spinners still yield this often so a starved peer can run
+
+	tailClosed = 1 << 63 // Set in tail by Close, so no slot can be claimed after it
+)
+
+func (w WaitStrategy) String() string {
+	switch w {
+	case WaitBusySpin:
+		return "spin"
+	case WaitYield:
+		return "yield"
+	case WaitPark:
+		return "park"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseWaitStrategy accepts "spin", "yield" or "park"
+func ParseWaitStrategy(s string) (WaitStrategy, error) {
+	switch s {
+	case "spin":
+		return WaitBusySpin, nil
+	case "yield":
+		return WaitYield, nil
+	case "park":
+		return WaitPark, nil
+	default:
+		return 0, fmt.Errorf("unknown wait strategy %q", s)
+	}
+}
+
+// queueSlot holds one element and the sequence number that says who may touch it next
+type queueSlot[T any] struct {
+	seq   atomic.Uint64
+	value T
+}
+
+// AtomicQueue is a bounded lock-free ring buffer for many producers and a single
+// consumer. Producers claim slots by advancing tail; each slot's sequence number
+// tells the consumer when the value is published and producers when it is free
+// again, so neither side takes a lock on the fast path.
+type AtomicQueue[T any] struct {
+	tail  PaddedUint64 // Next slot to claim, shared by producers; tailClosed once closed
+	head  PaddedUint64 // Next slot to consume, owned by the consumer
+	mask  uint64
+	slots []queueSlot[T]
+	wait  WaitStrategy
+
+	// Parking, only used by WaitPark
+	parked   atomic.Int32 // Goroutines asleep or about to sleep
+	parkMu   sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	closed   atomic.Bool
+}
+
+// NewAtomicQueue creates a queue holding at least capacity elements, rounded up to a power of two
+func NewAtomicQueue[T any](capacity int, wait WaitStrategy) *AtomicQueue[T] {
+	size := uint64(2)
+	for size < uint64(capacity) {
+		size <<= 1
+	}
+
+	q := &AtomicQueue[T]{
+		mask:  size - 1,
+		slots: make([]queueSlot[T], size),
+		wait:  wait,
+	}
+	for i := range q.slots {
+		q.slots[i].seq.Store(uint64(i))
+	}
+	q.notEmpty = sync.NewCond(&q.parkMu)
+	q.notFull = sync.NewCond(&q.parkMu)
+	return q
+}
+
+// Cap returns the number of slots
+func (q *AtomicQueue[T]) Cap() int {
+	return len(q.slots)
+}
+
+// Len returns the number of queued elements; approximate while producers are active
+func (q *AtomicQueue[T]) Len() int {
+	return int(atomic.LoadUint64(&q.tail.value)&^tailClosed - atomic.LoadUint64(&q.head.value))
+}
+
+// TryPush adds v unless the queue is full or closed. A push that claimed its slot
+// before Close is still delivered: Pop waits for it to be published.
+func (q *AtomicQueue[T]) TryPush(v T) bool {
+	for {
+		pos := atomic.LoadUint64(&q.tail.value)
+		if pos&tailClosed != 0 {
+			return false
+		}
+		slot := &q.slots[pos&q.mask]
+		switch seq := slot.seq.Load(); {
+		case seq == pos:
+			if atomic.CompareAndSwapUint64(&q.tail.value, pos, pos+1) {
+				slot.value = v
+				slot.seq.Store(pos + 1) // Publish to the consumer
+				q.wake(q.notEmpty)
+				return true
+			}
+		case seq < pos:
+			return false // Consumer has not freed the slot yet: full
+		}
+		// Another producer claimed pos first; retry with the new tail
+	}
+}
+
+// Push adds v, waiting while the queue is full. It reports false if the queue is closed.
+func (q *AtomicQueue[T]) Push(v T) bool {
+	for spins := 0; ; spins++ {
+		if q.TryPush(v) {
+			return true
+		}
+		if q.closed.Load() {
+			return false
+		}
+		q.idle(spins, q.notFull, q.isFull)
+	}
+}
+
+// TryPop removes the oldest element if one is published. Only one goroutine may pop.
+func (q *AtomicQueue[T]) TryPop() (T, bool) {
+	var zero T
+	pos := q.head.value
+	slot := &q.slots[pos&q.mask]
+	if slot.seq.Load() != pos+1 {
+		return zero, false
+	}
+
+	v := slot.value
+	slot.value = zero // Drop the reference for the GC
+	atomic.StoreUint64(&q.head.value, pos+1)
+	slot.seq.Store(pos + q.mask + 1) // Free the slot for the next lap
+	q.wake(q.notFull)
+	return v, true
+}
+
+// Pop removes the oldest element, waiting while the queue is empty. Once the queue
+// is closed and every element pushed before it has been delivered it reports false.
+func (q *AtomicQueue[T]) Pop() (T, bool) {
+	for spins := 0; ; spins++ {
+		if v, ok := q.TryPop(); ok {
+			return v, true
+		}
+		if tail := atomic.LoadUint64(&q.tail.value); tail&tailClosed != 0 {
+			if q.head.value == tail&^tailClosed {
+				var zero T
+				return zero, false
+			}
+			runtime.Gosched() // A producer claimed a slot before Close and is about to publish it
+			continue
+		}
+		q.idle(spins, q.notEmpty, q.isEmpty)
+	}
+}
+
+// Close stops further pushes and wakes all waiters. Every push that reported
+// success, even one racing with Close, is still delivered by Pop.
+func (q *AtomicQueue[T]) Close() {
+	for {
+		tail := atomic.LoadUint64(&q.tail.value)
+		if tail&tailClosed != 0 || atomic.CompareAndSwapUint64(&q.tail.value, tail, tail|tailClosed) {
+			break
+		}
+	}
+	q.closed.Store(true)
+	q.parkMu.Lock()
+	q.notEmpty.Broadcast()
+	q.notFull.Broadcast()
+	q.parkMu.Unlock()
+}
+
+func (q *AtomicQueue[T]) isFull() bool {
+	pos := atomic.LoadUint64(&q.tail.value) &^ tailClosed
+	return q.slots[pos&q.mask].seq.Load() < pos
+}
+
+func (q *AtomicQueue[T]) isEmpty() bool {
+	pos := atomic.LoadUint64(&q.head.value)
+	return q.slots[pos&q.mask].seq.Load() != pos+1
+}
+
+// idle waits once according to the strategy. Parking re-checks blocked under
+// parkMu, and wake takes parkMu, so a wakeup cannot slip in between.
+func (q *AtomicQueue[T]) idle(spins int, cond *sync.Cond, blocked func() bool) {
+	switch {
+	case q.wait == WaitBusySpin:
+		if spins%busySpinSpan == busySpinSpan-1 {
+			runtime.Gosched()
+		}
+	case q.wait == WaitYield, spins < parkSpins:
+		runtime.Gosched()
+	default:
+		q.parkMu.Lock()
+		q.parked.Add(1)
+		for blocked() && !q.closed.Load() {
+			cond.Wait()
+		}
+		q.parked.Add(-1)
+		q.parkMu.Unlock()
+	}
+}
+
+// wake rouses goroutines parked on cond; a single atomic load when nobody sleeps
+func (q *AtomicQueue[T]) wake(cond *sync.Cond) {
+	if q.parked.Load() == 0 {
+		return
+	}
+	q.parkMu.Lock()
+	cond.Broadcast()
+	q.parkMu.Unlock()
+}
@@ -0,0 +1,177 @@
+package engine
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAtomicQueueMultiProducer(t *testing.T) {
+	const producers, perProducer = 4, 5000
+
+	for _, wait := range []WaitStrategy{WaitBusySpin, WaitYield, WaitPark} {
+		t.Run(wait.String(), func(t *testing.T) {
+			q := NewAtomicQueue[uint64](8, wait) // Small so producers wrap and wait
+
+			var wg sync.WaitGroup
+			for p := 0; p < producers; p++ {
+				wg.Add(1)
+				go func(p uint64) {
+					defer wg.Done()
+					for i := uint64(0); i < perProducer; i++ {
+						q.Push(p<<32 | i)
+					}
+				}(uint64(p))
+			}
+
+			next := make([]uint64, producers)
+			for n := 0; n < producers*perProducer; n++ {
+				v, ok := q.Pop()
+				if !ok {
+					t.Fatal("queue reported closed")
+				}
+				p, i := v>>32, v&(1<<32-1)
+				if i != next[p] {
+					t.Fatalf("producer %d: got element %d, want %d", p, i, next[p])
+				}
+				next[p]++
+			}
+			wg.Wait()
+
+			if _, ok := q.TryPop(); ok {
+				t.Fatal("queue not empty after all elements were consumed")
+			}
+		})
+	}
+}
+
+func TestAtomicQueueCapacity(t *testing.T) {
+	q := NewAtomicQueue[int](5, WaitPark)
+	if q.Cap() != 8 {
+		t.Fatalf("capacity %d, want 8", q.Cap())
+	}
+	for i := 0; i < 8; i++ {
+		if !q.TryPush(i) {
+			t.Fatalf("push %d refused below capacity", i)
+		}
+	}
+	if q.TryPush(8) {
+		t.Fatal("push accepted on a full queue")
+	}
+	if v, _ := q.TryPop(); v != 0 {
+		t.Fatalf("popped %d, want 0", v)
+	}
+	if !q.TryPush(8) {
+		t.Fatal("push refused after a slot was freed")
+	}
+	if q.Len() != 8 {
+		t.Fatalf("len %d, want 8", q.Len())
+	}
+}
+
+func TestAtomicQueueClose(t *testing.T) {
+	q := NewAtomicQueue[int](4, WaitPark)
+	q.Push(1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if v, ok := q.Pop(); !ok || v != 1 {
+			t.Errorf("got %d %v, want the element queued before close", v, ok)
+		}
+		if _, ok := q.Pop(); ok {
+			t.Error("pop succeeded on a closed, drained queue")
+		}
+	}()
+
+	q.Close()
+	<-done
+	if q.Push(2) {
+		t.Fatal("push accepted after close")
+	}
+}
+
+// TestAtomicQueueCloseRacingPush checks that every push reported as accepted is
+// popped, even when Close lands while producers are mid-push
+func TestAtomicQueueCloseRacingPush(t *testing.T) {
+	const producers, perProducer = 4, 2000
+
+	for _, wait := range []WaitStrategy{WaitBusySpin, WaitYield, WaitPark} {
+		t.Run(wait.String(), func(t *testing.T) {
+			for round := 0; round < 5; round++ {
+				q := NewAtomicQueue[int](8, wait)
+
+				var wg sync.WaitGroup
+				accepted := make([]int, producers)
+				for p := 0; p < producers; p++ {
+					wg.Add(1)
+					go func(p int) {
+						defer wg.Done()
+						for i := 0; i < perProducer && q.Push(i); i++ {
+							accepted[p]++
+						}
+					}(p)
+				}
+
+				popped := make(chan int)
+				go func() {
+					n := 0
+					for _, ok := q.Pop(); ok; _, ok = q.Pop() {
+						n++
+					}
+					popped <- n
+				}()
+
+				for q.Len() == 0 {
+					runtime.Gosched()
+				}
+				q.Close()
+				wg.Wait()
+
+				total := 0
+				for _, n := range accepted {
+					total += n
+				}
+				if n := <-popped; n != total {
+					t.Fatalf("round %d: popped %d elements, %d pushes were accepted", round, n, total)
+				}
+			}
+		})
+	}
+}
+
+// TestAtomicQueueCloseWaitsForClaimedSlot closes the queue between a producer
+// claiming its slot and publishing it; Pop must wait for the element
+func TestAtomicQueueCloseWaitsForClaimedSlot(t *testing.T) {
+	q := NewAtomicQueue[int](4, WaitPark)
+	pos := atomic.LoadUint64(&q.tail.value)
+	if !atomic.CompareAndSwapUint64(&q.tail.value, pos, pos+1) { // Claim, as TryPush does
+		t.Fatal("could not claim a slot")
+	}
+	q.Close()
+
+	popped := make(chan int, 1)
+	go func() {
+		if v, ok := q.Pop(); ok {
+			popped <- v
+		}
+		close(popped)
+	}()
+	select {
+	case v, ok := <-popped:
+		t.Fatalf("Pop returned %d %v before the claimed slot was published", v, ok)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	slot := &q.slots[pos&q.mask]
+	slot.value = 7
+	slot.seq.Store(pos + 1) // Publish, as TryPush does
+	if v := <-popped; v != 7 {
+		t.Fatalf("popped %d, want the element claimed before close", v)
+	}
+	if _, ok := q.Pop(); ok {
+		t.Fatal("pop succeeded on a closed, drained queue")
+	}
+}
@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 	"unsafe"
@@ -19,147 +21,550 @@ type PaddedUint64 struct {
 	_     [paddedSize]uint64
 }
 
-// Lock-free order book with bids and asks
+// Order book with price-time priority on both sides.
+// Matching runs on a single goroutine; mu lets other goroutines read a consistent view.
 type OrderBook struct {
 	bidSeq          PaddedUint64
 	askSeq          PaddedUint64
+	sequence        uint64 // Sequence number of the last command applied, guarded by mu
+	nextSeq         uint64 // Last sequence number handed out; owned by the engine's sequencer
+	replaying       bool   // Rebuilding from the journal: outputs were published before the restart
+	mu              sync.RWMutex
 	bids            *OrderSide
 	asks            *OrderSide
-	incomingOrders  chan *models.Order
+	orders          map[uint64]*OrderNode     // Resting orders by ID
+	instrument      *models.Instrument        // Replaced, never mutated, on status changes
+	commands        *AtomicQueue[bookCommand] // Fed by the engine's sequencer
+	done            chan struct{}             // Closed once the book has been delisted
 	processedTrades chan *models.Trade
+	orderEvents     chan *models.OrderEvent
+	marketData      *MarketDataBus       // Where L2 and L3 updates are published; nil outside an engine
+	depthSequence   uint64               // Last L2 update published, guarded by mu
+	orderSequence   uint64               // Last L3 update published, guarded by mu
+	tracing         bool                 // The command being applied has L3 subscribers
+	orderFlow       []L3Event            // L3 events of the command being applied
+	events          []*models.OrderEvent // Order events of the command being applied, sent once mu is released
+	droppedEvents   atomic.Uint64        // Order events a direct call found no room for
+	outcome         *commandResult       // Gathers the trades of a new order whose caller waits
+	index           *orderIndex          // Latest state of open and recently closed orders, guarded by mu
+	history         *tradeHistory        // Recent trades and candles, fed from processedTrades
 }
 
-// Side of the order book (bids or asks)
-// TODO: Implement balanced binary search tree or a skip list;
+type commandType uint8
+
+const (
+	cmdNewOrder commandType = iota
+	cmdCancel
+	cmdAmend
+	cmdSetStatus
+	cmdDelist
+)
+
+// bookCommand is a unit of work for the book's processing goroutine
+type bookCommand struct {
+	kind       commandType
+	instrument string // Routing key for the engine's sequencer
+	sequence   uint64 // Per-instrument sequence number, zero if not sequenced
+	order      *models.Order
+	orderID    uint64
+	account    string               // Owner a cancelled or amended order must have, empty for any
+	price      models.Decimal       // New price for amends
+	quantity   models.Decimal       // New total quantity for amends
+	status     models.TradingStatus // New status for status changes
+	reply      chan commandResult   // Buffered, nil for fire-and-forget commands
+	durable    chan error           // Buffered, signalled once journaled; nil if the caller does not wait
+}
+
+// commandResult carries the outcome of a synchronous command back to the caller
+type commandResult struct {
+	order  *models.Order
+	orders []*models.Order     // Orders cancelled by a delist
+	trades []*models.Trade     // Trades of a new order, in execution order
+	reason models.RejectReason // Why a new order was rejected, killed or expired
+	err    error
+	settle func() error // Set when the caller gave up on a queued command; blocks until it is known whether the command was refused
+}
+
+// Side of the order book (bids or asks), indexed by price level
 type OrderSide struct {
-	head    *OrderNode
-	tail    *OrderNode
-	counter int32
+	levels  *priceLevels
+	counter int32            // Number of resting orders
+	changed []models.Decimal // Prices of levels changed since the last book update
 }
 
 // Node in the order book for each order
 type OrderNode struct {
-	order    *models.Order
-	next     unsafe.Pointer
-	quantity int64
+	order *models.Order
+	prev  *OrderNode
+	next  *OrderNode
+	level *priceLevel
 }
 
-func NewOrderBook(bufferSize int) *OrderBook {
+// NewOrderBook creates a book for the instrument; prices and quantities are held
+// at the scales of its tick and lot sizes
+func NewOrderBook(instrument *models.Instrument, bufferSize int, wait WaitStrategy) *OrderBook {
 	return &OrderBook{
-		bids: &OrderSide{
-			head: nil, tail: nil,
-		},
-		asks: &OrderSide{
-			head: nil, tail: nil,
-		},
-		incomingOrders:  make(chan *models.Order, bufferSize),
+		bids:            newOrderSide(descending),
+		asks:            newOrderSide(ascending),
+		orders:          make(map[uint64]*OrderNode),
+		instrument:      instrument,
+		commands:        NewAtomicQueue[bookCommand](bufferSize, wait),
+		done:            make(chan struct{}),
 		processedTrades: make(chan *models.Trade, bufferSize*2),
+		orderEvents:     make(chan *models.OrderEvent, bufferSize*2),
+		index:           newOrderIndex(),
+		history:         newTradeHistory(),
 	}
 }
 
+// Instrument returns the definition of the instrument traded in this book
+func (ob *OrderBook) Instrument() *models.Instrument {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	return ob.instrument
+}
+
 func (ob *OrderBook) AddOrder(order *models.Order) {
-	ob.incomingOrders <- order
+	ob.send(bookCommand{kind: cmdNewOrder, order: order})
+}
+
+// Sequence returns the sequence number of the last command applied to the book
+func (ob *OrderBook) Sequence() uint64 {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	return ob.sequence
 }
 
+// send queues a command unless the book has been delisted
+func (ob *OrderBook) send(cmd bookCommand) bool {
+	return ob.commands.Push(cmd)
+}
+
+// call queues a command and waits for its result
+func (ob *OrderBook) call(cmd bookCommand) commandResult {
+	cmd.reply = make(chan commandResult, 1)
+	if !ob.send(cmd) {
+		return commandResult{err: ErrInstrumentDelisted}
+	}
+	select {
+	case result := <-cmd.reply:
+		return result
+	case <-ob.done:
+		// The reply is sent before done closes, so check once more
+		select {
+		case result := <-cmd.reply:
+			return result
+		default:
+			return commandResult{err: ErrInstrumentDelisted}
+		}
+	}
+}
+
+// ProcessOrders runs the book's commands in arrival order until the book is
+// delisted or its queue is closed
 func (ob *OrderBook) ProcessOrders() {
-	for order := range ob.incomingOrders {
-		switch order.Side {
-		case models.Buy:
-			ob.ProcessBuyOrder(order)
-		case models.Sell:
-			ob.ProcessSellOrder(order)
+	for {
+		cmd, ok := ob.commands.Pop()
+		if !ok {
+			// The engine stopped: no more trades or order events will follow
+			close(ob.processedTrades)
+			close(ob.orderEvents)
+			return
+		}
+		result, events := ob.apply(cmd)
+		for _, event := range events {
+			ob.orderEvents <- event // The engine drains the channel, so this only waits for it
+		}
+		if cmd.reply != nil {
+			cmd.reply <- result
+		}
+		if cmd.kind == cmdDelist {
+			ob.close()
+			return
 		}
 	}
 }
 
-func (ob *OrderBook) ProcessBuyOrder(order *models.Order) {
-	remainingQty := order.Quantity
+// apply runs one command under the write lock, so readers see its effects and
+// its sequence number together. It returns the order events the command
+// reported, for the caller to send without holding the lock.
+func (ob *OrderBook) apply(cmd bookCommand) (commandResult, []*models.OrderEvent) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	if cmd.sequence != 0 {
+		ob.sequence = cmd.sequence
+	}
+	ob.tracing = !ob.replaying && ob.marketData != nil && ob.marketData.wants(ob.instrument.Symbol, ChannelOrders)
+	var result commandResult
+	switch cmd.kind {
+	case cmdNewOrder:
+		if cmd.reply == nil {
+			ob.processOrder(cmd.order)
+			break
+		}
+		ob.outcome = &result
+		ob.processOrder(cmd.order)
+		ob.outcome = nil
+		state := *cmd.order // The order may rest and keep changing
+		result.order = &state
+	case cmdCancel:
+		result.order, result.err = ob.cancelOrder(cmd.orderID, cmd.account)
+	case cmdAmend:
+		result.order, result.err = ob.amendOrder(cmd.orderID, cmd.account, cmd.price, cmd.quantity)
+	case cmdSetStatus:
+		result.err = ob.changeStatus(cmd.status)
+	case cmdDelist:
+		result.orders = ob.delist()
+	}
+	ob.publishDepth()
+	ob.publishOrders()
+	ob.tracing = false
+	return result, ob.takeEvents()
+}
+
+// update runs fn under the write lock for a direct call, then offers the order
+// events it reported without waiting: the book may have no reader, e.g. before
+// the engine starts. Events that do not fit are counted by DroppedEvents.
+func (ob *OrderBook) update(fn func()) {
+	ob.mu.Lock()
+	fn()
+	events := ob.takeEvents()
+	ob.mu.Unlock()
+	ob.offerEvents(events)
+}
+
+// applyDirect applies a sequenced command on the caller's goroutine, when the
+// book is not processing its queue, and offers its order events like update
+func (ob *OrderBook) applyDirect(cmd bookCommand) commandResult {
+	result, events := ob.apply(cmd)
+	ob.offerEvents(events)
+	return result
+}
 
-	for remainingQty > 0 {
-		bestAsk, ok := ob.GetBestAsk()
+// offerEvents sends order events the channel has room for and counts the rest
+func (ob *OrderBook) offerEvents(events []*models.OrderEvent) {
+	for _, event := range events {
+		select {
+		case ob.orderEvents <- event:
+		default:
+			ob.droppedEvents.Add(1)
+		}
+	}
+}
+
+// takeEvents hands over the order events reported so far; the caller must hold mu
+func (ob *OrderBook) takeEvents() []*models.OrderEvent {
+	events := ob.events
+	ob.events = nil
+	return events
+}
+
+// DroppedEvents returns the number of order events discarded because a direct
+// call found the channel full
+func (ob *OrderBook) DroppedEvents() uint64 {
+	return ob.droppedEvents.Load()
+}
+
+// close stops the book after a delist: waiting callers are released, queued
+// orders are rejected and the output channels are closed so their readers exit
+func (ob *OrderBook) close() {
+	close(ob.done)
+	ob.commands.Close()
+	for {
+		cmd, ok := ob.commands.TryPop()
 		if !ok {
+			close(ob.processedTrades)
+			close(ob.orderEvents)
+			return
+		}
+		if cmd.kind == cmdNewOrder {
+			ob.mu.Lock()
+			ob.rejectOrder(cmd.order, models.Rejected, models.ReasonInstrumentNotTrading)
+			events := ob.takeEvents()
+			ob.mu.Unlock()
+			for _, event := range events {
+				ob.orderEvents <- event
+			}
+			if cmd.reply != nil {
+				cmd.reply <- commandResult{order: cmd.order, reason: models.ReasonInstrumentNotTrading}
+			}
+		} else if cmd.reply != nil {
+			cmd.reply <- commandResult{err: ErrInstrumentDelisted}
+		}
+	}
+}
+
+// processOrder matches an incoming order; the caller must hold mu
+func (ob *OrderBook) processOrder(order *models.Order) {
+	if ob.instrument.Status != models.StatusTrading {
+		// Halted after the order was accepted
+		ob.rejectOrder(order, models.Rejected, models.ReasonInstrumentNotTrading)
+		return
+	}
+	ob.match(order)
+}
+
+func (ob *OrderBook) ProcessBuyOrder(order *models.Order) {
+	ob.update(func() { ob.matchBuy(order) })
+}
+
+func (ob *OrderBook) ProcessSellOrder(order *models.Order) {
+	ob.update(func() { ob.matchSell(order) })
+}
+
+// match runs the order against the opposite side; the caller must hold mu
+func (ob *OrderBook) match(order *models.Order) {
+	if order.Side == models.Buy {
+		ob.matchBuy(order)
+	} else {
+		ob.matchSell(order)
+	}
+}
+
+func (ob *OrderBook) matchBuy(order *models.Order) {
+	if !ob.normalize(order) {
+		return
+	}
+	if order.Type == models.FOK && !ob.asks.canFill(order.Price, order.Remaining) {
+		ob.killOrder(order, models.ReasonInsufficientLiquidity)
+		return
+	}
+	if order.Type == models.PostOnly && !ob.preparePostOnly(order, ob.asks) {
+		return
+	}
+	ob.reportOrder(order, order.Status, models.ReasonNone, nil) // Accepted
+
+	remainingQty := order.Remaining
+	overflow := false // The order's filled value cannot take the next fill
+
+	for remainingQty.Sign() > 0 {
+		bestAskNode := ob.asks.best()
+		if bestAskNode == nil {
 			break // No more asks to match
 		}
+		bestAsk := bestAskNode.order
 
-		if order.Type != models.Market && order.Price < bestAsk.Price {
+		if order.Type != models.Market && order.Price.LessThan(bestAsk.Price) {
 			break // Price doesn't cross
 		}
 
 		// Calculate fill quantity
-		fillQty := min(remainingQty, bestAsk.Remaining)
+		fillQty := models.MinDecimal(remainingQty, bestAsk.Remaining)
 		fillPrice := bestAsk.Price
+		if bestAsk.CheckFill(fillQty, fillPrice) != nil {
+			ob.withdraw(bestAsk, models.ReasonNotionalOutOfRange) // Would block the level for good
+			continue
+		}
+		if order.CheckFill(fillQty, fillPrice) != nil {
+			overflow = true
+			break
+		}
 
 		// Execute trade
 		trade := ob.createTradeDraft(bestAsk, order, fillPrice, fillQty)
-		ob.processedTrades <- trade
+		ob.emitTrade(trade)
+		ob.traceOrder(L3Execute, bestAsk, fillPrice, fillQty, trade.TradeID)
 
 		// Update quantities
-		remainingQty -= fillQty
-		bestAsk.Remaining -= fillQty
-		order.Remaining -= fillQty
+		remainingQty = remainingQty.Sub(fillQty)
+		makerStatus, takerStatus := bestAsk.Status, order.Status
+		bestAsk.Fill(fillQty, fillPrice)
+		ob.asks.reduce(bestAskNode, fillQty)
+		order.Fill(fillQty, fillPrice)
 
 		// Remove exhausted order
-		if bestAsk.Remaining <= 0 {
+		if bestAsk.Remaining.Sign() <= 0 {
 			ob.removeAsk(bestAsk)
 		}
+		ob.reportOrder(bestAsk, makerStatus, models.ReasonNone, trade)
+		ob.reportOrder(order, takerStatus, models.ReasonNone, trade)
 
 		// Handle order types
-		if order.Type == models.IOC && remainingQty > 0 {
+		if order.Type == models.IOC && remainingQty.Sign() > 0 {
 			break // Immediate-or-Cancel: cancel remaining
 		}
 
 	}
 
-	// Add remaining quantity to book if not fully filled
-	if remainingQty > 0 && order.Type != models.IOC && order.Type != models.FOK {
-		ob.AddBid(order)
+	// Add remaining quantity to book if not fully filled, unless the order may not rest
+	if remainingQty.Sign() > 0 {
+		switch {
+		case overflow:
+			ob.rejectOrder(order, models.Cancelled, models.ReasonNotionalOutOfRange)
+		case order.Type == models.Market || order.Type == models.IOC || order.Type == models.FOK:
+			ob.rejectOrder(order, models.Cancelled, models.ReasonExpired)
+		default:
+			ob.addBid(order)
+		}
 	}
 }
 
-func (ob *OrderBook) ProcessSellOrder(order *models.Order) {
-	remainingQty := order.Quantity
+func (ob *OrderBook) matchSell(order *models.Order) {
+	if !ob.normalize(order) {
+		return
+	}
+	if order.Type == models.FOK && !ob.bids.canFill(order.Price, order.Remaining) {
+		ob.killOrder(order, models.ReasonInsufficientLiquidity)
+		return
+	}
+	if order.Type == models.PostOnly && !ob.preparePostOnly(order, ob.bids) {
+		return
+	}
+	ob.reportOrder(order, order.Status, models.ReasonNone, nil) // Accepted
 
-	for remainingQty > 0 {
-		bestBid, ok := ob.GetBestBid()
-		if !ok {
+	remainingQty := order.Remaining
+	overflow := false // The order's filled value cannot take the next fill
+
+	for remainingQty.Sign() > 0 {
+		bestBidNode := ob.bids.best()
+		if bestBidNode == nil {
 			break // No more bids to match
 		}
+		bestBid := bestBidNode.order
 
-		if order.Type != models.Market && order.Price > bestBid.Price {
+		if order.Type != models.Market && order.Price.GreaterThan(bestBid.Price) {
 			break // Price doesn't cross
 		}
 
 		// Calculate fill quantity
-		fillQty := min(remainingQty, bestBid.Remaining)
+		fillQty := models.MinDecimal(remainingQty, bestBid.Remaining)
 		fillPrice := bestBid.Price // Price-time priority
+		if bestBid.CheckFill(fillQty, fillPrice) != nil {
+			ob.withdraw(bestBid, models.ReasonNotionalOutOfRange) // Would block the level for good
+			continue
+		}
+		if order.CheckFill(fillQty, fillPrice) != nil {
+			overflow = true
+			break
+		}
 
 		// Execute trade
 		trade := ob.createTradeDraft(bestBid, order, fillPrice, fillQty)
-		ob.processedTrades <- trade
+		ob.emitTrade(trade)
+		ob.traceOrder(L3Execute, bestBid, fillPrice, fillQty, trade.TradeID)
 
 		// Update quantities
-		remainingQty -= fillQty
-		bestBid.Remaining -= fillQty
-		order.Remaining -= fillQty
+		remainingQty = remainingQty.Sub(fillQty)
+		makerStatus, takerStatus := bestBid.Status, order.Status
+		bestBid.Fill(fillQty, fillPrice)
+		ob.bids.reduce(bestBidNode, fillQty)
+		order.Fill(fillQty, fillPrice)
 
 		// Remove exhausted order
-		if bestBid.Remaining <= 0 {
+		if bestBid.Remaining.Sign() <= 0 {
 			ob.removeBid(bestBid)
 		}
+		ob.reportOrder(bestBid, makerStatus, models.ReasonNone, trade)
+		ob.reportOrder(order, takerStatus, models.ReasonNone, trade)
 
 		// Handle order types
-		if order.Type == models.IOC && remainingQty > 0 {
+		if order.Type == models.IOC && remainingQty.Sign() > 0 {
 			break // Immediate-or-Cancel: cancel remaining
 		}
 	}
 
-	// Add remaining quantity to book if not fully filled
-	if remainingQty > 0 && order.Type != models.IOC && order.Type != models.FOK {
-		ob.AddAsk(order)
+	// Add remaining quantity to book if not fully filled, unless the order may not rest
+	if remainingQty.Sign() > 0 {
+		switch {
+		case overflow:
+			ob.rejectOrder(order, models.Cancelled, models.ReasonNotionalOutOfRange)
+		case order.Type == models.Market || order.Type == models.IOC || order.Type == models.FOK:
+			ob.rejectOrder(order, models.Cancelled, models.ReasonExpired)
+		default:
+			ob.addAsk(order)
+		}
+	}
+}
+
+// preparePostOnly keeps a post-only order from taking liquidity on the opposite side,
+// sliding it behind the opposite best or rejecting it. It reports whether the order may rest.
+func (ob *OrderBook) preparePostOnly(order *models.Order, opposite *OrderSide) bool {
+	best := opposite.levels.best()
+	if best == nil || opposite.levels.better(order.Price, best.price) {
+		return true // Does not cross
+	}
+
+	if order.PostOnly == models.PostOnlySlide {
+		price := best.price.Add(ob.instrument.TickSize)
+		if order.Side == models.Buy {
+			price = best.price.Sub(ob.instrument.TickSize)
+		}
+		// The new price must pass the checks the original did, such as the price band
+		slid := *order
+		slid.Price = price
+		if price.Sign() > 0 && ob.instrument.ValidateOrder(&slid) == nil {
+			order.Price = price
+			return true
+		}
+	}
+
+	ob.rejectOrder(order, models.Rejected, models.ReasonPostOnlyWouldCross)
+	return false
+}
+
+// normalize brings the order's price and quantities to the book's scales, rejecting
+// orders that carry more precision than the instrument allows. The engine already
+// normalizes orders it admits; this covers orders added to the book directly.
+func (ob *OrderBook) normalize(order *models.Order) bool {
+	if err := ob.instrument.NormalizeOrder(order); err != nil {
+		ob.rejectOrder(order, models.Rejected, models.ReasonInvalidPrecision)
+		return false
+	}
+	return true
+}
+
+// killOrder cancels an incoming order without executing any of it
+func (ob *OrderBook) killOrder(order *models.Order, reason models.RejectReason) {
+	ob.rejectOrder(order, models.Cancelled, reason)
+}
+
+// rejectOrder moves an order that will not trade to a terminal status and reports why
+func (ob *OrderBook) rejectOrder(order *models.Order, status models.OrderStatus, reason models.RejectReason) {
+	oldStatus := order.Status
+	order.Status = status
+	order.LastUpdated = time.Now()
+	if ob.outcome != nil {
+		ob.outcome.reason = reason
+	}
+	ob.reportOrder(order, oldStatus, reason, nil)
+}
+
+// reportOrder indexes the order's new state and queues it as an order event, sent
+// once mu is released, unless the book is replaying history. Fills pass the trade
+// that caused them.
+func (ob *OrderBook) reportOrder(order *models.Order, oldStatus models.OrderStatus, reason models.RejectReason, trade *models.Trade) {
+	state := *order // The book keeps changing the order; the copy is shared read-only
+	ob.index.update(&state)
+	if ob.replaying {
+		return
+	}
+	event := &models.OrderEvent{
+		Order:     &state,
+		OldStatus: oldStatus,
+		Reason:    reason,
+		Timestamp: order.LastUpdated,
+	}
+	if trade != nil {
+		event.TradeID = trade.TradeID
+		event.ExecutionID = trade.ExecutionID
+		event.TradePrice = trade.Price
+		event.TradeSize = trade.Quantity
+	}
+	ob.events = append(ob.events, event)
+}
+
+// emitTrade publishes a trade unless the book is replaying history
+func (ob *OrderBook) emitTrade(trade *models.Trade) {
+	if ob.outcome != nil {
+		ob.outcome.trades = append(ob.outcome.trades, trade)
+	}
+	if !ob.replaying {
+		ob.processedTrades <- trade
 	}
 }
 
-func (ob *OrderBook) createTradeDraft(maker, taker *models.Order, price, qty float64) *models.Trade {
+func (ob *OrderBook) createTradeDraft(maker, taker *models.Order, price, qty models.Decimal) *models.Trade {
 	return &models.Trade{
 		TradeID:      generateTradeID(),
 		ExecutionID:  atomic.AddUint64(&executionCounter, 1),
@@ -169,126 +574,319 @@ func (ob *OrderBook) createTradeDraft(maker, taker *models.Order, price, qty flo
 		MakerOrderID: maker.ID,
 		TakerOrderID: taker.ID,
 		Instrument:   maker.Instrument,
+		Sequence:     ob.sequence,
 		Side:         taker.Side,
 	}
 }
 
 func (ob *OrderBook) AddBid(order *models.Order) {
-	// Create a new order node
-	newNode := &OrderNode{
-		order:    order,
-		next:     nil,
-		quantity: int64(order.Quantity),
-	}
+	ob.update(func() {
+		if ob.normalize(order) {
+			ob.addBid(order)
+		}
+	})
+}
 
-	for {
-		// Load the current tail of the bids list
-		tail := atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&ob.bids.tail)))
-
-		if atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&ob.bids.tail)), tail, unsafe.Pointer(newNode)) {
-			if tail != nil {
-				// Link the new node to the previous tail
-				atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(&(*OrderNode)(tail).next)), unsafe.Pointer(newNode))
-			} else {
-				// If the list was empty, set head to the new node
-				atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&ob.bids.head)), nil, unsafe.Pointer(newNode))
-			}
-			return
+func (ob *OrderBook) AddAsk(order *models.Order) {
+	ob.update(func() {
+		if ob.normalize(order) {
+			ob.addAsk(order)
 		}
+	})
+}
+
+func (ob *OrderBook) addBid(order *models.Order) {
+	ob.orders[order.ID] = ob.bids.add(order)
+	ob.traceOrder(L3Add, order, order.Price, order.Remaining, 0)
+}
+
+func (ob *OrderBook) addAsk(order *models.Order) {
+	ob.orders[order.ID] = ob.asks.add(order)
+	ob.traceOrder(L3Add, order, order.Price, order.Remaining, 0)
+}
+
+func (ob *OrderBook) removeBid(order *models.Order) {
+	if node, ok := ob.orders[order.ID]; ok {
+		ob.bids.removeNode(node)
+		delete(ob.orders, order.ID)
 	}
 }
 
-func (ob *OrderBook) AddAsk(order *models.Order) {
-	// Create a new order node
-	newNode := &OrderNode{
-		order:    order,
-		next:     nil,
-		quantity: int64(order.Quantity),
+func (ob *OrderBook) removeAsk(order *models.Order) {
+	if node, ok := ob.orders[order.ID]; ok {
+		ob.asks.removeNode(node)
+		delete(ob.orders, order.ID)
 	}
+}
 
-	for {
-		// Load the current tail of the asks list
-		tail := atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&ob.asks.tail)))
-
-		if atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&ob.asks.tail)), tail, unsafe.Pointer(newNode)) {
-			if tail != nil {
-				// Link the new node to the previous tail.
-				// This is safe because only one goroutine can succeed in the CAS above.
-				// So when we get here, 'tail' is guaranteed to be the previous tail.
-				// However, there will be a momentary inconsistency where the new node is not yet linked to the previous tail.
-				// This is acceptable in a lock-free design as other readers will eventually see the updated list.
-				// Readers must always traverse from head to tail to see the complete list.
-				// This ensures that even if they see an intermediate state, they will eventually see the fully linked list.
-				atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(&(*OrderNode)(tail).next)), unsafe.Pointer(newNode))
-			} else {
-				// If the list was empty, set head to the new node
-				atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&ob.asks.head)), nil, unsafe.Pointer(newNode))
-			}
-			return
+// CancelOrder removes a resting order from the book and returns it in its final state.
+// Orders that are unknown or already filled return ErrOrderNotFound.
+func (ob *OrderBook) CancelOrder(orderID uint64) (order *models.Order, err error) {
+	ob.update(func() { order, err = ob.cancelOrder(orderID, "") })
+	return order, err
+}
+
+func (ob *OrderBook) cancelOrder(orderID uint64, account string) (*models.Order, error) {
+	node, ok := ob.restingOrder(orderID, account)
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+
+	ob.withdraw(node.order, models.ReasonNone)
+	return node.order, nil
+}
+
+// restingOrder finds a resting order. Unless account is empty, another account's
+// order is reported as missing, so its existence is not revealed.
+func (ob *OrderBook) restingOrder(orderID uint64, account string) (*OrderNode, bool) {
+	node, ok := ob.orders[orderID]
+	if !ok || (account != "" && node.order.Account != account) {
+		return nil, false
+	}
+	return node, true
+}
+
+// withdraw takes a resting order off the book as cancelled
+func (ob *OrderBook) withdraw(order *models.Order, reason models.RejectReason) {
+	ob.traceOrder(L3Delete, order, order.Price, order.Remaining, 0)
+	if order.Side == models.Buy {
+		ob.removeBid(order)
+	} else {
+		ob.removeAsk(order)
+	}
+	oldStatus := order.Status
+	order.Status = models.Cancelled
+	order.LastUpdated = time.Now()
+	ob.reportOrder(order, oldStatus, reason, nil)
+}
+
+// AmendOrder changes the price and/or total quantity of a resting order; zero leaves
+// a field unchanged. Reducing quantity at the same price keeps time priority. Any other
+// change sends the order to the back of the queue and matches it like a new order.
+func (ob *OrderBook) AmendOrder(orderID uint64, price, quantity models.Decimal) (order *models.Order, err error) {
+	ob.update(func() { order, err = ob.amendOrder(orderID, "", price, quantity) })
+	return order, err
+}
+
+func (ob *OrderBook) amendOrder(orderID uint64, account string, price, quantity models.Decimal) (*models.Order, error) {
+	node, ok := ob.restingOrder(orderID, account)
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+	order := node.order
+
+	if price.IsZero() {
+		price = order.Price
+	}
+	if quantity.IsZero() {
+		quantity = order.Quantity
+	}
+	price, okPrice := price.Rescale(ob.instrument.PriceScale())
+	quantity, okQty := quantity.Rescale(ob.instrument.QuantityScale())
+	filled := order.Quantity.Sub(order.Remaining)
+	if !okPrice || !okQty || price.Sign() < 0 || quantity.Cmp(filled) <= 0 {
+		return nil, ErrInvalidAmend
+	}
+
+	candidate := *order
+	candidate.Price, candidate.Quantity = price, quantity
+	if err := ob.instrument.ValidateOrder(&candidate); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAmend, err)
+	}
+
+	if price.Equal(order.Price) && quantity.Cmp(order.Quantity) <= 0 {
+		// Size reduction in place keeps queue position
+		remaining := quantity.Sub(filled)
+		if order.Side == models.Buy {
+			ob.bids.reduce(node, order.Remaining.Sub(remaining))
+		} else {
+			ob.asks.reduce(node, order.Remaining.Sub(remaining))
 		}
+		order.Quantity = quantity
+		order.Remaining = remaining
+		order.LastUpdated = time.Now()
+		ob.traceOrder(L3Modify, order, order.Price, remaining, 0)
+		ob.reportOrder(order, order.Status, models.ReasonNone, nil)
+		amended := *order
+		return &amended, nil
 	}
+
+	// Price change or size increase: re-enter as if newly arrived
+	ob.traceOrder(L3Delete, order, order.Price, order.Remaining, 0)
+	if order.Side == models.Buy {
+		ob.removeBid(order)
+	} else {
+		ob.removeAsk(order)
+	}
+	order.Price = price
+	order.Quantity = quantity
+	order.Remaining = quantity.Sub(filled)
+	order.LastUpdated = time.Now()
+	ob.match(order)
+
+	amended := *order
+	return &amended, nil
 }
 
-func (ob *OrderBook) removeBid(order *models.Order) {
+// SetStatus changes the instrument's trading status. A delisted book cannot be reopened.
+func (ob *OrderBook) SetStatus(status models.TradingStatus) error {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+	return ob.changeStatus(status)
+}
 
+func (ob *OrderBook) changeStatus(status models.TradingStatus) error {
+	if ob.instrument.Status == models.StatusDelisted {
+		return ErrInstrumentDelisted
+	}
+	ob.setStatus(status)
+	return nil
 }
 
-func (ob *OrderBook) removeAsk(order *models.Order) {
+// setStatus swaps in a copy of the instrument so readers never see it change; the caller must hold mu
+func (ob *OrderBook) setStatus(status models.TradingStatus) {
+	instrument := *ob.instrument
+	instrument.Status = status
+	ob.instrument = &instrument
+}
 
+// Delist marks the instrument delisted and cancels every resting order, best price first
+func (ob *OrderBook) Delist() (cancelled []*models.Order) {
+	ob.update(func() { cancelled = ob.delist() })
+	return cancelled
 }
 
-func (ob *OrderBook) GetBestBid() (*models.Order, bool) {
-	head := atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&ob.bids.head)))
-	if head == nil {
-		return nil, false
+func (ob *OrderBook) delist() []*models.Order {
+	ob.setStatus(models.StatusDelisted)
+	cancelled := make([]*models.Order, 0, len(ob.orders))
+	for _, side := range []*OrderSide{ob.bids, ob.asks} {
+		for node := side.best(); node != nil; node = side.best() {
+			order := node.order
+			ob.traceOrder(L3Delete, order, order.Price, order.Remaining, 0)
+			side.removeNode(node)
+			delete(ob.orders, order.ID)
+			oldStatus := order.Status
+			order.Status = models.Cancelled
+			order.LastUpdated = time.Now()
+			ob.reportOrder(order, oldStatus, models.ReasonInstrumentNotTrading, nil)
+			cancelled = append(cancelled, order)
+		}
 	}
-	order := (*OrderNode)(head)
-	return order.order, true
+	return cancelled
+}
+
+func (ob *OrderBook) GetBestBid() (*models.Order, bool) {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	return ob.bids.bestOrder()
 }
 
 func (ob *OrderBook) GetBestAsk() (*models.Order, bool) {
-	head := atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&ob.asks.head)))
-	if head == nil {
-		return nil, false
-	}
-	order := (*OrderNode)(head)
-	return order.order, true
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	return ob.asks.bestOrder()
 }
 
+// GetMarketDepth aggregates up to level price levels a side, best first, or every
+// level if level is not positive. Both sides and the sequence are read together.
 func (ob *OrderBook) GetMarketDepth(level int32) *OrderBookSnapshot {
-	snapshot := &OrderBookSnapshot{
-		Bids: make([]PriceLevel, 0, level),
-		Asks: make([]PriceLevel, 0, level),
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	return &OrderBookSnapshot{
+		Instrument: ob.instrument.Symbol,
+		Sequence:   ob.sequence,
+		Timestamp:  time.Now().UnixNano(),
+		Bids:       ob.bids.depth(level),
+		Asks:       ob.asks.depth(level),
+	}
+}
+
+func newOrderSide(better func(a, b models.Decimal) bool) *OrderSide {
+	return &OrderSide{
+		levels: newPriceLevels(better),
 	}
+}
+
+// add appends the order to the back of its price level's queue
+func (os *OrderSide) add(order *models.Order) *OrderNode {
+	node := &OrderNode{order: order}
+	os.levels.getOrCreate(order.Price).pushBack(node)
+	os.counter++
+	os.markChanged(order.Price)
+	return node
+}
 
-	// TODO: implement the logic to populate snapshot.Bids and snapshot.Asks
+// removeNode unlinks a resting node, dropping its level once empty
+func (os *OrderSide) removeNode(node *OrderNode) {
+	level := node.level
+	level.unlink(node)
+	os.counter--
+	os.markChanged(level.price)
+	if level.count == 0 {
+		os.levels.remove(level.price)
+	}
+}
 
-	return snapshot
+// reduce accounts for qty taken off a resting node that was already applied to its order
+func (os *OrderSide) reduce(node *OrderNode, qty models.Decimal) {
+	node.level.volume = node.level.volume.Sub(qty)
+	os.markChanged(node.level.price)
 }
-func (os *OrderSide) GetDepth(price float64) int32 {
-	var count int32
-	current := (*OrderNode)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&os.head))))
 
-	for current != nil {
-		if current.order.Price == price {
-			count++
+// canFill reports whether at least qty rests at prices no worse than limit
+func (os *OrderSide) canFill(limit, qty models.Decimal) bool {
+	for level := os.levels.best(); level != nil; level = level.forward[0] {
+		if os.levels.better(limit, level.price) {
+			break // Beyond the limit price
+		}
+		qty = qty.Sub(level.volume)
+		if qty.Sign() <= 0 {
+			return true
 		}
-		current = (*OrderNode)(atomic.LoadPointer(&current.next))
 	}
+	return false
+}
 
-	return count
+// best returns the oldest node at the best price, or nil if the side is empty
+func (os *OrderSide) best() *OrderNode {
+	level := os.levels.best()
+	if level == nil {
+		return nil
+	}
+	return level.head
 }
 
-func (os *OrderSide) GetTotalVolume(price float64) int64 {
-	var volume int64
-	current := (*OrderNode)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&os.head))))
+// bestOrder returns the oldest order at the best price
+func (os *OrderSide) bestOrder() (*models.Order, bool) {
+	node := os.best()
+	if node == nil {
+		return nil, false
+	}
+	return node.order, true
+}
 
-	for current != nil {
-		if current.order.Price == price {
-			volume += atomic.LoadInt64(&current.quantity)
-		}
-		current = (*OrderNode)(atomic.LoadPointer(&current.next))
+// depth aggregates up to limit levels, best first; all of them if limit is not positive
+func (os *OrderSide) depth(limit int32) []PriceLevel {
+	levels := make([]PriceLevel, 0, min(int(max(limit, 0)), os.levels.length))
+	for level := os.levels.best(); level != nil && (limit <= 0 || int32(len(levels)) < limit); level = level.forward[0] {
+		levels = append(levels, PriceLevel{Price: level.price, Quantity: level.volume, Orders: int(level.count)})
 	}
+	return levels
+}
 
-	return volume
+func (os *OrderSide) GetDepth(price models.Decimal) int32 {
+	level := os.levels.find(price)
+	if level == nil {
+		return 0
+	}
+	return level.count
+}
+
+func (os *OrderSide) GetTotalVolume(price models.Decimal) models.Decimal {
+	level := os.levels.find(price)
+	if level == nil {
+		return models.Decimal{}
+	}
+	return level.volume
 }
@@ -0,0 +1,344 @@
+package engine
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/aeromatch/internal/models"
+)
+
+func newTestOrder(id uint64, side models.OrderSide, orderType models.OrderType, price, qty string) *models.Order {
+	return &models.Order{
+		ID:         id,
+		Price:      models.MustParseDecimal(price),
+		Quantity:   models.MustParseDecimal(qty),
+		Remaining:  models.MustParseDecimal(qty),
+		Side:       side,
+		Type:       orderType,
+		Instrument: "BTC-USD",
+	}
+}
+
+func newTestBook(tick, lot string) *OrderBook {
+	return NewOrderBook(&models.Instrument{
+		Symbol:   "BTC-USD",
+		TickSize: models.MustParseDecimal(tick),
+		LotSize:  models.MustParseDecimal(lot),
+	}, 64, WaitPark)
+}
+
+// drainTrades returns every trade the book has produced so far
+func drainTrades(ob *OrderBook) []*models.Trade {
+	var trades []*models.Trade
+	for {
+		select {
+		case trade := <-ob.processedTrades:
+			trades = append(trades, trade)
+		default:
+			return trades
+		}
+	}
+}
+
+func TestFillOrKill(t *testing.T) {
+	// Asks: 3 @ 100, 2 @ 101, 5 @ 103. Bids: 4 @ 99, 1 @ 98, 3 @ 96.
+	seed := func() *OrderBook {
+		ob := newTestBook("0.01", "1")
+		ob.AddAsk(newTestOrder(1, models.Sell, models.Limit, "100", "3"))
+		ob.AddAsk(newTestOrder(2, models.Sell, models.Limit, "101", "2"))
+		ob.AddAsk(newTestOrder(3, models.Sell, models.Limit, "103", "5"))
+		ob.AddBid(newTestOrder(4, models.Buy, models.Limit, "99", "4"))
+		ob.AddBid(newTestOrder(5, models.Buy, models.Limit, "98", "1"))
+		ob.AddBid(newTestOrder(6, models.Buy, models.Limit, "96", "3"))
+		return ob
+	}
+
+	tests := []struct {
+		name       string
+		side       models.OrderSide
+		price      string
+		qty        string
+		wantFilled bool
+		wantTrades int
+	}{
+		{"buy within best level", models.Buy, "100", "2", true, 1},
+		{"buy exactly two levels", models.Buy, "101", "5", true, 2},
+		{"buy sweeping three levels", models.Buy, "105", "9", true, 3},
+		{"buy short by one across levels", models.Buy, "101", "6", false, 0},
+		{"buy more than the whole side", models.Buy, "200", "11", false, 0},
+		{"buy limit below best ask", models.Buy, "99", "1", false, 0},
+		{"sell within best level", models.Sell, "99", "4", true, 1},
+		{"sell exactly two levels", models.Sell, "98", "5", true, 2},
+		{"sell short at limit", models.Sell, "97", "6", false, 0},
+		{"sell sweeping three levels", models.Sell, "96", "8", true, 3},
+		{"sell limit above best bid", models.Sell, "100", "1", false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ob := seed()
+			order := newTestOrder(100, tt.side, models.FOK, tt.price, tt.qty)
+
+			if tt.side == models.Buy {
+				ob.ProcessBuyOrder(order)
+			} else {
+				ob.ProcessSellOrder(order)
+			}
+
+			trades := drainTrades(ob)
+			if len(trades) != tt.wantTrades {
+				t.Fatalf("got %d trades, want %d", len(trades), tt.wantTrades)
+			}
+
+			if tt.wantFilled {
+				if order.Status != models.Filled || !order.Remaining.IsZero() {
+					t.Fatalf("got status %v remaining %v, want fully filled", order.Status, order.Remaining)
+				}
+				return
+			}
+
+			if order.Status != models.Cancelled || !order.Remaining.Equal(models.MustParseDecimal(tt.qty)) {
+				t.Fatalf("got status %v remaining %v, want killed untouched", order.Status, order.Remaining)
+			}
+			if len(ob.orders) != 6 {
+				t.Fatalf("killed FOK changed the book: %d resting orders, want 6", len(ob.orders))
+			}
+
+			select {
+			case event := <-ob.orderEvents:
+				if event.Order.ID != order.ID || event.Order.Status != models.Cancelled || event.Reason != models.ReasonInsufficientLiquidity {
+					t.Fatalf("got event for order %d reason %v", event.Order.ID, event.Reason)
+				}
+			default:
+				t.Fatal("no kill event emitted")
+			}
+		})
+	}
+}
+
+func TestFillOrKillAfterPartialFillsOfResting(t *testing.T) {
+	ob := newTestBook("0.01", "1")
+	ob.AddAsk(newTestOrder(1, models.Sell, models.Limit, "100", "5"))
+	ob.ProcessBuyOrder(newTestOrder(2, models.Buy, models.Limit, "100", "3"))
+	drainTrades(ob)
+
+	// Only 2 left at 100; level volume must reflect the earlier fill
+	order := newTestOrder(3, models.Buy, models.FOK, "100", "3")
+	ob.ProcessBuyOrder(order)
+
+	if order.Status != models.Cancelled {
+		t.Fatalf("got status %v, want cancelled", order.Status)
+	}
+	if trades := drainTrades(ob); len(trades) != 0 {
+		t.Fatalf("got %d trades, want none", len(trades))
+	}
+}
+
+func TestPostOnly(t *testing.T) {
+	tests := []struct {
+		name       string
+		side       models.OrderSide
+		price      string
+		mode       models.PostOnlyMode
+		wantStatus models.OrderStatus
+		wantPrice  string
+		minPrice   string // Price band, if any
+		maxPrice   string
+	}{
+		{"buy below best ask rests", models.Buy, "99.5", models.PostOnlyReject, models.New, "99.5", "", ""},
+		{"buy at best ask rejected", models.Buy, "100", models.PostOnlyReject, models.Rejected, "100", "", ""},
+		{"buy through best ask slides", models.Buy, "102", models.PostOnlySlide, models.New, "99.99", "", ""},
+		{"buy sliding below the band rejected", models.Buy, "102", models.PostOnlySlide, models.Rejected, "102", "100", ""},
+		{"sell above best bid rests", models.Sell, "99.5", models.PostOnlyReject, models.New, "99.5", "", ""},
+		{"sell at best bid rejected", models.Sell, "99", models.PostOnlyReject, models.Rejected, "99", "", ""},
+		{"sell through best bid slides", models.Sell, "97", models.PostOnlySlide, models.New, "99.01", "", ""},
+		{"sell sliding above the band rejected", models.Sell, "97", models.PostOnlySlide, models.Rejected, "97", "", "99"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ob := newTestBook("0.01", "1")
+			if tt.minPrice != "" {
+				ob.instrument.MinPrice = models.MustParseDecimal(tt.minPrice)
+			}
+			if tt.maxPrice != "" {
+				ob.instrument.MaxPrice = models.MustParseDecimal(tt.maxPrice)
+			}
+			ob.AddAsk(newTestOrder(1, models.Sell, models.Limit, "100", "1"))
+			ob.AddBid(newTestOrder(2, models.Buy, models.Limit, "99", "1"))
+
+			order := newTestOrder(3, tt.side, models.PostOnly, tt.price, "1")
+			order.PostOnly = tt.mode
+			if tt.side == models.Buy {
+				ob.ProcessBuyOrder(order)
+			} else {
+				ob.ProcessSellOrder(order)
+			}
+
+			if trades := drainTrades(ob); len(trades) != 0 {
+				t.Fatalf("post-only order took liquidity: %d trades", len(trades))
+			}
+			if order.Status != tt.wantStatus || !order.Price.Equal(models.MustParseDecimal(tt.wantPrice)) {
+				t.Fatalf("got status %v price %v, want %v at %v", order.Status, order.Price, tt.wantStatus, tt.wantPrice)
+			}
+
+			_, resting := ob.orders[order.ID]
+			if resting != (tt.wantStatus == models.New) {
+				t.Fatalf("resting = %v with status %v", resting, order.Status)
+			}
+			if tt.wantStatus == models.Rejected {
+				event := <-ob.orderEvents
+				if event.Reason != models.ReasonPostOnlyWouldCross {
+					t.Fatalf("got reason %v", event.Reason)
+				}
+			}
+		})
+	}
+}
+
+func TestFractionalQuantities(t *testing.T) {
+	ob := newTestBook("0.01", "0.00000001")
+	price := models.MustParseDecimal("67012.50")
+	for i, qty := range []string{"0.1", "0.2", "0.00000001"} {
+		ob.AddAsk(newTestOrder(uint64(i+1), models.Sell, models.Limit, "67012.50", qty))
+	}
+
+	if got := ob.asks.GetTotalVolume(price); got.String() != "0.30000001" {
+		t.Fatalf("total volume = %v, want 0.30000001", got)
+	}
+
+	ob.ProcessBuyOrder(newTestOrder(10, models.Buy, models.Limit, "67012.5", "0.15"))
+	if got := ob.asks.GetTotalVolume(price); got.String() != "0.15000001" {
+		t.Fatalf("total volume after fill = %v, want 0.15000001", got)
+	}
+
+	tooFine := newTestOrder(11, models.Buy, models.Limit, "67012.505", "1")
+	ob.ProcessBuyOrder(tooFine)
+	if tooFine.Status != models.Rejected {
+		t.Fatalf("got status %v for a price finer than the tick", tooFine.Status)
+	}
+}
+
+func TestAmendOrder(t *testing.T) {
+	ob := newTestBook("0.01", "1")
+	for id := uint64(1); id <= 3; id++ {
+		ob.AddAsk(newTestOrder(id, models.Sell, models.Limit, "100", "3"))
+	}
+	queue := func() []uint64 {
+		var ids []uint64
+		for _, order := range restingAt(ob) {
+			ids = append(ids, order.ID)
+		}
+		return ids
+	}
+	amend := func(id uint64, price, qty string) *models.Order {
+		t.Helper()
+		var p, q models.Decimal
+		if price != "" {
+			p = models.MustParseDecimal(price)
+		}
+		if qty != "" {
+			q = models.MustParseDecimal(qty)
+		}
+		amended, err := ob.AmendOrder(id, p, q)
+		if err != nil {
+			t.Fatalf("amend %d: %v", id, err)
+		}
+		return amended
+	}
+
+	// A smaller size keeps the order's place in the queue
+	if amended := amend(1, "", "2"); !amended.Remaining.Equal(models.MustParseDecimal("2")) {
+		t.Fatalf("reduced order has %s remaining, want 2", amended.Remaining)
+	}
+	if got := queue(); !slices.Equal(got, []uint64{1, 2, 3}) {
+		t.Fatalf("queue after a size decrease is %v, want [1 2 3]", got)
+	}
+	if got := ob.asks.GetTotalVolume(models.MustParseDecimal("100")); got.String() != "8" {
+		t.Fatalf("level volume %s after a size decrease, want 8", got)
+	}
+
+	// A larger size goes to the back of the level
+	amend(1, "", "4")
+	if got := queue(); !slices.Equal(got, []uint64{2, 3, 1}) {
+		t.Fatalf("queue after a size increase is %v, want [2 3 1]", got)
+	}
+
+	// So does a new price, even one the order later returns to
+	amend(2, "100.50", "")
+	amend(2, "100", "")
+	if got := queue(); !slices.Equal(got, []uint64{3, 1, 2}) {
+		t.Fatalf("queue after a price change is %v, want [3 1 2]", got)
+	}
+
+	// A bid amended across the spread matches at once, against the oldest ask
+	ob.AddBid(newTestOrder(4, models.Buy, models.Limit, "99", "5"))
+	drainTrades(ob)
+	if amended := amend(4, "100", ""); amended.Status != models.Filled {
+		t.Fatalf("crossing amend left the bid %v", amended.Status)
+	}
+	trades := drainTrades(ob)
+	if len(trades) != 2 || trades[0].MakerOrderID != 3 || trades[1].MakerOrderID != 1 || trades[0].TakerOrderID != 4 {
+		t.Fatalf("crossing amend traded %+v, want order 4 against 3 then 1", trades)
+	}
+	if got := queue(); !slices.Equal(got, []uint64{1, 2}) {
+		t.Fatalf("queue after the crossing amend is %v, want [1 2]", got)
+	}
+	if _, resting := ob.orders[4]; resting {
+		t.Fatal("filled bid still resting")
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	ob := newTestBook("0.01", "1")
+	ob.AddAsk(newTestOrder(1, models.Sell, models.Limit, "100", "5"))
+	ob.AddBid(newTestOrder(2, models.Buy, models.Limit, "98", "1"))
+	ob.ProcessBuyOrder(newTestOrder(3, models.Buy, models.Limit, "100", "2"))
+	ob.ProcessSellOrder(newTestOrder(4, models.Sell, models.Limit, "98", "1")) // Fills order 2
+	drainTrades(ob)
+
+	cancelled, err := ob.CancelOrder(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cancelled.Status != models.Cancelled || !cancelled.Remaining.Equal(models.MustParseDecimal("3")) {
+		t.Fatalf("cancelled order is %v with %s remaining, want cancelled with 3", cancelled.Status, cancelled.Remaining)
+	}
+	if _, ok := ob.GetBestAsk(); ok {
+		t.Fatal("ask still on the book after its cancel")
+	}
+
+	for _, tt := range []struct {
+		name string
+		id   uint64
+	}{
+		{"already cancelled", 1},
+		{"filled", 2},
+		{"unknown", 99},
+	} {
+		if _, err := ob.CancelOrder(tt.id); !errors.Is(err, ErrOrderNotFound) {
+			t.Fatalf("%s order: got %v, want ErrOrderNotFound", tt.name, err)
+		}
+	}
+}
+
+func TestRestingOrderFilledValueOverflow(t *testing.T) {
+	ob := newTestBook("0.01", "1")
+	full := newTestOrder(1, models.Sell, models.Limit, "100", "5")
+	full.FilledValue = models.MustParseDecimal("9223372036854775800") // No room for another fill
+	ob.AddAsk(full)
+	ob.AddAsk(newTestOrder(2, models.Sell, models.Limit, "100", "5"))
+
+	ob.ProcessBuyOrder(newTestOrder(3, models.Buy, models.Limit, "100", "1"))
+	trades := drainTrades(ob)
+	if len(trades) != 1 || trades[0].MakerOrderID != 2 {
+		t.Fatalf("got trades %+v, want one against order 2", trades)
+	}
+	if full.Status != models.Cancelled {
+		t.Fatalf("order 1 is %v, want it withdrawn rather than left at the front of the level", full.Status)
+	}
+	if _, err := ob.CancelOrder(1); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("order 1 still on the book: %v", err)
+	}
+}
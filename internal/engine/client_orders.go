@@ -0,0 +1,130 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aeromatch/internal/models"
+)
+
+// ErrDuplicateOrder matches a DuplicateOrderError
+var ErrDuplicateOrder = errors.New("duplicate client order ID")
+
+// DuplicateOrderError refuses an order whose client order ID its account already
+// used within the dedupe window. Nothing is submitted; the fields acknowledge the
+// original order.
+type DuplicateOrderError struct {
+	OrderID   uint64
+	Timestamp time.Time
+}
+
+func (e *DuplicateOrderError) Error() string {
+	return fmt.Sprintf("%v: already used by order %d", ErrDuplicateOrder, e.OrderID)
+}
+
+func (e *DuplicateOrderError) Unwrap() error {
+	return ErrDuplicateOrder
+}
+
+type clientOrderKey struct {
+	account   string
+	clientOID string
+}
+
+type clientOrder struct {
+	key       clientOrderKey
+	orderID   uint64
+	timestamp time.Time
+}
+
+// clientOrderIDs remembers the client order IDs each account used within the
+// window, so a resubmitted order is acknowledged rather than entered twice
+type clientOrderIDs struct {
+	mu     sync.Mutex
+	window time.Duration // Zero turns deduplication off
+	byKey  map[clientOrderKey]clientOrder
+	queue  []clientOrder // Oldest timestamp first, for expiry
+}
+
+func newClientOrderIDs() *clientOrderIDs {
+	return &clientOrderIDs{byKey: make(map[clientOrderKey]clientOrder)}
+}
+
+func (c *clientOrderIDs) setWindow(window time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.window = window
+}
+
+// reserve claims the order's client order ID, or returns a DuplicateOrderError
+// if its account used the ID within the window. Orders without one always pass.
+func (c *clientOrderIDs) reserve(order *models.Order) error {
+	if order.ClientOID == "" {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.window <= 0 {
+		return nil
+	}
+	c.expire(time.Now())
+	key := clientOrderKey{order.Account, order.ClientOID}
+	if original, ok := c.byKey[key]; ok {
+		return &DuplicateOrderError{OrderID: original.orderID, Timestamp: original.timestamp}
+	}
+	c.add(key, order)
+	return nil
+}
+
+// remember records a replayed order's client order ID if it is still within the window
+func (c *clientOrderIDs) remember(order *models.Order) {
+	if order.ClientOID == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.window <= 0 || time.Since(order.Timestamp) >= c.window {
+		return
+	}
+	c.add(clientOrderKey{order.Account, order.ClientOID}, order)
+}
+
+// release forgets a reservation for an order that never reached the engine
+func (c *clientOrderIDs) release(order *models.Order) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := clientOrderKey{order.Account, order.ClientOID}
+	if original, ok := c.byKey[key]; ok && original.orderID == order.ID {
+		delete(c.byKey, key)
+	}
+}
+
+// add records the order; the caller must hold mu. Replayed orders keep their
+// original timestamps, which may be older than entries already queued, so the
+// entry is inserted in timestamp order rather than appended.
+func (c *clientOrderIDs) add(key clientOrderKey, order *models.Order) {
+	entry := clientOrder{key: key, orderID: order.ID, timestamp: order.Timestamp}
+	if entry.timestamp.IsZero() {
+		entry.timestamp = time.Now()
+	}
+	c.byKey[key] = entry
+	i := len(c.queue)
+	for i > 0 && c.queue[i-1].timestamp.After(entry.timestamp) {
+		i--
+	}
+	c.queue = slices.Insert(c.queue, i, entry)
+}
+
+// expire drops entries older than the window; the caller must hold mu
+func (c *clientOrderIDs) expire(now time.Time) {
+	for len(c.queue) > 0 && now.Sub(c.queue[0].timestamp) >= c.window {
+		oldest := c.queue[0]
+		if current, ok := c.byKey[oldest.key]; ok && current.orderID == oldest.orderID {
+			delete(c.byKey, oldest.key)
+		}
+		c.queue = c.queue[1:]
+	}
+}
@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/aeromatch/internal/models"
+)
+
+// BookUpdate is an L2 view of one instrument's book. A subscriber's first update
+// for a book is a snapshot of every level; each later one holds the levels a
+// single command changed, with zero quantity for levels it emptied. Sequence
+// increases by one per update of the book, so a reader that sees it skip has
+// missed an update and must wait for or ask for a new snapshot.
+type BookUpdate struct {
+	Instrument string       `json:"instrument"`
+	Sequence   uint64       `json:"sequence"`
+	Snapshot   bool         `json:"snapshot"` // Levels replace the whole book rather than amend it
+	Timestamp  int64        `json:"timestamp"`
+	Bids       []PriceLevel `json:"bids"`
+	Asks       []PriceLevel `json:"asks"`
+}
+
+// depthSnapshot returns every level as a snapshot update at the last sequence
+// published; the caller must hold mu
+func (ob *OrderBook) depthSnapshot() *BookUpdate {
+	return &BookUpdate{
+		Instrument: ob.instrument.Symbol,
+		Sequence:   ob.depthSequence,
+		Snapshot:   true,
+		Timestamp:  time.Now().UnixNano(),
+		Bids:       ob.bids.depth(0),
+		Asks:       ob.asks.depth(0),
+	}
+}
+
+// publishDepth puts the levels changed since the last update on the market data
+// bus; the caller must hold mu
+func (ob *OrderBook) publishDepth() {
+	if ob.replaying || ob.marketData == nil || !ob.marketData.wants(ob.instrument.Symbol, ChannelBook) {
+		ob.bids.changed = ob.bids.changed[:0]
+		ob.asks.changed = ob.asks.changed[:0]
+		return
+	}
+	bids, asks := ob.bids.changedLevels(), ob.asks.changedLevels()
+	if len(bids) == 0 && len(asks) == 0 {
+		return
+	}
+	ob.depthSequence++
+	ob.marketData.publish(MarketData{Instrument: ob.instrument.Symbol, Book: &BookUpdate{
+		Instrument: ob.instrument.Symbol,
+		Sequence:   ob.depthSequence,
+		Timestamp:  time.Now().UnixNano(),
+		Bids:       bids,
+		Asks:       asks,
+	}})
+}
+
+// changedLevels returns the current state of every level changed since the last
+// call, once each and in the order first changed
+func (os *OrderSide) changedLevels() []PriceLevel {
+	if len(os.changed) == 0 {
+		return nil
+	}
+	levels := make([]PriceLevel, 0, len(os.changed))
+	seen := make(map[models.Decimal]struct{}, len(os.changed)) // Prices share the book's scale
+	for _, price := range os.changed {
+		if _, dup := seen[price]; dup {
+			continue
+		}
+		seen[price] = struct{}{}
+		if level := os.levels.find(price); level != nil {
+			levels = append(levels, PriceLevel{Price: level.price, Quantity: level.volume, Orders: int(level.count)})
+		} else {
+			levels = append(levels, PriceLevel{Price: price})
+		}
+	}
+	os.changed = os.changed[:0]
+	return levels
+}
+
+// markChanged records that the level at price changed, for the next book update
+func (os *OrderSide) markChanged(price models.Decimal) {
+	if n := len(os.changed); n == 0 || !os.changed[n-1].Equal(price) {
+		os.changed = append(os.changed, price)
+	}
+}
@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aeromatch/internal/models"
+)
+
+// JournalEntryType identifies what a journal entry records
+type JournalEntryType uint8
+
+const (
+	JournalOrder      JournalEntryType = iota + 1 // Accepted new order
+	JournalCancel                                 // Cancel request
+	JournalAmend                                  // Amend request
+	JournalStatus                                 // Halt or resume
+	JournalDelist                                 // Instrument delisted
+	JournalInstrument                             // Instrument listed at runtime
+	JournalTrade                                  // Trade produced by matching
+	JournalRun                                    // A run that did not recover started; earlier state is void
+)
+
+// JournalEntry is one record of the engine's write-ahead journal. Inputs carry the
+// per-instrument sequence number the sequencer gave them; trades carry the sequence
+// of the command that produced them.
+type JournalEntry struct {
+	Type       JournalEntryType     `json:"type"`
+	Sequence   uint64               `json:"seq"`
+	Instrument string               `json:"instrument"`
+	Timestamp  int64                `json:"ts"`
+	Order      *models.Order        `json:"order,omitempty"`
+	OrderID    uint64               `json:"order_id,omitempty"`
+	Account    string               `json:"account,omitempty"` // Cancels and amends limited to one owner
+	Price      models.Decimal       `json:"price"`             // Amends
+	Quantity   models.Decimal       `json:"quantity"`          // Amends
+	Status     models.TradingStatus `json:"status"`            // Status changes
+	Definition *models.Instrument   `json:"definition,omitempty"`
+	Trade      *models.Trade        `json:"trade,omitempty"`
+}
+
+// Journal is an append-only log of everything the engine accepted and produced.
+// Entries are appended in sequence order per instrument. Implementations must be
+// safe for concurrent use.
+type Journal interface {
+	// Append writes the entry after all earlier ones and must not retain it. An error
+	// means the entry was not written. If durable is non-nil it receives the outcome
+	// once the entry is on stable storage.
+	Append(entry *JournalEntry, durable chan<- error) error
+	Close() error
+}
+
+// journalEntry describes a sequenced command
+func journalEntry(cmd *bookCommand) *JournalEntry {
+	entry := &JournalEntry{
+		Sequence:   cmd.sequence,
+		Instrument: cmd.instrument,
+		Timestamp:  time.Now().UnixNano(),
+	}
+	switch cmd.kind {
+	case cmdNewOrder:
+		entry.Type = JournalOrder
+		entry.Order = cmd.order
+	case cmdCancel:
+		entry.Type = JournalCancel
+		entry.OrderID = cmd.orderID
+		entry.Account = cmd.account
+	case cmdAmend:
+		entry.Type = JournalAmend
+		entry.OrderID = cmd.orderID
+		entry.Account = cmd.account
+		entry.Price = cmd.price
+		entry.Quantity = cmd.quantity
+	case cmdSetStatus:
+		entry.Type = JournalStatus
+		entry.Status = cmd.status
+	case cmdDelist:
+		entry.Type = JournalDelist
+	}
+	return entry
+}
+
+// Replay applies a journal entry through the same matching code that produced it,
+// rebuilding books, restoring the trade and execution counters and the trade
+// history and remembering recent client order IDs. Entries must be replayed in
+// journal order before Start; ones a book already reflects are skipped.
+func (m *MatchingEngine) Replay(entry *JournalEntry) error {
+	m.lifecycleMu.Lock()
+	running := m.running
+	m.lifecycleMu.Unlock()
+	if running {
+		return ErrEngineRunning
+	}
+
+	switch entry.Type {
+	case JournalInstrument:
+		if m.getOrderBook(entry.Instrument) != nil {
+			return nil // Also listed by configuration
+		}
+		_, err := m.AddInstrument(entry.Definition)
+		return err
+	case JournalRun:
+		return nil // Recover decides where replay starts
+	case JournalTrade:
+		// Trades are regenerated by replaying their inputs; the journaled ones only
+		// guarantee the counters never fall behind what was published, and refill
+		// the trade history, which snapshots do not carry
+		restoreCounter(&tradeIDCounter, entry.Trade.TradeID)
+		restoreCounter(&executionCounter, entry.Trade.ExecutionID)
+		if book := m.getOrderBook(entry.Instrument); book != nil {
+			book.history.record(entry.Trade)
+		}
+		return nil
+	}
+
+	book := m.getOrderBook(entry.Instrument)
+	if book == nil {
+		return fmt.Errorf("%w: %s at sequence %d", ErrUnknownInstrument, entry.Instrument, entry.Sequence)
+	}
+	if entry.Type == JournalOrder {
+		m.clientOrders.remember(entry.Order) // Even if a snapshot already reflects the order
+	}
+	if entry.Sequence <= book.nextSeq {
+		return nil
+	}
+	if entry.Sequence != book.nextSeq+1 {
+		return fmt.Errorf("%w: %s jumps from %d to %d", ErrJournalGap, entry.Instrument, book.nextSeq, entry.Sequence)
+	}
+
+	cmd := bookCommand{
+		instrument: entry.Instrument,
+		sequence:   entry.Sequence,
+		order:      entry.Order,
+		orderID:    entry.OrderID,
+		account:    entry.Account,
+		price:      entry.Price,
+		quantity:   entry.Quantity,
+		status:     entry.Status,
+	}
+	switch entry.Type {
+	case JournalOrder:
+		cmd.kind = cmdNewOrder
+	case JournalCancel:
+		cmd.kind = cmdCancel
+	case JournalAmend:
+		cmd.kind = cmdAmend
+	case JournalStatus:
+		cmd.kind = cmdSetStatus
+	case JournalDelist:
+		cmd.kind = cmdDelist
+	default:
+		return fmt.Errorf("unknown journal entry type %d", entry.Type)
+	}
+
+	book.replaying = true
+	book.apply(cmd) // Rejections replay as rejections; their outcome is not needed
+	book.replaying = false
+	book.nextSeq = entry.Sequence
+
+	if cmd.kind == cmdDelist {
+		m.removeBook(entry.Instrument, book)
+	}
+	return nil
+}
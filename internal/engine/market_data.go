@@ -0,0 +1,475 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aeromatch/internal/models"
+)
+
+// ErrSubscriberBehind ends a disconnect-policy subscription whose queue filled up
+var ErrSubscriberBehind = errors.New("market data subscriber fell behind")
+
+// ErrSubscriptionClosed is returned by Next once the subscriber has been closed
+var ErrSubscriptionClosed = errors.New("market data subscription closed")
+
+// SlowConsumerPolicy decides what the bus does with a message for a subscriber
+// whose queue is full
+type SlowConsumerPolicy uint8
+
+const (
+	PolicyDrop       SlowConsumerPolicy = iota // Discard the message; book readers see the sequence skip
+	PolicyDisconnect                           // End the subscription with ErrSubscriberBehind
+	PolicyConflate                             // Discard the message; missed book updates are replaced by a snapshot of the latest book
+)
+
+func (p SlowConsumerPolicy) String() string {
+	switch p {
+	case PolicyDrop:
+		return "drop"
+	case PolicyDisconnect:
+		return "disconnect"
+	case PolicyConflate:
+		return "conflate"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseSlowConsumerPolicy accepts "drop", "disconnect" or "conflate"
+func ParseSlowConsumerPolicy(s string) (SlowConsumerPolicy, error) {
+	switch s {
+	case "drop":
+		return PolicyDrop, nil
+	case "disconnect":
+		return PolicyDisconnect, nil
+	case "conflate":
+		return PolicyConflate, nil
+	default:
+		return 0, fmt.Errorf("unknown slow consumer policy %q", s)
+	}
+}
+
+// Channel is a kind of market data; channels combine as a bit set
+type Channel uint8
+
+const (
+	ChannelTrades  Channel = 1 << iota
+	ChannelBook            // L2 price level updates
+	ChannelOrders          // L3 order-by-order updates
+	ChannelCandles         // OHLCV candles, updated by every trade
+)
+
+// MarketData is one message on the bus: a trade, an L2 book update, an L3 update
+// or a candle
+type MarketData struct {
+	Instrument string
+	Trade      *models.Trade  // Set for trades
+	Book       *BookUpdate    // Set for book updates
+	Orders     *L3Update      // Set for order-by-order updates
+	Candle     *models.Candle // Set for candles; the latest state of a candle that may still change
+}
+
+// channel returns the channel the message belongs to
+func (d MarketData) channel() Channel {
+	switch {
+	case d.Book != nil:
+		return ChannelBook
+	case d.Orders != nil:
+		return ChannelOrders
+	case d.Candle != nil:
+		return ChannelCandles
+	default:
+		return ChannelTrades
+	}
+}
+
+// sequence returns the book sequence of an L2 or L3 update
+func (d MarketData) sequence() uint64 {
+	if d.Book != nil {
+		return d.Book.Sequence
+	}
+	return d.Orders.Sequence
+}
+
+// SubscriptionOptions selects what a subscriber receives and how it is treated when slow
+type SubscriptionOptions struct {
+	Instruments []string // Topics; empty subscribes to every instrument
+	Channels    Channel  // Zero means trades and book updates
+	Buffer      int      // Messages queued for the subscriber; zero means 1024
+	Policy      SlowConsumerPolicy
+
+	CandleIntervals []time.Duration // Candles wanted; empty means every one of CandleIntervals
+}
+
+// feed is one channel of one instrument; an empty instrument stands for all of them
+type feed struct {
+	instrument string
+	channel    Channel
+}
+
+// snapshotted reports whether the channel's updates must start from a snapshot
+func (c Channel) snapshotted() bool {
+	return c == ChannelBook || c == ChannelOrders
+}
+
+const defaultSubscriberBuffer = 1024
+
+// MarketDataBus fans market data out to every interested subscriber.
+// Publishing never blocks: each subscriber has its own bounded queue and its
+// policy decides what happens when that queue is full.
+//
+// L2 and L3 updates are published under their book's write lock and snapshots
+// are taken under its read lock, so a subscriber's snapshot and the updates after
+// it line up exactly. Locks are always taken book, then bus, then subscriber.
+type MarketDataBus struct {
+	mu          sync.RWMutex
+	subscribers map[*MarketDataSubscriber]struct{}
+	feeds       map[feed]int // Subscribers per feed
+	lookup      func(instrument string) *OrderBook
+}
+
+func newMarketDataBus(lookup func(string) *OrderBook) *MarketDataBus {
+	return &MarketDataBus{
+		subscribers: make(map[*MarketDataSubscriber]struct{}),
+		feeds:       make(map[feed]int),
+		lookup:      lookup,
+	}
+}
+
+// Subscribe adds a subscriber. Its first L2 or L3 message for each of its
+// instruments is a snapshot; with no instruments, each instrument's snapshot comes
+// just before its first change.
+func (b *MarketDataBus) Subscribe(opts SubscriptionOptions) *MarketDataSubscriber {
+	if opts.Buffer <= 0 {
+		opts.Buffer = defaultSubscriberBuffer
+	}
+	if opts.Channels == 0 {
+		opts.Channels = ChannelTrades | ChannelBook
+	}
+	s := &MarketDataSubscriber{
+		bus:       b,
+		policy:    opts.Policy,
+		channels:  opts.Channels,
+		intervals: opts.CandleIntervals,
+		queue:     make(chan MarketData, opts.Buffer),
+		wake:      make(chan struct{}, 1),
+		done:      make(chan struct{}),
+		stale:     make(map[feed]struct{}),
+		synced:    make(map[feed]uint64),
+	}
+	if len(opts.Instruments) > 0 {
+		s.topics = make(map[string]struct{}, len(opts.Instruments))
+		for _, instrument := range opts.Instruments {
+			s.topics[instrument] = struct{}{}
+			for _, channel := range []Channel{ChannelBook, ChannelOrders} {
+				if s.channels&channel != 0 {
+					s.stale[feed{instrument, channel}] = struct{}{}
+				}
+			}
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[s] = struct{}{}
+	s.eachFeed(func(f feed) { b.feeds[f]++ })
+	return s
+}
+
+// wants reports whether anyone listens to the instrument's channel, so its updates need building
+func (b *MarketDataBus) wants(instrument string, channel Channel) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.wantsLocked(instrument, channel)
+}
+
+func (b *MarketDataBus) wantsLocked(instrument string, channel Channel) bool {
+	return b.feeds[feed{"", channel}] > 0 || b.feeds[feed{instrument, channel}] > 0
+}
+
+// publish offers the message to every subscriber of its instrument and channel
+func (b *MarketDataBus) publish(data MarketData) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	channel := data.channel()
+	if !b.wantsLocked(data.Instrument, channel) {
+		return
+	}
+	for s := range b.subscribers {
+		if s.follows(data.Instrument, channel) && s.wantsCandle(data.Candle) {
+			s.offer(data)
+		}
+	}
+}
+
+// delist forgets a delisted instrument. Subscribers left without any topic are
+// ended with ErrInstrumentDelisted.
+func (b *MarketDataBus) delist(instrument string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subscribers {
+		s.mu.Lock()
+		if _, ok := s.topics[instrument]; ok && len(s.topics) == 1 {
+			// Its feeds stay synced so Next can still hand out the book's last updates
+			delete(s.topics, instrument)
+			s.closeLocked(ErrInstrumentDelisted)
+			delete(b.subscribers, s)
+			s.mu.Unlock()
+			continue
+		}
+		delete(s.topics, instrument)
+		for _, channel := range []Channel{ChannelBook, ChannelOrders} {
+			delete(s.stale, feed{instrument, channel})
+			delete(s.synced, feed{instrument, channel})
+		}
+		s.mu.Unlock()
+	}
+	for _, channel := range []Channel{ChannelTrades, ChannelBook, ChannelOrders, ChannelCandles} {
+		delete(b.feeds, feed{instrument, channel})
+	}
+}
+
+// unsubscribe removes the subscriber and ends it with err
+func (b *MarketDataBus) unsubscribe(s *MarketDataSubscriber, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subscribers[s]; !ok {
+		return
+	}
+	delete(b.subscribers, s)
+	s.mu.Lock()
+	s.eachFeed(func(f feed) { b.feeds[f]-- })
+	s.closeLocked(err)
+	s.mu.Unlock()
+}
+
+// MarketDataSubscriber reads the messages of its topics, in publication order per
+// instrument, through Next
+type MarketDataSubscriber struct {
+	bus       *MarketDataBus
+	policy    SlowConsumerPolicy
+	channels  Channel
+	intervals []time.Duration     // Candle intervals wanted; empty for all
+	topics    map[string]struct{} // Nil for every instrument; shrinks under the bus's lock as instruments are delisted
+	queue     chan MarketData
+	wake      chan struct{} // Signalled when a snapshot is owed
+	done      chan struct{} // Closed when the subscription ends
+	dropped   atomic.Uint64
+
+	mu     sync.Mutex
+	stale  map[feed]struct{} // Feeds owed a snapshot; their updates are skipped until it is taken
+	synced map[feed]uint64   // Sequence of each feed's last snapshot; queued updates at or below it are stale
+	err    error
+}
+
+// eachFeed calls fn for every feed the subscriber counts towards
+func (s *MarketDataSubscriber) eachFeed(fn func(feed)) {
+	for _, channel := range []Channel{ChannelTrades, ChannelBook, ChannelOrders, ChannelCandles} {
+		if s.channels&channel == 0 {
+			continue
+		}
+		if s.topics == nil {
+			fn(feed{"", channel})
+		}
+		for instrument := range s.topics {
+			fn(feed{instrument, channel})
+		}
+	}
+}
+
+// follows reports whether the subscriber wants the instrument's channel
+func (s *MarketDataSubscriber) follows(instrument string, channel Channel) bool {
+	if s.channels&channel == 0 {
+		return false
+	}
+	if s.topics == nil {
+		return true
+	}
+	_, ok := s.topics[instrument]
+	return ok
+}
+
+// wantsCandle reports whether the subscriber wants the candle's interval; anything
+// that is not a candle passes
+func (s *MarketDataSubscriber) wantsCandle(candle *models.Candle) bool {
+	return candle == nil || len(s.intervals) == 0 || slices.Contains(s.intervals, candle.Interval)
+}
+
+// Next returns the subscriber's next message, waiting for one until ctx is done or
+// the subscription ends
+func (s *MarketDataSubscriber) Next(ctx context.Context) (MarketData, error) {
+	for {
+		select {
+		case <-s.done:
+			return s.remaining()
+		default:
+		}
+		if snapshot, ok := s.resync(); ok {
+			return snapshot, nil
+		}
+		select {
+		case data := <-s.queue:
+			if !s.current(data) {
+				continue // Superseded by a later snapshot
+			}
+			return data, nil
+		case <-s.wake:
+		case <-s.done:
+			return s.remaining()
+		case <-ctx.Done():
+			return MarketData{}, ctx.Err()
+		}
+	}
+}
+
+// remaining returns what is still queued for a subscription ended by the delist
+// of its last instrument, so the book's final updates are not lost, and then the
+// error that ended it. Any other ending abandons the queue.
+func (s *MarketDataSubscriber) remaining() (MarketData, error) {
+	for errors.Is(s.err, ErrInstrumentDelisted) {
+		select {
+		case data := <-s.queue:
+			if s.current(data) {
+				return data, nil
+			}
+		default:
+			return MarketData{}, s.err
+		}
+	}
+	return MarketData{}, s.err
+}
+
+// Dropped returns the number of messages discarded because the queue was full
+func (s *MarketDataSubscriber) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Close ends the subscription; Next then returns ErrSubscriptionClosed
+func (s *MarketDataSubscriber) Close() {
+	s.bus.unsubscribe(s, ErrSubscriptionClosed)
+}
+
+// offer queues the message, applying the slow consumer policy if there is no room.
+// The caller holds the bus's read lock.
+func (s *MarketDataSubscriber) offer(data MarketData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return
+	}
+	f := feed{data.Instrument, data.channel()}
+	if f.channel.snapshotted() {
+		if _, owed := s.stale[f]; owed {
+			return // The snapshot will include it
+		}
+		if _, ok := s.synced[f]; !ok {
+			s.owe(f) // First sight of an instrument for a subscriber to everything
+			return
+		}
+	}
+
+	select {
+	case s.queue <- data:
+		return
+	default:
+	}
+	switch s.policy {
+	case PolicyDisconnect:
+		s.closeLocked(ErrSubscriberBehind)
+		go s.bus.unsubscribe(s, ErrSubscriberBehind) // The caller holds the bus's read lock
+	case PolicyConflate:
+		s.dropped.Add(1)
+		if f.channel.snapshotted() {
+			s.owe(f)
+		}
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// owe marks the feed as needing a fresh snapshot; the caller must hold mu
+func (s *MarketDataSubscriber) owe(f feed) {
+	s.stale[f] = struct{}{}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// resync takes a snapshot for one feed that is owed one, if any
+func (s *MarketDataSubscriber) resync() (MarketData, bool) {
+	for {
+		f, ok := s.nextStale()
+		if !ok {
+			return MarketData{}, false
+		}
+		book := s.bus.lookup(f.instrument)
+		if book == nil {
+			s.mu.Lock()
+			delete(s.stale, f) // Delisted meanwhile
+			s.mu.Unlock()
+			continue
+		}
+
+		book.mu.RLock()
+		s.mu.Lock()
+		snapshot := MarketData{Instrument: f.instrument}
+		_, owed := s.stale[f]
+		owed = owed && s.err == nil
+		if owed {
+			if f.channel == ChannelBook {
+				snapshot.Book = book.depthSnapshot()
+			} else {
+				snapshot.Orders = book.orderSnapshot()
+			}
+			delete(s.stale, f)
+			s.synced[f] = snapshot.sequence()
+		}
+		s.mu.Unlock()
+		book.mu.RUnlock()
+		if owed {
+			return snapshot, true
+		}
+	}
+}
+
+func (s *MarketDataSubscriber) nextStale() (feed, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return feed{}, false
+	}
+	for f := range s.stale {
+		return f, true
+	}
+	return feed{}, false
+}
+
+// current reports whether a dequeued message is still wanted: L2 and L3 updates
+// must follow their feed's last snapshot
+func (s *MarketDataSubscriber) current(data MarketData) bool {
+	f := feed{data.Instrument, data.channel()}
+	if !f.channel.snapshotted() {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	synced, ok := s.synced[f]
+	_, owed := s.stale[f]
+	return ok && !owed && data.sequence() > synced
+}
+
+// closeLocked ends the subscription with err; the caller must hold mu
+func (s *MarketDataSubscriber) closeLocked(err error) {
+	if s.err != nil {
+		return
+	}
+	s.err = err
+	close(s.done)
+}
@@ -0,0 +1,282 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aeromatch/internal/models"
+)
+
+// nextData waits for the subscriber's next message
+func nextData(t *testing.T, sub *MarketDataSubscriber) MarketData {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	data, err := sub.Next(ctx)
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	return data
+}
+
+// startMarket runs an engine with one instrument and returns a submit helper for it
+func startMarket(t *testing.T, symbol string) (*MatchingEngine, func(id uint64, side models.OrderSide, price, qty string)) {
+	t.Helper()
+	m := NewMatchingEngine(64, 64, WaitPark)
+	if _, err := m.AddInstrument(newTestInstrument(symbol)); err != nil {
+		t.Fatal(err)
+	}
+	m.Start()
+	t.Cleanup(m.Stop)
+	return m, func(id uint64, side models.OrderSide, price, qty string) {
+		order := newTestOrder(id, side, models.Limit, price, qty)
+		order.Instrument = symbol
+		if err := m.SubmitOrder(order); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBookUpdatesFollowSnapshot(t *testing.T) {
+	m, submit := startMarket(t, "BTC-USD")
+	submit(1, models.Sell, "101", "2")
+	barrier(t, m, "BTC-USD")
+
+	sub, err := m.SubscribeMarketData(SubscriptionOptions{Instruments: []string{"BTC-USD"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := nextData(t, sub).Book
+	if snapshot == nil || !snapshot.Snapshot || len(snapshot.Asks) != 1 || !snapshot.Asks[0].Quantity.Equal(models.MustParseDecimal("2")) {
+		t.Fatalf("got snapshot %+v", snapshot)
+	}
+
+	submit(2, models.Sell, "101", "1")
+	submit(3, models.Buy, "99", "1")
+	barrier(t, m, "BTC-USD") // Changes nothing, so publishes nothing
+	submit(4, models.Buy, "101", "3")
+
+	added := nextData(t, sub).Book
+	if added == nil || added.Snapshot || added.Sequence != snapshot.Sequence+1 || len(added.Asks) != 1 || added.Asks[0].Orders != 2 {
+		t.Fatalf("got update %+v after snapshot %d", added, snapshot.Sequence)
+	}
+	bid := nextData(t, sub).Book
+	if bid == nil || bid.Sequence != added.Sequence+1 || len(bid.Bids) != 1 || len(bid.Asks) != 0 {
+		t.Fatalf("got update %+v", bid)
+	}
+	// The sweep's trades and its book update come from different goroutines
+	var swept *BookUpdate
+	trades := 0
+	for swept == nil || trades < 2 {
+		data := nextData(t, sub)
+		if data.Trade != nil {
+			trades++
+		} else {
+			swept = data.Book
+		}
+	}
+	if swept.Sequence != bid.Sequence+1 || len(swept.Asks) != 1 || !swept.Asks[0].Quantity.IsZero() || swept.Asks[0].Orders != 0 {
+		t.Fatalf("sweep published %+v, want the 101 ask level removed", swept)
+	}
+
+	if _, err := m.DelistInstrument("BTC-USD"); err != nil {
+		t.Fatal(err)
+	}
+	if cleared := nextData(t, sub).Book; cleared == nil || len(cleared.Bids) != 1 || !cleared.Bids[0].Quantity.IsZero() {
+		t.Fatalf("delist published %+v, want the 99 bid level removed", cleared)
+	}
+	if _, err := sub.Next(context.Background()); !errors.Is(err, ErrInstrumentDelisted) {
+		t.Fatalf("next after delist: got %v", err)
+	}
+}
+
+func TestEverySubscriberGetsEveryTrade(t *testing.T) {
+	m, submit := startMarket(t, "BTC-USD")
+	if _, err := m.AddInstrument(newTestInstrument("ETH-USD")); err != nil {
+		t.Fatal(err)
+	}
+	filtered, err := m.SubscribeMarketData(SubscriptionOptions{Instruments: []string{"BTC-USD"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	everything, _ := m.SubscribeMarketData(SubscriptionOptions{})
+	if _, err := m.SubscribeMarketData(SubscriptionOptions{Instruments: []string{"XRP-USD"}}); !errors.Is(err, ErrUnknownInstrument) {
+		t.Fatalf("unknown topic: got %v", err)
+	}
+
+	other := newTestOrder(10, models.Sell, models.Limit, "50", "1")
+	other.Instrument = "ETH-USD"
+	if err := m.SubmitOrder(other); err != nil {
+		t.Fatal(err)
+	}
+	submit(1, models.Sell, "100", "1")
+	submit(2, models.Buy, "100", "1")
+
+	for _, sub := range []*MarketDataSubscriber{filtered, everything} {
+		var trade *models.Trade
+		for trade == nil {
+			data := nextData(t, sub)
+			if data.Instrument != "BTC-USD" && sub == filtered {
+				t.Fatalf("filtered subscriber got %s data", data.Instrument)
+			}
+			trade = data.Trade
+		}
+		if trade.MakerOrderID != 1 || trade.TakerOrderID != 2 {
+			t.Fatalf("got trade %+v", trade)
+		}
+	}
+}
+
+func TestCandleTopic(t *testing.T) {
+	m, submit := startMarket(t, "BTC-USD")
+	sub, err := m.SubscribeMarketData(SubscriptionOptions{
+		Instruments:     []string{"BTC-USD"},
+		Channels:        ChannelCandles,
+		CandleIntervals: []time.Duration{time.Minute},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	submit(1, models.Sell, "100", "3")
+	submit(2, models.Buy, "100", "1")
+	submit(3, models.Buy, "100", "2")
+	var previous *models.Candle
+	for _, volume := range []string{"1", "2"} {
+		data := nextData(t, sub)
+		candle := data.Candle
+		if candle == nil {
+			t.Fatalf("got %+v, want a candle", data)
+		}
+		want := models.MustParseDecimal(volume)
+		if previous != nil && previous.Start == candle.Start {
+			want = want.Add(previous.Volume) // Unless the trades straddle a minute
+		}
+		if candle.Interval != time.Minute || !candle.Volume.Equal(want) {
+			t.Fatalf("got %+v, want a one-minute candle with volume %v", data, want)
+		}
+		previous = candle
+	}
+
+	trades, err := m.GetRecentTrades("BTC-USD", 0)
+	if err != nil || len(trades) != 2 || trades[0].TakerOrderID != 3 {
+		t.Fatalf("got %d recent trades, %v", len(trades), err)
+	}
+	candles, err := m.GetCandles("BTC-USD", time.Hour, time.Time{}, time.Time{}, 0)
+	if err != nil || len(candles) == 0 || !candles[len(candles)-1].Close.Equal(models.MustParseDecimal("100")) {
+		t.Fatalf("got hourly candles %+v, %v", candles, err)
+	}
+	if _, err := m.GetCandles("XRP-USD", time.Hour, time.Time{}, time.Time{}, 0); !errors.Is(err, ErrUnknownInstrument) {
+		t.Fatalf("unknown instrument: got %v", err)
+	}
+}
+
+func TestSlowConsumerPolicies(t *testing.T) {
+	bus := newMarketDataBus(nil)
+	ob := newTestBook("0.01", "1")
+	ob.marketData = bus
+	bus.lookup = func(string) *OrderBook { return ob }
+	symbol := ob.instrument.Symbol
+
+	subscribe := func(policy SlowConsumerPolicy) *MarketDataSubscriber {
+		sub := bus.Subscribe(SubscriptionOptions{Instruments: []string{symbol}, Buffer: 1, Policy: policy})
+		if snapshot := nextData(t, sub).Book; snapshot == nil || !snapshot.Snapshot {
+			t.Fatalf("%v subscriber did not start with a snapshot", policy)
+		}
+		return sub
+	}
+	dropping, disconnecting, conflating := subscribe(PolicyDrop), subscribe(PolicyDisconnect), subscribe(PolicyConflate)
+	for i, price := range []string{"100", "101", "102"} {
+		ob.apply(bookCommand{kind: cmdNewOrder, order: newTestOrder(uint64(i+1), models.Sell, models.Limit, price, "1")})
+	}
+
+	if data := nextData(t, dropping).Book; data.Sequence != 1 || dropping.Dropped() != 2 {
+		t.Fatalf("dropping subscriber read sequence %d with %d dropped", data.Sequence, dropping.Dropped())
+	}
+	if _, err := disconnecting.Next(context.Background()); !errors.Is(err, ErrSubscriberBehind) {
+		t.Fatalf("disconnecting subscriber: got %v", err)
+	}
+	// Conflation replaces the missed updates with the latest book
+	snapshot := nextData(t, conflating).Book
+	if !snapshot.Snapshot || snapshot.Sequence != 3 || len(snapshot.Asks) != 3 {
+		t.Fatalf("conflating subscriber got %+v", snapshot)
+	}
+	// The update superseded by the snapshot still takes the only slot, so the next
+	// change conflates again
+	ob.apply(bookCommand{kind: cmdCancel, orderID: 1})
+	if update := nextData(t, conflating).Book; update.Sequence != 4 || len(update.Asks) != 2 {
+		t.Fatalf("conflating subscriber got %+v after resync", update)
+	}
+
+	conflating.Close()
+	if _, err := conflating.Next(context.Background()); !errors.Is(err, ErrSubscriptionClosed) {
+		t.Fatalf("next after close: got %v", err)
+	}
+}
+
+func TestOrderFlowRebuildsBook(t *testing.T) {
+	m, submit := startMarket(t, "BTC-USD")
+	submit(1, models.Sell, "101", "2")
+	barrier(t, m, "BTC-USD")
+
+	sub, err := m.SubscribeMarketData(SubscriptionOptions{Instruments: []string{"BTC-USD"}, Channels: ChannelOrders})
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := nextData(t, sub).Orders
+	if snapshot == nil || !snapshot.Snapshot || len(snapshot.Asks) != 1 || snapshot.Asks[0].OrderID != 1 {
+		t.Fatalf("got snapshot %+v", snapshot)
+	}
+
+	submit(2, models.Sell, "101", "3")
+	submit(3, models.Buy, "101", "3") // Fills order 1, then 1 of order 2
+	if _, err := m.AmendOrder("BTC-USD", "", 2, models.Decimal{}, models.MustParseDecimal("2")); err != nil {
+		t.Fatal(err) // 1 of 3 filled, so 1 left resting in place
+	}
+	if _, err := m.AmendOrder("BTC-USD", "", 2, models.MustParseDecimal("102"), models.Decimal{}); err != nil {
+		t.Fatal(err)
+	}
+	submit(4, models.Buy, "99", "1")
+	if _, err := m.CancelOrder("BTC-USD", "", 4); err != nil {
+		t.Fatal(err)
+	}
+
+	var events []L3Event
+	sequence := snapshot.Sequence
+	for len(events) < 8 {
+		update := nextData(t, sub).Orders
+		if update == nil || update.Snapshot || update.Sequence != sequence+1 {
+			t.Fatalf("got %+v after sequence %d", update, sequence)
+		}
+		sequence = update.Sequence
+		events = append(events, update.Events...)
+	}
+	want := []struct {
+		kind     L3EventType
+		orderID  uint64
+		quantity string
+	}{
+		{L3Add, 2, "3"},
+		{L3Execute, 1, "2"},
+		{L3Execute, 2, "1"},
+		{L3Modify, 2, "1"},
+		{L3Delete, 2, "1"},
+		{L3Add, 2, "1"},
+		{L3Add, 4, "1"},
+		{L3Delete, 4, "1"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events %+v, want %d", len(events), events, len(want))
+	}
+	for i, w := range want {
+		if e := events[i]; e.Type != w.kind || e.OrderID != w.orderID || !e.Quantity.Equal(models.MustParseDecimal(w.quantity)) {
+			t.Fatalf("event %d is %v of order %d for %s, want %v of order %d for %s", i, e.Type, e.OrderID, e.Quantity, w.kind, w.orderID, w.quantity)
+		}
+	}
+	if events[1].TradeID == 0 || !events[5].Price.Equal(models.MustParseDecimal("102")) {
+		t.Fatalf("execution %+v, re-added %+v", events[1], events[5])
+	}
+}
@@ -1,77 +1,716 @@
 package engine
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/aeromatch/internal/models"
+	"github.com/aeromatch/internal/util"
 )
 
+// Engine errors
+var (
+	ErrUnknownInstrument  = errors.New("unknown instrument")
+	ErrInvalidOrder       = errors.New("invalid order")
+	ErrOrderNotFound      = errors.New("order not found")
+	ErrInvalidAmend       = errors.New("invalid amend: price must be positive and quantity above filled size")
+	ErrInvalidInstrument  = errors.New("invalid instrument")
+	ErrInstrumentExists   = errors.New("instrument already listed")
+	ErrInstrumentDelisted = errors.New("instrument delisted")
+	ErrEngineStopped      = errors.New("matching engine stopped")
+	ErrJournal            = errors.New("journal write failed")
+	ErrJournalGap         = errors.New("journal sequence gap")
+	ErrEngineRunning      = errors.New("matching engine already started")
+)
+
+// Recorder persists what the engine produced, off the matching path. Calls come
+// from each book's output goroutines, in order per instrument, and may block to
+// push back on a slow store.
+type Recorder interface {
+	RecordTrade(trade *models.Trade)
+	RecordOrderEvent(event *models.OrderEvent)
+}
+
+// MatchingEngine routes orders to one book per instrument.
+//
+// Ordering guarantees: every order, cancel, amend, halt, resume and delist passes
+// through a single sequencer goroutine (processOrders), which stamps it with the
+// next sequence number of its instrument and hands it to that instrument's
+// matching goroutine. Hence, for each instrument:
+//   - sequence numbers start at 1 and increase by one with no gaps;
+//   - commands are applied in sequence order, so a call that returned before
+//     another began is applied first, and time priority follows sequence order;
+//   - a book snapshot carries the sequence of the last command it reflects.
+//
+// No ordering is implied between different instruments.
 type MatchingEngine struct {
-	orderBooks sync.Map           // Instrument -> OrderBook
-	incoming   chan *models.Order // Buffered channel for order ingestion
-	trades     chan *models.Trade // Buffered channel for matched trades
-	shutdown   chan struct{}
+	orderBooks     sync.Map                  // Instrument -> OrderBook
+	incoming       *AtomicQueue[bookCommand] // Ring buffer feeding the sequencer
+	marketData     *MarketDataBus            // Fans trades and book updates out to subscribers
+	orderUpdates   *OrderUpdateFeed          // Routes order events to their owners
+	orderIDs       *util.IDGenerator         // Assigns IDs to orders submitted without one
+	clientOrders   *clientOrderIDs           // Client order IDs recently used per account
+	bookBufferSize int                       // Command buffer for books added at runtime
+	wait           WaitStrategy              // How queue consumers and producers wait
+	journal        Journal                   // Optional write-ahead journal, set before Start
+	recorder       Recorder                  // Optional sink for trades and order events, set before Start
+	depthCache     *SnapshotManager          // Optional cache of book depth, set before Start
+	lifecycleMu    sync.Mutex                // Serializes Start and instrument admin operations
+	running        bool
+	recovered      bool // Recover rebuilt the books, so the journal continues their sequences
+	stopped        bool
+	workers        sync.WaitGroup // The sequencer and book goroutines, waited for by Stop
+	lostTrades     atomic.Uint64  // Trades the journal failed to record
+}
+
+func NewMatchingEngine(bufferSize, bookBufferSize int, wait WaitStrategy) *MatchingEngine {
+	m := &MatchingEngine{
+		orderBooks:     sync.Map{},
+		incoming:       NewAtomicQueue[bookCommand](bufferSize, wait),
+		bookBufferSize: bookBufferSize,
+		wait:           wait,
+		orderUpdates:   newOrderUpdateFeed(),
+		clientOrders:   newClientOrderIDs(),
+		orderIDs:       util.NewLocalIDGenerator(),
+	}
+	m.marketData = newMarketDataBus(m.getOrderBook)
+	return m
+}
+
+// SetJournal makes the engine record every accepted command and produced trade,
+// acknowledging commands only once they are durable. It must be called after
+// Recover, if at all, and before Start.
+//
+// A run that did not recover numbers its commands afresh, so it first journals a
+// JournalRun marker: a later Recover ignores what was journaled and snapshotted
+// before it. A failed write leaves the journal broken, which refuses every command.
+// Storage that keeps snapshots by sequence must be cleared before then, or the
+// earlier runs' snapshots outrank this run's.
+func (m *MatchingEngine) SetJournal(journal Journal) {
+	m.journal = journal
+	m.lifecycleMu.Lock()
+	recovered := m.recovered
+	m.lifecycleMu.Unlock()
+	if !recovered {
+		journal.Append(&JournalEntry{Type: JournalRun, Timestamp: time.Now().UnixNano()}, nil)
+	}
+}
+
+// SetOrderIDs replaces the generator of order IDs, which by default uses node ID
+// 0. Engines whose orders share an ID space need distinct node IDs. It must be
+// called before Start.
+func (m *MatchingEngine) SetOrderIDs(ids *util.IDGenerator) {
+	m.orderIDs = ids
+}
+
+// SetClientOrderIDWindow makes SubmitOrder and ExecuteOrder refuse, with a
+// DuplicateOrderError, an order whose client order ID its account used within
+// the window. Zero, the default, turns this off. It must be called before Replay
+// so that replayed orders are remembered.
+func (m *MatchingEngine) SetClientOrderIDWindow(window time.Duration) {
+	m.clientOrders.setWindow(window)
+}
+
+// SetRecorder hands every trade and order event to the recorder. It must be called before Start.
+func (m *MatchingEngine) SetRecorder(recorder Recorder) {
+	m.recorder = recorder
+}
+
+// SetSnapshotManager lets GetOrderBook serve depth from the manager's periodic
+// snapshots, and keeps the manager's set of books in step with the engine's. It
+// must be called before Start; starting the manager is left to the caller.
+func (m *MatchingEngine) SetSnapshotManager(manager *SnapshotManager) {
+	m.depthCache = manager
+	m.orderBooks.Range(func(key, value any) bool {
+		manager.RegisterOrderBook(key.(string), value.(*OrderBook))
+		return true
+	})
+}
+
+// GetOrderBook returns up to depth price levels a side, or all of them if depth is
+// not positive, with the sequence of the last command they reflect. The snapshot
+// manager's cached copy is served when it is deep enough, unless fresh asks for a
+// consistent read of the book as it is now.
+func (m *MatchingEngine) GetOrderBook(instrument string, depth int, fresh bool) (*OrderBookSnapshot, error) {
+	book := m.getOrderBook(instrument)
+	if book == nil {
+		return nil, ErrUnknownInstrument
+	}
+	if !fresh && m.depthCache != nil && depth > 0 && depth <= m.depthCache.Depth() {
+		if snapshot, ok := m.depthCache.GetSnapshot(instrument); ok {
+			snapshot.Bids = snapshot.Bids[:min(depth, len(snapshot.Bids))]
+			snapshot.Asks = snapshot.Asks[:min(depth, len(snapshot.Asks))]
+			return snapshot, nil
+		}
+	}
+	return book.GetMarketDepth(int32(max(depth, 0))), nil
+}
+
+// SubscribeMarketData subscribes to trades and L2 book updates, starting with a
+// book snapshot for each instrument. Every listed instrument must exist.
+func (m *MatchingEngine) SubscribeMarketData(opts SubscriptionOptions) (*MarketDataSubscriber, error) {
+	for _, instrument := range opts.Instruments {
+		if m.getOrderBook(instrument) == nil {
+			return nil, ErrUnknownInstrument
+		}
+	}
+	return m.marketData.Subscribe(opts), nil
+}
+
+// SubscribeOrderUpdates subscribes to the events of the account's orders from now
+// on, queueing up to buffer of them. The subscription ends with
+// ErrOrderUpdatesBehind if the queue fills.
+func (m *MatchingEngine) SubscribeOrderUpdates(account string, buffer int) *OrderUpdateSubscriber {
+	return m.orderUpdates.Subscribe(account, buffer)
+}
+
+// RegisterOrderBook makes the book reachable under its instrument's symbol.
+// Books registered on a running engine start processing immediately.
+func (m *MatchingEngine) RegisterOrderBook(book *OrderBook) error {
+	m.lifecycleMu.Lock()
+	defer m.lifecycleMu.Unlock()
+	return m.registerOrderBook(book)
+}
+
+// registerOrderBook is RegisterOrderBook for a caller holding lifecycleMu
+func (m *MatchingEngine) registerOrderBook(book *OrderBook) error {
+	symbol := book.Instrument().Symbol
+	if m.stopped {
+		return ErrEngineStopped
+	}
+	if _, loaded := m.orderBooks.LoadOrStore(symbol, book); loaded {
+		return ErrInstrumentExists
+	}
+	book.marketData = m.marketData
+	if m.depthCache != nil {
+		m.depthCache.RegisterOrderBook(symbol, book)
+	}
+	if m.running {
+		m.startBook(book)
+	}
+	return nil
+}
+
+// GetInstrument returns the definition of a registered instrument
+func (m *MatchingEngine) GetInstrument(symbol string) (*models.Instrument, bool) {
+	book := m.getOrderBook(symbol)
+	if book == nil {
+		return nil, false
+	}
+	return book.Instrument(), true
+}
+
+// ListInstruments returns the listed instruments ordered by symbol
+func (m *MatchingEngine) ListInstruments() []*models.Instrument {
+	var instruments []*models.Instrument
+	m.orderBooks.Range(func(key, value interface{}) bool {
+		instruments = append(instruments, value.(*OrderBook).Instrument())
+		return true
+	})
+	sort.Slice(instruments, func(i, j int) bool {
+		return instruments[i].Symbol < instruments[j].Symbol
+	})
+	return instruments
 }
 
-func NewMatchingEngine(bufferSize int) *MatchingEngine {
-	return &MatchingEngine{
-		orderBooks: sync.Map{},
-		incoming:   make(chan *models.Order, bufferSize),
-		trades:     make(chan *models.Trade, bufferSize*2),
-		shutdown:   make(chan struct{}),
+// GetOrder returns the latest state of an order that is open, or among the most
+// recently closed on its instrument. Orders of delisted instruments are forgotten.
+func (m *MatchingEngine) GetOrder(orderID uint64) (*models.Order, error) {
+	return m.findOrder(func(book *OrderBook) (*models.Order, bool) {
+		return book.GetOrder(orderID)
+	})
+}
+
+// GetOrderByClientID is GetOrder for the account's order with the client order ID
+func (m *MatchingEngine) GetOrderByClientID(account, clientOID string) (*models.Order, error) {
+	return m.findOrder(func(book *OrderBook) (*models.Order, bool) {
+		return book.GetOrderByClientID(account, clientOID)
+	})
+}
+
+// findOrder asks each book in turn until one knows the order
+func (m *MatchingEngine) findOrder(lookup func(*OrderBook) (*models.Order, bool)) (*models.Order, error) {
+	var found *models.Order
+	m.orderBooks.Range(func(key, value interface{}) bool {
+		order, ok := lookup(value.(*OrderBook))
+		found = order
+		return !ok
+	})
+	if found == nil {
+		return nil, ErrOrderNotFound
+	}
+	return found, nil
+}
+
+// ListOpenOrders returns the account's open orders on the instrument, or on every
+// instrument if it is empty, ordered by instrument and then by order ID
+func (m *MatchingEngine) ListOpenOrders(account, instrument string) ([]*models.Order, error) {
+	if instrument != "" {
+		book := m.getOrderBook(instrument)
+		if book == nil {
+			return nil, ErrUnknownInstrument
+		}
+		return book.OpenOrders(account), nil
+	}
+
+	var orders []*models.Order
+	for _, definition := range m.ListInstruments() {
+		if book := m.getOrderBook(definition.Symbol); book != nil {
+			orders = append(orders, book.OpenOrders(account)...)
+		}
+	}
+	return orders, nil
+}
+
+// GetRecentTrades returns up to limit of the instrument's newest trades, newest
+// first. Books keep their last 1000 trades; a limit that is not positive returns all of them.
+func (m *MatchingEngine) GetRecentTrades(instrument string, limit int) ([]*models.Trade, error) {
+	book := m.getOrderBook(instrument)
+	if book == nil {
+		return nil, ErrUnknownInstrument
+	}
+	return book.RecentTrades(limit), nil
+}
+
+// GetCandles returns up to limit of the instrument's newest candles of the
+// interval, one of CandleIntervals, that start in [from, to), oldest first. Zero
+// times leave that end open and a limit that is not positive returns every match.
+// Books keep their last 1000 candles per interval; intervals without trades have
+// none, and the newest candle may still be in progress.
+func (m *MatchingEngine) GetCandles(instrument string, interval time.Duration, from, to time.Time, limit int) ([]models.Candle, error) {
+	book := m.getOrderBook(instrument)
+	if book == nil {
+		return nil, ErrUnknownInstrument
+	}
+	return book.Candles(interval, from, to, limit)
+}
+
+// AddInstrument lists a new instrument with an empty book. The listing is
+// journaled before the book is registered, so no command for the instrument can
+// be journaled ahead of it, and a failed write leaves the instrument unlisted.
+func (m *MatchingEngine) AddInstrument(instrument *models.Instrument) (*models.Instrument, error) {
+	if err := instrument.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidInstrument, err)
 	}
+	if instrument.Status == models.StatusDelisted {
+		return nil, ErrInstrumentDelisted
+	}
+	definition := *instrument // The book owns its copy
+	book := NewOrderBook(&definition, m.bookBufferSize, m.wait)
+
+	m.lifecycleMu.Lock()
+	defer m.lifecycleMu.Unlock()
+	if m.stopped {
+		return nil, ErrEngineStopped
+	}
+	if m.getOrderBook(definition.Symbol) != nil {
+		return nil, ErrInstrumentExists
+	}
+	if m.journal != nil {
+		err := m.journalNow(&JournalEntry{
+			Type:       JournalInstrument,
+			Instrument: definition.Symbol,
+			Timestamp:  time.Now().UnixNano(),
+			Definition: &definition,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := m.registerOrderBook(book); err != nil {
+		return nil, err
+	}
+	return book.Instrument(), nil
+}
+
+// HaltInstrument stops matching for an instrument. Resting orders stay on the
+// book and can still be cancelled; new orders and amends are refused.
+func (m *MatchingEngine) HaltInstrument(symbol string) (*models.Instrument, error) {
+	return m.setInstrumentStatus(symbol, models.StatusHalted)
+}
+
+// ResumeInstrument reopens a halted instrument for trading
+func (m *MatchingEngine) ResumeInstrument(symbol string) (*models.Instrument, error) {
+	return m.setInstrumentStatus(symbol, models.StatusTrading)
+}
+
+func (m *MatchingEngine) setInstrumentStatus(symbol string, status models.TradingStatus) (*models.Instrument, error) {
+	m.lifecycleMu.Lock()
+	defer m.lifecycleMu.Unlock()
+
+	book := m.getOrderBook(symbol)
+	if book == nil {
+		return nil, ErrUnknownInstrument
+	}
+
+	cmd := bookCommand{kind: cmdSetStatus, instrument: symbol, status: status}
+	var err error
+	if m.running {
+		err = m.route(context.Background(), cmd).err
+	} else {
+		err = m.applyIdle(book, cmd).err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return book.Instrument(), nil
 }
 
-func (m *MatchingEngine) RegisterOrderBook(instrument string, book *OrderBook) {
-	m.orderBooks.Store(instrument, book)
+// DelistInstrument cancels every resting order, stops the book's goroutines and
+// removes the instrument. It returns the cancelled orders.
+func (m *MatchingEngine) DelistInstrument(symbol string) ([]*models.Order, error) {
+	m.lifecycleMu.Lock()
+	defer m.lifecycleMu.Unlock()
+
+	book := m.getOrderBook(symbol)
+	if book == nil {
+		return nil, ErrUnknownInstrument
+	}
+
+	if !m.running {
+		result := m.applyIdle(book, bookCommand{kind: cmdDelist, instrument: symbol})
+		if result.err != nil {
+			return nil, result.err
+		}
+		m.removeBook(symbol, book)
+		return result.orders, nil
+	}
+	// The sequencer unregisters the book once the delist is queued behind earlier commands
+	result := m.route(context.Background(), bookCommand{kind: cmdDelist, instrument: symbol})
+	return result.orders, result.err
 }
 
 func (m *MatchingEngine) Start() {
-	go m.processOrders()
-	go m.processTrades()
+	m.lifecycleMu.Lock()
+	defer m.lifecycleMu.Unlock()
+
+	m.running = true
+	m.orderBooks.Range(func(key, value interface{}) bool {
+		m.startBook(value.(*OrderBook))
+		return true
+	})
+	m.workers.Add(1)
+	go func() {
+		defer m.workers.Done()
+		m.processOrders()
+	}()
+}
+
+// Stop refuses new commands and returns once those already accepted have been
+// applied and their trades and order events handled, so the books are at rest
+func (m *MatchingEngine) Stop() {
+	m.lifecycleMu.Lock()
+	m.stopped = true // No book is registered after the sequencer has stopped them all
+	m.incoming.Close()
+	m.lifecycleMu.Unlock()
+	m.workers.Wait()
+}
+
+// LostTrades returns the number of trades the journal failed to record
+func (m *MatchingEngine) LostTrades() uint64 {
+	return m.lostTrades.Load()
+}
+
+// startBook runs the book's matching goroutine and drains its outputs.
+// All three exit once the book is delisted or the engine stopped.
+func (m *MatchingEngine) startBook(book *OrderBook) {
+	m.workers.Add(3)
+	go func() {
+		defer m.workers.Done()
+		book.ProcessOrders()
+	}()
+	go func() {
+		defer m.workers.Done()
+		for trade := range book.processedTrades { // blocks until a trade is available
+			m.journalTrade(trade)
+			if m.recorder != nil {
+				m.recorder.RecordTrade(trade)
+			}
+			candles := book.history.record(trade)
+			m.marketData.publish(MarketData{Instrument: trade.Instrument, Trade: trade})
+			for i := range candles {
+				m.marketData.publish(MarketData{Instrument: trade.Instrument, Candle: &candles[i]})
+			}
+		}
+		select {
+		case <-book.done: // Delisted, rather than the engine stopped
+			m.marketData.delist(book.Instrument().Symbol)
+		default:
+		}
+	}()
+	go func() {
+		defer m.workers.Done()
+		for event := range book.orderEvents {
+			m.dispatchOrderEvent(event)
+		}
+	}()
+}
+
+// SubmitOrder validates the order against its instrument and queues it for
+// matching. An order without an ID is given one.
+func (m *MatchingEngine) SubmitOrder(order *models.Order) error {
+	if err := m.admitOrder(order); err != nil {
+		return err
+	}
+
+	cmd := bookCommand{kind: cmdNewOrder, instrument: order.Instrument, order: order}
+	if m.journal != nil {
+		cmd.durable = make(chan error, 1)
+	}
+	if !m.incoming.Push(cmd) {
+		m.clientOrders.release(order)
+		return ErrEngineStopped
+	}
+	if cmd.durable != nil {
+		err := <-cmd.durable // Acknowledge only once journaled
+		if err != nil {
+			m.clientOrders.release(order)
+		}
+		return err
+	}
+	return nil
+}
+
+// OrderResult is what matching an order produced, as seen once the book is done with it
+type OrderResult struct {
+	Order  *models.Order       // Final state; New or Partial means the remaining quantity rests
+	Trades []*models.Trade     // In execution order
+	Reason models.RejectReason // Why the order was rejected, killed or expired
+}
+
+// ExecuteOrder submits the order like SubmitOrder, then waits until its book has
+// matched it. If ctx ends first, ExecuteOrder returns ctx's error without
+// withdrawing the order, which may still trade.
+func (m *MatchingEngine) ExecuteOrder(ctx context.Context, order *models.Order) (*OrderResult, error) {
+	if err := m.admitOrder(order); err != nil {
+		return nil, err
+	}
+	result := m.route(ctx, bookCommand{kind: cmdNewOrder, instrument: order.Instrument, order: order})
+	switch {
+	case result.settle != nil:
+		// The order may yet be refused; a retry must not be acknowledged as its duplicate then
+		go func() {
+			if result.settle() != nil {
+				m.clientOrders.release(order)
+			}
+		}()
+	case result.err != nil:
+		m.clientOrders.release(order) // Never entered the engine
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+	return &OrderResult{Order: result.order, Trades: result.trades, Reason: result.reason}, nil
+}
+
+// admitOrder brings the order to its instrument's scales and checks it against
+// the instrument and the client order IDs recently used, and gives it an ID if it
+// has none, before it is queued
+func (m *MatchingEngine) admitOrder(order *models.Order) error {
+	book := m.getOrderBook(order.Instrument)
+	if book == nil {
+		return ErrUnknownInstrument
+	}
+	instrument := book.Instrument()
+	err := instrument.NormalizeOrder(order)
+	if err == nil {
+		err = instrument.ValidateOrder(order)
+	}
+	if err != nil {
+		if errors.Is(err, models.ErrInstrumentNotTrading) {
+			return err
+		}
+		return fmt.Errorf("%w: %w", ErrInvalidOrder, err)
+	}
+	if order.ID == 0 {
+		order.ID = m.orderIDs.Next()
+	}
+	return m.clientOrders.reserve(order)
+}
+
+// CancelOrder cancels a resting order and returns it with its final remaining quantity.
+// It is applied after every command for the instrument submitted before it. Unless
+// account is empty, an order the account does not own returns ErrOrderNotFound.
+func (m *MatchingEngine) CancelOrder(instrument, account string, orderID uint64) (*models.Order, error) {
+	if m.getOrderBook(instrument) == nil {
+		return nil, ErrUnknownInstrument
+	}
+	result := m.route(context.Background(), bookCommand{kind: cmdCancel, instrument: instrument, orderID: orderID, account: account})
+	return result.order, result.err
 }
 
-func (m *MatchingEngine) SubmitOrder(order *models.Order) {
-	m.incoming <- order
+// AmendOrder changes the price and/or total quantity of a resting order; zero leaves a
+// field unchanged. Like CancelOrder, it only finds the account's own orders.
+func (m *MatchingEngine) AmendOrder(instrument, account string, orderID uint64, price, quantity models.Decimal) (*models.Order, error) {
+	if m.getOrderBook(instrument) == nil {
+		return nil, ErrUnknownInstrument
+	}
+	result := m.route(context.Background(), bookCommand{kind: cmdAmend, instrument: instrument, orderID: orderID, account: account, price: price, quantity: quantity})
+	return result.order, result.err
+}
+
+// route hands a command to the sequencer and waits for the book's reply, or
+// until ctx ends; the command is applied either way once accepted. If ctx ends
+// after the command was queued, the result's settle reports its eventual outcome.
+func (m *MatchingEngine) route(ctx context.Context, cmd bookCommand) commandResult {
+	if err := ctx.Err(); err != nil {
+		return commandResult{err: err}
+	}
+	cmd.reply = make(chan commandResult, 1)
+	if m.journal != nil {
+		cmd.durable = make(chan error, 1)
+	}
+	if !m.incoming.Push(cmd) {
+		return commandResult{err: ErrEngineStopped}
+	}
+	if cmd.durable != nil {
+		select {
+		case err := <-cmd.durable:
+			if err != nil {
+				return commandResult{err: err}
+			}
+		case <-ctx.Done():
+			return commandResult{err: ctx.Err(), settle: func() error { return awaitCommand(cmd.durable, cmd.reply) }}
+		}
+	}
+	select {
+	case result := <-cmd.reply:
+		return result
+	case <-ctx.Done():
+		return commandResult{err: ctx.Err(), settle: func() error { return awaitCommand(nil, cmd.reply) }}
+	}
 }
 
-func (m *MatchingEngine) GetTradesChannel() <-chan *models.Trade {
-	return m.trades
+// awaitCommand waits for the outcome of a queued command whose caller gave up on
+// it: the journal's answer unless already read, then the book's reply
+func awaitCommand(durable <-chan error, reply <-chan commandResult) error {
+	if durable != nil {
+		if err := <-durable; err != nil {
+			return err
+		}
+	}
+	return (<-reply).err
 }
 
+// processOrders is the engine's sequencer, the only goroutine that feeds the books
 func (m *MatchingEngine) processOrders() {
 	// TODO: validate orders, check risk, etc.
 	for {
-		select {
-		case order := <-m.incoming:
-			go m.matchOrder(order)
-		case <-m.shutdown:
+		cmd, ok := m.incoming.Pop()
+		if !ok {
+			// Let every book finish the commands already queued on it
+			m.orderBooks.Range(func(key, value interface{}) bool {
+				value.(*OrderBook).commands.Close()
+				return true
+			})
 			return
 		}
+		m.sequence(cmd)
 	}
 }
 
-func (m *MatchingEngine) processTrades() {
-	m.orderBooks.Range(func(key, value interface{}) bool {
-		book := value.(*OrderBook)
-		go func(o *OrderBook) {
-			for trade := range book.processedTrades { // blocks until a trade is available
-				go m.broadCastTrade(trade) // TODO: Use a worker pool
-			}
-		}(book)
-		return true
-	})
+// sequence numbers the command, journals it and queues it on its book, blocking
+// while that book's queue is full so that nothing is reordered
+func (m *MatchingEngine) sequence(cmd bookCommand) {
+	book := m.getOrderBook(cmd.instrument)
+	if book == nil {
+		m.refuse(cmd, ErrUnknownInstrument) // Delisted after the command was accepted
+		return
+	}
+
+	cmd.sequence = book.nextSeq + 1
+	if cmd.order != nil {
+		cmd.order.Sequence = cmd.sequence
+	}
+	if m.journal != nil {
+		if err := m.journal.Append(journalEntry(&cmd), cmd.durable); err != nil {
+			m.refuse(cmd, fmt.Errorf("%w: %w", ErrJournal, err))
+			return
+		}
+	} else if cmd.durable != nil {
+		cmd.durable <- nil
+	}
+	book.nextSeq = cmd.sequence
+	book.send(cmd)
+
+	if cmd.kind == cmdDelist {
+		m.removeBook(cmd.instrument, book)
+	}
+}
+
+// applyIdle does the sequencer's and the book's work for an admin command issued
+// before Start: it numbers, journals and applies the command on the caller's
+// goroutine. The caller holds lifecycleMu, which keeps Start from racing it.
+func (m *MatchingEngine) applyIdle(book *OrderBook, cmd bookCommand) commandResult {
+	cmd.sequence = book.nextSeq + 1
+	if m.journal != nil {
+		if err := m.journalNow(journalEntry(&cmd)); err != nil {
+			return commandResult{err: err}
+		}
+	}
+	book.nextSeq = cmd.sequence
+	return book.applyDirect(cmd)
 }
 
-func (m *MatchingEngine) broadCastTrade(trade *models.Trade) {
-	// TODO: Persist trade to database, notify external systems, etc.
+// journalNow appends the entry and waits until it is durable
+func (m *MatchingEngine) journalNow(entry *JournalEntry) error {
+	durable := make(chan error, 1)
+	if err := m.journal.Append(entry, durable); err != nil {
+		return fmt.Errorf("%w: %w", ErrJournal, err)
+	}
+	if err := <-durable; err != nil {
+		return fmt.Errorf("%w: %w", ErrJournal, err)
+	}
+	return nil
+}
 
+// refuse fails a command that never reached its book
+func (m *MatchingEngine) refuse(cmd bookCommand, err error) {
+	if cmd.durable != nil {
+		cmd.durable <- err
+	}
+	if cmd.reply != nil {
+		cmd.reply <- commandResult{err: err}
+	}
 }
 
-func (m *MatchingEngine) matchOrder(order *models.Order) {
-	book := m.getOrderBook(order.Instrument)
-	book.incomingOrders <- order
+// journalTrade records a trade behind the command that produced it
+func (m *MatchingEngine) journalTrade(trade *models.Trade) {
+	if m.journal == nil {
+		return
+	}
+	// A failed write leaves the journal broken, which refuses the next command
+	err := m.journal.Append(&JournalEntry{
+		Type:       JournalTrade,
+		Sequence:   trade.Sequence,
+		Instrument: trade.Instrument,
+		Timestamp:  trade.Timestamp,
+		Trade:      trade,
+	}, nil)
+	if err != nil {
+		m.lostTrades.Add(1)
+	}
+}
+
+func (m *MatchingEngine) dispatchOrderEvent(event *models.OrderEvent) {
+	if m.recorder != nil {
+		m.recorder.RecordOrderEvent(event)
+	}
+	if event.Order.Account != "" {
+		m.orderUpdates.publish(event)
+	}
+}
+
+// removeBook unregisters a delisted book, unless it was already replaced
+func (m *MatchingEngine) removeBook(instrument string, book *OrderBook) {
+	if m.orderBooks.CompareAndDelete(instrument, book) && m.depthCache != nil {
+		m.depthCache.UnregisterOrderBook(instrument, book)
+	}
 }
 
 func (m *MatchingEngine) getOrderBook(instrument string) *OrderBook {
@@ -86,19 +725,20 @@ func (m *MatchingEngine) getOrderBook(instrument string) *OrderBook {
 	return book
 }
 
-// Helper functions
-func min(a, b float64) float64 {
-	if a < b {
-		return a
-	}
-	return b
-}
-
-// Atomic counters
-// TODO: Retrieve from a persistent store
+// Atomic counters, restored by Replay on startup
 var executionCounter uint64
 var tradeIDCounter uint64
 
 func generateTradeID() uint64 {
 	return atomic.AddUint64(&tradeIDCounter, 1)
 }
+
+// restoreCounter raises counter to at least v
+func restoreCounter(counter *uint64, v uint64) {
+	for {
+		current := atomic.LoadUint64(counter)
+		if current >= v || atomic.CompareAndSwapUint64(counter, current, v) {
+			return
+		}
+	}
+}
@@ -0,0 +1,963 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aeromatch/internal/models"
+)
+
+func newTestInstrument(symbol string) *models.Instrument {
+	return &models.Instrument{
+		Symbol:   symbol,
+		TickSize: models.MustParseDecimal("0.01"),
+		LotSize:  models.MustParseDecimal("1"),
+	}
+}
+
+// barrier waits until every command queued on the book before it has run
+func barrier(t *testing.T, m *MatchingEngine, symbol string) {
+	t.Helper()
+	if _, err := m.CancelOrder(symbol, "", 0); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("barrier cancel: %v", err)
+	}
+}
+
+func TestInstrumentLifecycle(t *testing.T) {
+	m := NewMatchingEngine(64, 64, WaitPark)
+	m.Start()
+
+	// Added after Start: the book must be live without a restart
+	if _, err := m.AddInstrument(newTestInstrument("ETH-USD")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.AddInstrument(newTestInstrument("ETH-USD")); !errors.Is(err, ErrInstrumentExists) {
+		t.Fatalf("duplicate add: got %v", err)
+	}
+
+	book := m.getOrderBook("ETH-USD")
+	newOrder := func(id uint64, side models.OrderSide, price string) *models.Order {
+		order := newTestOrder(id, side, models.Limit, price, "1")
+		order.Instrument = "ETH-USD"
+		return order
+	}
+	add := func(id uint64, side models.OrderSide, price string) {
+		if err := m.SubmitOrder(newOrder(id, side, price)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, price := range []string{"100", "101", "102"} {
+		add(uint64(i+1), models.Sell, price)
+	}
+	add(4, models.Buy, "100")
+	barrier(t, m, "ETH-USD")
+
+	if best, _ := book.GetBestAsk(); best.ID != 2 {
+		t.Fatalf("best ask is order %d, want 2 after the cross", best.ID)
+	}
+
+	halted, err := m.HaltInstrument("ETH-USD")
+	if err != nil || halted.Status != models.StatusHalted {
+		t.Fatalf("halt: %v %v", halted, err)
+	}
+	if err := m.SubmitOrder(newOrder(5, models.Buy, "101")); !errors.Is(err, models.ErrInstrumentNotTrading) {
+		t.Fatalf("submit while halted: got %v", err)
+	}
+
+	if _, err := m.ResumeInstrument("ETH-USD"); err != nil {
+		t.Fatal(err)
+	}
+	add(6, models.Buy, "99")
+
+	cancelled, err := m.DelistInstrument("ETH-USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cancelled) != 3 {
+		t.Fatalf("delist cancelled %d orders, want 3", len(cancelled))
+	}
+	for _, order := range cancelled {
+		if order.Status != models.Cancelled {
+			t.Fatalf("order %d left in status %v", order.ID, order.Status)
+		}
+	}
+
+	if _, ok := m.GetInstrument("ETH-USD"); ok {
+		t.Fatal("delisted instrument still listed")
+	}
+	if _, err := m.CancelOrder("ETH-USD", "", 6); !errors.Is(err, ErrUnknownInstrument) {
+		t.Fatalf("cancel after delist: got %v", err)
+	}
+	if result := book.call(bookCommand{kind: cmdCancel, orderID: 6}); !errors.Is(result.err, ErrInstrumentDelisted) {
+		t.Fatalf("cancel on delisted book: got %v", result.err)
+	}
+	if _, open := <-book.processedTrades; open {
+		t.Fatal("trade channel still open after delist")
+	}
+}
+
+// TestDelistBeforeStart delists a book holding more orders than its event channel
+// buffers while nothing reads the channel
+func TestDelistBeforeStart(t *testing.T) {
+	m := NewMatchingEngine(64, 8, WaitPark)
+	if _, err := m.AddInstrument(newTestInstrument("BTC-USD")); err != nil {
+		t.Fatal(err)
+	}
+	book := m.getOrderBook("BTC-USD")
+	const resting = 100
+	for id := uint64(1); id <= resting; id++ {
+		book.AddAsk(newTestOrder(id, models.Sell, models.Limit, "100", "1"))
+	}
+
+	done := make(chan []*models.Order)
+	go func() {
+		cancelled, _ := m.DelistInstrument("BTC-USD")
+		done <- cancelled
+	}()
+	select {
+	case cancelled := <-done:
+		if len(cancelled) != resting {
+			t.Fatalf("delist cancelled %d orders, want %d", len(cancelled), resting)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("delist blocked on order events nobody reads")
+	}
+	if got, want := uint64(len(book.orderEvents))+book.DroppedEvents(), uint64(resting); got != want {
+		t.Fatalf("%d cancel events kept or counted as dropped, want %d", got, want)
+	}
+}
+
+func TestOrdersValidatedAtInstrumentScale(t *testing.T) {
+	m := NewMatchingEngine(64, 64, WaitPark)
+	instrument := newTestInstrument("BTC-USD")
+	instrument.MaxPrice = models.MustParseDecimal("1000.00")
+	instrument.MinNotional = models.MustParseDecimal("5")
+	if _, err := m.AddInstrument(instrument); err != nil {
+		t.Fatal(err)
+	}
+	m.Start()
+	defer m.Stop()
+
+	// Trailing zeros beyond the tick scale are dropped before the rules are checked
+	order := newTestOrder(0, models.Buy, models.Limit, "9.000000000000000000", "1.000000000000000000")
+	if err := m.SubmitOrder(order); err != nil {
+		t.Fatalf("precise order: %v", err)
+	}
+	if order.Price.Scale() != 2 || order.Quantity.Scale() != 0 || order.Remaining.Scale() != 0 {
+		t.Fatalf("order kept scales %d, %d, %d", order.Price.Scale(), order.Quantity.Scale(), order.Remaining.Scale())
+	}
+
+	for _, tt := range []struct{ price, qty string }{{"9.001", "1"}, {"9", "1.5"}} {
+		order := newTestOrder(0, models.Buy, models.Limit, tt.price, tt.qty)
+		if err := m.SubmitOrder(order); !errors.Is(err, ErrInvalidOrder) || !errors.Is(err, models.ErrInvalidPrecision) {
+			t.Fatalf("%s @ %s: got %v, want ErrInvalidPrecision", tt.qty, tt.price, err)
+		}
+	}
+	order = newTestOrder(0, models.Buy, models.Limit, "4.000000000000000000", "1.000000000000000000")
+	if err := m.SubmitOrder(order); !errors.Is(err, models.ErrBelowMinNotional) {
+		t.Fatalf("small order: got %v, want ErrBelowMinNotional", err)
+	}
+}
+
+func TestHaltRejectsQueuedOrders(t *testing.T) {
+	ob := NewOrderBook(newTestInstrument("BTC-USD"), 64, WaitPark)
+	ob.AddOrder(newTestOrder(1, models.Buy, models.Limit, "100", "1"))
+	ob.commands.Push(bookCommand{kind: cmdSetStatus, status: models.StatusHalted, reply: make(chan commandResult, 1)})
+	ob.AddOrder(newTestOrder(2, models.Buy, models.Limit, "100", "1"))
+	ob.commands.Push(bookCommand{kind: cmdDelist, reply: make(chan commandResult, 1)})
+	ob.ProcessOrders()
+
+	<-ob.orderEvents // Order 1 accepted
+	event := <-ob.orderEvents
+	if event.Order.ID != 2 || event.Reason != models.ReasonInstrumentNotTrading {
+		t.Fatalf("got event for order %d reason %v", event.Order.ID, event.Reason)
+	}
+	if _, resting := ob.orders[1]; resting {
+		t.Fatal("order accepted before the halt should be cancelled by the delist")
+	}
+}
+
+// restingAt returns the orders queued at the best ask, oldest first
+func restingAt(ob *OrderBook) []*models.Order {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	var orders []*models.Order
+	for node := ob.asks.best(); node != nil; node = node.next {
+		orders = append(orders, node.order)
+	}
+	return orders
+}
+
+func TestSequencerPreservesSubmissionOrder(t *testing.T) {
+	const producers, perProducer = 8, 200
+
+	m := NewMatchingEngine(16, 16, WaitPark) // Small buffers so producers contend and block
+	if _, err := m.AddInstrument(newTestInstrument("BTC-USD")); err != nil {
+		t.Fatal(err)
+	}
+	m.Start()
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				id := uint64(p*perProducer + i + 1)
+				if err := m.SubmitOrder(newTestOrder(id, models.Sell, models.Limit, "100", "1")); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(p)
+	}
+	wg.Wait()
+	barrier(t, m, "BTC-USD")
+
+	book := m.getOrderBook("BTC-USD")
+	queue := restingAt(book)
+	if len(queue) != producers*perProducer {
+		t.Fatalf("got %d resting orders, want %d", len(queue), producers*perProducer)
+	}
+
+	lastID := make([]uint64, producers)
+	for i, order := range queue {
+		// Time priority follows sequence order, with no gaps
+		if order.Sequence != uint64(i+1) {
+			t.Fatalf("queue position %d holds sequence %d", i, order.Sequence)
+		}
+		// Each producer's orders keep the order it submitted them in
+		p := (order.ID - 1) / perProducer
+		if order.ID <= lastID[p] {
+			t.Fatalf("order %d queued after order %d from the same producer", order.ID, lastID[p])
+		}
+		lastID[p] = order.ID
+	}
+
+	if got, want := book.Sequence(), uint64(producers*perProducer+1); got != want {
+		t.Fatalf("book sequence %d, want %d including the barrier", got, want)
+	}
+}
+
+func TestCancelFollowsSubmit(t *testing.T) {
+	m := NewMatchingEngine(64, 64, WaitPark)
+	if _, err := m.AddInstrument(newTestInstrument("BTC-USD")); err != nil {
+		t.Fatal(err)
+	}
+	m.Start()
+
+	// A cancel issued after SubmitOrder returns must always find the order
+	for id := uint64(1); id <= 500; id++ {
+		if err := m.SubmitOrder(newTestOrder(id, models.Buy, models.Limit, "100", "1")); err != nil {
+			t.Fatal(err)
+		}
+		order, err := m.CancelOrder("BTC-USD", "", id)
+		if err != nil {
+			t.Fatalf("cancel of order %d: %v", id, err)
+		}
+		if order.Sequence != 2*id-1 {
+			t.Fatalf("order %d has sequence %d, want %d", id, order.Sequence, 2*id-1)
+		}
+	}
+}
+
+func TestCancelAndAmendOwnOrdersOnly(t *testing.T) {
+	m, _ := startMarket(t, "BTC-USD")
+	order := newTestOrder(1, models.Sell, models.Limit, "101", "2")
+	order.Account = "alice"
+	if err := m.SubmitOrder(order); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.AmendOrder("BTC-USD", "bob", 1, models.Decimal{}, models.MustParseDecimal("1")); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("amend by another account: got %v, want ErrOrderNotFound", err)
+	}
+	if _, err := m.CancelOrder("BTC-USD", "bob", 1); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("cancel by another account: got %v, want ErrOrderNotFound", err)
+	}
+	amended, err := m.AmendOrder("BTC-USD", "alice", 1, models.Decimal{}, models.MustParseDecimal("1"))
+	if err != nil || amended.Quantity.String() != "1" {
+		t.Fatalf("amend by the owner: got %v, %v", amended, err)
+	}
+	if _, err := m.CancelOrder("BTC-USD", "alice", 1); err != nil {
+		t.Fatalf("cancel by the owner: %v", err)
+	}
+}
+
+func TestExecuteOrderWaitsForMatch(t *testing.T) {
+	m, submit := startMarket(t, "BTC-USD")
+	submit(1, models.Sell, "100", "2")
+	submit(2, models.Sell, "101", "2")
+
+	// The remainder of an IOC order is cancelled, not rested
+	result, err := m.ExecuteOrder(context.Background(), newTestOrder(3, models.Buy, models.IOC, "100", "3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Order.Status != models.Cancelled || result.Reason != models.ReasonExpired ||
+		!result.Order.Remaining.Equal(models.MustParseDecimal("1")) {
+		t.Fatalf("got status %v reason %v remaining %v", result.Order.Status, result.Reason, result.Order.Remaining)
+	}
+	if len(result.Trades) != 1 || result.Trades[0].MakerOrderID != 1 || !result.Trades[0].Quantity.Equal(models.MustParseDecimal("2")) {
+		t.Fatalf("got trades %+v, want 2 against order 1", result.Trades)
+	}
+
+	// A limit order's remainder rests
+	result, err = m.ExecuteOrder(context.Background(), newTestOrder(4, models.Buy, models.Limit, "101", "3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Order.Status != models.Partial || len(result.Trades) != 1 || !result.Order.Remaining.Equal(models.MustParseDecimal("1")) {
+		t.Fatalf("got status %v remaining %v after %d trades", result.Order.Status, result.Order.Remaining, len(result.Trades))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := m.ExecuteOrder(ctx, newTestOrder(5, models.Buy, models.Limit, "99", "1")); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expired context: got %v", err)
+	}
+}
+
+// TestMarketOrderFilledValueOverflow sends a market order for the largest quantity
+// a Decimal holds. It passes validation, but its filled value cannot take a second
+// full fill, so matching must stop there rather than panic the book.
+func TestMarketOrderFilledValueOverflow(t *testing.T) {
+	m, submit := startMarket(t, "BTC-USD")
+	submit(1, models.Sell, "100", "90000000000000000") // 100 × quantity just fits
+	submit(2, models.Sell, "100", "90000000000000000")
+
+	result, err := m.ExecuteOrder(context.Background(), newTestOrder(3, models.Buy, models.Market, "0", "9223372036854775807"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Order.Status != models.Cancelled || result.Reason != models.ReasonNotionalOutOfRange || len(result.Trades) != 1 {
+		t.Fatalf("got status %v reason %v after %d trades", result.Order.Status, result.Reason, len(result.Trades))
+	}
+
+	// The book goes on matching, and order 2 still rests
+	result, err = m.ExecuteOrder(context.Background(), newTestOrder(4, models.Buy, models.Limit, "100", "1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Order.Status != models.Filled || result.Trades[0].MakerOrderID != 2 {
+		t.Fatalf("got status %v after trades %+v", result.Order.Status, result.Trades)
+	}
+}
+
+func TestClientOrderIDDeduplication(t *testing.T) {
+	m := NewMatchingEngine(64, 64, WaitPark)
+	m.SetClientOrderIDWindow(time.Minute)
+	if _, err := m.AddInstrument(newTestInstrument("BTC-USD")); err != nil {
+		t.Fatal(err)
+	}
+	m.Start()
+	defer m.Stop()
+
+	submit := func(account, clientOID string) (*models.Order, error) {
+		order := newTestOrderFor(account, clientOID)
+		return order, m.SubmitOrder(order)
+	}
+	original, err := submit("alice", "a-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if original.ID == 0 {
+		t.Fatal("engine did not assign an order ID")
+	}
+
+	_, err = submit("alice", "a-1")
+	var duplicate *DuplicateOrderError
+	if !errors.As(err, &duplicate) || duplicate.OrderID != original.ID || !duplicate.Timestamp.Equal(original.Timestamp) {
+		t.Fatalf("resubmission: got %v, want the original order %d", err, original.ID)
+	}
+	if _, err := m.ExecuteOrder(context.Background(), newTestOrderFor("alice", "a-1")); !errors.Is(err, ErrDuplicateOrder) {
+		t.Fatalf("waiting resubmission: got %v", err)
+	}
+
+	// Scoped per account, and orders without a client order ID are never duplicates
+	other, err := submit("bob", "a-1")
+	if err != nil || other.ID == original.ID {
+		t.Fatalf("another account's order: id %d, %v", other.ID, err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := submit("alice", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Replayed orders are remembered while still within the window
+	restarted := NewMatchingEngine(64, 64, WaitPark)
+	restarted.SetClientOrderIDWindow(time.Minute)
+	if _, err := restarted.AddInstrument(newTestInstrument("BTC-USD")); err != nil {
+		t.Fatal(err)
+	}
+	journaled := newTestOrderFor("alice", "a-1") // As decoded from the journal, not shared with the running engine
+	journaled.ID = original.ID
+	if err := restarted.Replay(&JournalEntry{Type: JournalOrder, Sequence: 1, Instrument: "BTC-USD", Order: journaled}); err != nil {
+		t.Fatal(err)
+	}
+	if err := restarted.SubmitOrder(newTestOrderFor("alice", "a-1")); !errors.Is(err, ErrDuplicateOrder) {
+		t.Fatalf("resubmission after replay: got %v", err)
+	}
+}
+
+func TestOrderStateQueries(t *testing.T) {
+	m, _ := startMarket(t, "BTC-USD")
+	if _, err := m.AddInstrument(newTestInstrument("ETH-USD")); err != nil {
+		t.Fatal(err)
+	}
+	submit := func(id uint64, account, clientOID, instrument string, side models.OrderSide, price, qty string) {
+		order := newTestOrder(id, side, models.Limit, price, qty)
+		order.Account, order.ClientOID, order.Instrument, order.Timestamp = account, clientOID, instrument, time.Now()
+		if err := m.SubmitOrder(order); err != nil {
+			t.Fatal(err)
+		}
+	}
+	submit(1, "alice", "s-1", "BTC-USD", models.Sell, "100", "1")
+	submit(2, "alice", "s-2", "BTC-USD", models.Sell, "101", "3")
+	submit(3, "bob", "", "BTC-USD", models.Buy, "101", "2")
+	submit(4, "alice", "b-1", "ETH-USD", models.Buy, "10", "5")
+	barrier(t, m, "BTC-USD")
+	barrier(t, m, "ETH-USD")
+
+	check := func(order *models.Order, err error, status models.OrderStatus, remaining, average string) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.Status != status || !order.Remaining.Equal(models.MustParseDecimal(remaining)) ||
+			!order.AveragePrice().Equal(models.MustParseDecimal(average)) {
+			t.Fatalf("order %d: got %v remaining %v average %v, want %v remaining %s average %s",
+				order.ID, order.Status, order.Remaining, order.AveragePrice(), status, remaining, average)
+		}
+		if order.Timestamp.IsZero() || order.LastUpdated.Before(order.Timestamp) {
+			t.Fatalf("order %d: timestamp %v last updated %v", order.ID, order.Timestamp, order.LastUpdated)
+		}
+	}
+	order, err := m.GetOrder(3)
+	check(order, err, models.Filled, "0", "100.5")
+	order, err = m.GetOrder(2)
+	check(order, err, models.Partial, "2", "101")
+
+	// Closed orders stay queryable, also by the account's client order ID
+	order, err = m.GetOrderByClientID("alice", "s-1")
+	check(order, err, models.Filled, "0", "100")
+	if _, err := m.GetOrderByClientID("bob", "s-1"); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("another account's client order ID: got %v", err)
+	}
+	if _, err := m.GetOrder(99); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("unknown order: got %v", err)
+	}
+
+	ids := func(orders []*models.Order, err error) string {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fmt.Sprint(func() []uint64 {
+			ids := make([]uint64, len(orders))
+			for i, order := range orders {
+				ids[i] = order.ID
+			}
+			return ids
+		}())
+	}
+	if got := ids(m.ListOpenOrders("alice", "")); got != "[2 4]" {
+		t.Fatalf("alice's open orders: got %s", got)
+	}
+	if got := ids(m.ListOpenOrders("alice", "ETH-USD")); got != "[4]" {
+		t.Fatalf("alice's ETH-USD open orders: got %s", got)
+	}
+	if got := ids(m.ListOpenOrders("bob", "")); got != "[]" {
+		t.Fatalf("bob's open orders: got %s", got)
+	}
+	if _, err := m.ListOpenOrders("alice", "XRP-USD"); !errors.Is(err, ErrUnknownInstrument) {
+		t.Fatalf("unknown instrument: got %v", err)
+	}
+}
+
+func TestAbandonedOrderReleasesClientOrderID(t *testing.T) {
+	journal := &memJournal{}
+	m := NewMatchingEngine(64, 64, WaitPark)
+	m.SetClientOrderIDWindow(time.Minute)
+	if _, err := m.AddInstrument(newTestInstrument("BTC-USD")); err != nil {
+		t.Fatal(err)
+	}
+	m.SetJournal(journal)
+	m.Start()
+	defer m.Stop()
+	reserved := func(clientOID string) bool {
+		m.clientOrders.mu.Lock()
+		defer m.clientOrders.mu.Unlock()
+		_, ok := m.clientOrders.byKey[clientOrderKey{"alice", clientOID}]
+		return ok
+	}
+	waitReleased := func(clientOID string) {
+		t.Helper()
+		for deadline := time.Now().Add(time.Second); reserved(clientOID); time.Sleep(time.Millisecond) {
+			if time.Now().After(deadline) {
+				t.Fatalf("%s is still reserved", clientOID)
+			}
+		}
+	}
+
+	// Given up on before it was queued
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := m.ExecuteOrder(ctx, newTestOrderFor("alice", "a-1")); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want context.Canceled", err)
+	}
+	waitReleased("a-1")
+
+	// Given up on while the journal wrote it, which then failed
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := m.ExecuteOrder(ctx, newTestOrderFor("alice", "a-2")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+	if !reserved("a-2") {
+		t.Fatal("released a client order ID whose order may still be entered")
+	}
+	journal.fail(errors.New("disk full"))
+	waitReleased("a-2")
+}
+
+// TestClientOrderIDsExpireReplayedOrders mixes live and replayed orders: a replayed
+// order is remembered after newer live ones, yet must expire on its own timestamp
+func TestClientOrderIDsExpireReplayedOrders(t *testing.T) {
+	c := newClientOrderIDs()
+	c.setWindow(time.Minute)
+	now := time.Now()
+	at := func(clientOID string, age time.Duration) *models.Order {
+		order := newTestOrderFor("alice", clientOID)
+		order.Timestamp = now.Add(-age)
+		return order
+	}
+	if err := c.reserve(at("live-1", 0)); err != nil {
+		t.Fatal(err)
+	}
+	c.remember(at("replayed-old", 50*time.Second))
+	c.remember(at("replayed-new", 5*time.Second))
+	if err := c.reserve(at("live-2", 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expire(now.Add(20 * time.Second))
+	for clientOID, want := range map[string]bool{"replayed-old": false, "replayed-new": true, "live-1": true, "live-2": true} {
+		if _, ok := c.byKey[clientOrderKey{"alice", clientOID}]; ok != want {
+			t.Fatalf("%s remembered: %v, want %v", clientOID, ok, want)
+		}
+	}
+	if len(c.queue) != 3 {
+		t.Fatalf("%d entries queued after expiry, want 3", len(c.queue))
+	}
+}
+
+// newTestOrderFor returns a resting buy with the account and client order ID
+func newTestOrderFor(account, clientOID string) *models.Order {
+	order := newTestOrder(0, models.Buy, models.Limit, "100", "1")
+	order.Account, order.ClientOID, order.Timestamp = account, clientOID, time.Now()
+	return order
+}
+
+// memJournal records entries and holds acknowledgements until released
+type memJournal struct {
+	mu      sync.Mutex
+	entries []JournalEntry
+	waiters []chan<- error
+}
+
+func (j *memJournal) Append(entry *JournalEntry, durable chan<- error) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries = append(j.entries, *entry)
+	if durable != nil {
+		j.waiters = append(j.waiters, durable)
+	}
+	return nil
+}
+
+func (j *memJournal) release() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, durable := range j.waiters {
+		durable <- nil
+	}
+	j.waiters = nil
+}
+
+// fail refuses every entry still waiting for acknowledgement
+func (j *memJournal) fail(err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, durable := range j.waiters {
+		durable <- err
+	}
+	j.waiters = nil
+}
+
+func (j *memJournal) Close() error { return nil }
+
+func TestJournalBeforeAcknowledge(t *testing.T) {
+	journal := &memJournal{}
+	m := NewMatchingEngine(64, 64, WaitPark)
+	if _, err := m.AddInstrument(newTestInstrument("BTC-USD")); err != nil {
+		t.Fatal(err)
+	}
+	m.SetJournal(journal)
+	m.Start()
+
+	acked := make(chan error, 1)
+	go func() {
+		acked <- m.SubmitOrder(newTestOrder(1, models.Sell, models.Limit, "100", "1"))
+	}()
+	select {
+	case <-acked:
+		t.Fatal("order acknowledged before its journal entry was durable")
+	case <-time.After(20 * time.Millisecond):
+	}
+	journal.release()
+	if err := <-acked; err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		acked <- m.SubmitOrder(newTestOrder(2, models.Buy, models.Limit, "100", "1"))
+	}()
+	time.Sleep(10 * time.Millisecond)
+	journal.release()
+	if err := <-acked; err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_, err := m.CancelOrder("BTC-USD", "", 0)
+		acked <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	journal.release()
+	<-acked
+
+	// The trade is journaled by the book's output goroutine, so give it a moment
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		journal.mu.Lock()
+		n := len(journal.entries)
+		journal.mu.Unlock()
+		if n >= 4 {
+			break
+		}
+	}
+
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	var inputs []JournalEntryType
+	var trades int
+	for _, e := range journal.entries {
+		if e.Type == JournalRun {
+			continue
+		}
+		if e.Type == JournalTrade {
+			if e.Sequence != 2 || e.Trade.TakerOrderID != 2 {
+				t.Fatalf("trade journaled with sequence %d taker %d", e.Sequence, e.Trade.TakerOrderID)
+			}
+			trades++
+			continue
+		}
+		if e.Sequence != uint64(len(inputs)+1) {
+			t.Fatalf("input %d journaled with sequence %d", len(inputs)+1, e.Sequence)
+		}
+		inputs = append(inputs, e.Type)
+	}
+	if len(inputs) != 3 || inputs[0] != JournalOrder || inputs[1] != JournalOrder || inputs[2] != JournalCancel {
+		t.Fatalf("journaled inputs %v, want order, order, cancel", inputs)
+	}
+	if trades != 1 {
+		t.Fatalf("journaled %d trades, want 1", trades)
+	}
+}
+
+// encodedJournal keeps entries as encoded, the way a file journal would, and acks at once
+type encodedJournal struct {
+	mu      sync.Mutex
+	entries [][]byte
+}
+
+func (j *encodedJournal) Append(entry *JournalEntry, durable chan<- error) error {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	j.entries = append(j.entries, payload)
+	j.mu.Unlock()
+	if durable != nil {
+		durable <- nil
+	}
+	return nil
+}
+
+func (j *encodedJournal) Close() error { return nil }
+
+func (j *encodedJournal) trades() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var n int
+	for _, payload := range j.entries {
+		var entry JournalEntry
+		if json.Unmarshal(payload, &entry) == nil && entry.Type == JournalTrade {
+			n++
+		}
+	}
+	return n
+}
+
+// TestStopDrainsBooks checks that Stop returns only once every accepted order
+// has been matched and its trades journaled, and that lost trades are counted
+func TestStopDrainsBooks(t *testing.T) {
+	const pairs = 200
+	m := NewMatchingEngine(64, 8, WaitPark)
+	if _, err := m.AddInstrument(newTestInstrument("BTC-USD")); err != nil {
+		t.Fatal(err)
+	}
+	journal := &encodedJournal{}
+	m.SetJournal(journal)
+	m.Start()
+
+	for i := 0; i < pairs; i++ {
+		for _, side := range []models.OrderSide{models.Sell, models.Buy} {
+			if err := m.SubmitOrder(newTestOrder(0, side, models.Limit, "100", "1")); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	m.Stop()
+
+	if n := journal.trades(); n != pairs {
+		t.Fatalf("journaled %d trades by Stop, want %d", n, pairs)
+	}
+	if n := len(restingAt(m.getOrderBook("BTC-USD"))); n != 0 {
+		t.Fatalf("%d orders resting after Stop, want 0", n)
+	}
+	if err := m.SubmitOrder(newTestOrder(0, models.Buy, models.Limit, "100", "1")); !errors.Is(err, ErrEngineStopped) {
+		t.Fatalf("submit after Stop: got %v", err)
+	}
+	if _, err := m.AddInstrument(newTestInstrument("ETH-USD")); !errors.Is(err, ErrEngineStopped) {
+		t.Fatalf("add after Stop: got %v", err)
+	}
+
+	m = NewMatchingEngine(64, 8, WaitPark)
+	if _, err := m.AddInstrument(newTestInstrument("BTC-USD")); err != nil {
+		t.Fatal(err)
+	}
+	m.SetJournal(tradeFailingJournal{journal})
+	m.Start()
+	for _, side := range []models.OrderSide{models.Sell, models.Buy} {
+		if err := m.SubmitOrder(newTestOrder(0, side, models.Limit, "100", "1")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m.Stop()
+	if n := m.LostTrades(); n != 1 {
+		t.Fatalf("counted %d lost trades, want 1", n)
+	}
+}
+
+// tradeFailingJournal records commands but fails every trade
+type tradeFailingJournal struct{ *encodedJournal }
+
+func (j tradeFailingJournal) Append(entry *JournalEntry, durable chan<- error) error {
+	if entry.Type == JournalTrade {
+		return errors.New("disk full")
+	}
+	return j.encodedJournal.Append(entry, durable)
+}
+
+// refusingJournal fails every write
+type refusingJournal struct{}
+
+func (refusingJournal) Append(*JournalEntry, chan<- error) error { return errors.New("disk full") }
+func (refusingJournal) Close() error                             { return nil }
+
+// TestInstrumentAdminBeforeStart checks that listings, halts and delists made
+// before Start are journaled, so replay repeats them, and that a listing the
+// journal refuses is not made
+func TestInstrumentAdminBeforeStart(t *testing.T) {
+	configure := func() *MatchingEngine {
+		m := NewMatchingEngine(64, 64, WaitPark)
+		for _, symbol := range []string{"BTC-USD", "ETH-USD"} {
+			if _, err := m.AddInstrument(newTestInstrument(symbol)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return m
+	}
+	journal := &encodedJournal{}
+	m := configure()
+	m.SetJournal(journal)
+	if _, err := m.AddInstrument(newTestInstrument("SOL-USD")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.HaltInstrument("BTC-USD"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.DelistInstrument("ETH-USD"); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := configure()
+	journal.mu.Lock()
+	for _, payload := range journal.entries {
+		var entry JournalEntry
+		if err := json.Unmarshal(payload, &entry); err != nil {
+			t.Fatal(err)
+		}
+		if err := replayed.Replay(&entry); err != nil {
+			t.Fatalf("replay %+v: %v", entry, err)
+		}
+	}
+	journal.mu.Unlock()
+	if btc, ok := replayed.GetInstrument("BTC-USD"); !ok || btc.Status != models.StatusHalted {
+		t.Fatalf("BTC-USD replayed as %+v, want halted", btc)
+	}
+	if _, ok := replayed.GetInstrument("ETH-USD"); ok {
+		t.Fatal("ETH-USD still listed after replaying its delist")
+	}
+	if _, ok := replayed.GetInstrument("SOL-USD"); !ok {
+		t.Fatal("SOL-USD not listed by replay")
+	}
+
+	m = configure()
+	m.SetJournal(refusingJournal{})
+	if _, err := m.AddInstrument(newTestInstrument("SOL-USD")); !errors.Is(err, ErrJournal) {
+		t.Fatalf("add with a failing journal: got %v", err)
+	}
+	if _, ok := m.GetInstrument("SOL-USD"); ok {
+		t.Fatal("SOL-USD listed although its journal write failed")
+	}
+	if _, err := m.HaltInstrument("BTC-USD"); !errors.Is(err, ErrJournal) {
+		t.Fatalf("halt with a failing journal: got %v", err)
+	}
+	if btc, _ := m.GetInstrument("BTC-USD"); btc.Status != models.StatusTrading {
+		t.Fatalf("BTC-USD is %v although its halt was not journaled", btc.Status)
+	}
+}
+
+// describeBook lists every resting order in priority order, bids then asks
+func describeBook(ob *OrderBook) []string {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	var orders []string
+	for _, side := range []*OrderSide{ob.bids, ob.asks} {
+		for level := side.levels.best(); level != nil; level = level.forward[0] {
+			for node := level.head; node != nil; node = node.next {
+				o := node.order
+				orders = append(orders, fmt.Sprintf("%d %v %v/%v %v", o.ID, o.Side, o.Remaining, o.Quantity, o.Price))
+			}
+		}
+	}
+	return append(orders, ob.Instrument().Status.String(), fmt.Sprint(ob.Sequence()))
+}
+
+func TestReplayRebuildsBooks(t *testing.T) {
+	atomic.StoreUint64(&tradeIDCounter, 0)
+	atomic.StoreUint64(&executionCounter, 0)
+
+	journal := &encodedJournal{}
+	m := NewMatchingEngine(64, 64, WaitPark)
+	m.SetJournal(journal)
+	m.Start()
+	if _, err := m.AddInstrument(newTestInstrument("BTC-USD")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.AddInstrument(newTestInstrument("ETH-USD")); err != nil {
+		t.Fatal(err)
+	}
+
+	submit := func(id uint64, symbol string, side models.OrderSide, price, qty string) {
+		order := newTestOrder(id, side, models.Limit, price, qty)
+		order.Instrument = symbol
+		if err := m.SubmitOrder(order); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, price := range []string{"101", "100", "100", "102"} {
+		submit(uint64(i+1), "BTC-USD", models.Sell, price, "2")
+		submit(uint64(i+11), "ETH-USD", models.Buy, price, "3")
+	}
+	submit(5, "BTC-USD", models.Buy, "101", "5")
+	submit(15, "ETH-USD", models.Sell, "100", "4")
+	barrier(t, m, "BTC-USD")
+	if _, err := m.CancelOrder("BTC-USD", "", 4); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.CancelOrder("BTC-USD", "mallory", 1); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("cancel of another account's order: got %v", err) // Must fail on replay too
+	}
+	if _, err := m.AmendOrder("ETH-USD", "", 12, models.MustParseDecimal("101"), models.MustParseDecimal("3")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.HaltInstrument("ETH-USD"); err != nil {
+		t.Fatal(err)
+	}
+	barrier(t, m, "ETH-USD")
+
+	trades := atomic.LoadUint64(&tradeIDCounter)
+	executions := atomic.LoadUint64(&executionCounter)
+	if trades == 0 {
+		t.Fatal("workload produced no trades")
+	}
+	for deadline := time.Now().Add(time.Second); journal.trades() < int(trades); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("journaled %d of %d trades", journal.trades(), trades)
+		}
+	}
+	want := map[string][]string{
+		"BTC-USD": describeBook(m.getOrderBook("BTC-USD")),
+		"ETH-USD": describeBook(m.getOrderBook("ETH-USD")),
+	}
+	m.Stop()
+
+	atomic.StoreUint64(&tradeIDCounter, 0)
+	atomic.StoreUint64(&executionCounter, 0)
+	recovered := NewMatchingEngine(64, 64, WaitPark)
+	if _, err := recovered.AddInstrument(newTestInstrument("BTC-USD")); err != nil {
+		t.Fatal(err) // Configured instruments are listed before the journal is replayed
+	}
+	journal.mu.Lock()
+	for _, payload := range journal.entries {
+		var entry JournalEntry
+		if err := json.Unmarshal(payload, &entry); err != nil {
+			t.Fatal(err)
+		}
+		if err := recovered.Replay(&entry); err != nil {
+			t.Fatalf("replay %+v: %v", entry, err)
+		}
+	}
+	journal.mu.Unlock()
+
+	for symbol, orders := range want {
+		got := describeBook(recovered.getOrderBook(symbol))
+		if fmt.Sprint(got) != fmt.Sprint(orders) {
+			t.Fatalf("%s rebuilt as\n%v\nwant\n%v", symbol, got, orders)
+		}
+	}
+	if got := atomic.LoadUint64(&tradeIDCounter); got != trades {
+		t.Fatalf("trade ID counter %d, want %d", got, trades)
+	}
+	if got := atomic.LoadUint64(&executionCounter); got != executions {
+		t.Fatalf("execution counter %d, want %d", got, executions)
+	}
+
+	recovered.Start()
+	defer recovered.Stop()
+	if err := recovered.Replay(&JournalEntry{Type: JournalCancel, Instrument: "BTC-USD"}); !errors.Is(err, ErrEngineRunning) {
+		t.Fatalf("replay after start: got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/aeromatch/internal/models"
+)
+
+// L3EventType is what happened to a resting order
+type L3EventType uint8
+
+const (
+	L3Add     L3EventType = iota // The order now rests in the book, at the back of its price level
+	L3Modify                     // The order's size was reduced in place, keeping its queue position
+	L3Delete                     // The order left the book without trading: cancelled, amended away or delisted
+	L3Execute                    // The order traded; it leaves the book once nothing remains
+)
+
+func (t L3EventType) String() string {
+	switch t {
+	case L3Add:
+		return "add"
+	case L3Modify:
+		return "modify"
+	case L3Delete:
+		return "delete"
+	case L3Execute:
+		return "execute"
+	default:
+		return "unknown"
+	}
+}
+
+// L3Event is a change to one resting order
+type L3Event struct {
+	Type     L3EventType      `json:"type"`
+	OrderID  uint64           `json:"order_id"`
+	Side     models.OrderSide `json:"side"`
+	Price    models.Decimal   `json:"price"`              // Resting price, or the trade price for executions
+	Quantity models.Decimal   `json:"quantity"`           // Size left resting, or the size traded for executions
+	TradeID  uint64           `json:"trade_id,omitempty"` // Executions only
+}
+
+// L3Order is a resting order in an L3 snapshot
+type L3Order struct {
+	OrderID  uint64         `json:"order_id"`
+	Price    models.Decimal `json:"price"`
+	Quantity models.Decimal `json:"quantity"` // Size left resting
+}
+
+// L3Update is an order-by-order view of one instrument's book. A subscriber's
+// first update for a book is a snapshot of every resting order; each later one
+// holds the events of a single command in the order they happened. Sequence
+// increases by one per update, as for L2 updates.
+type L3Update struct {
+	Instrument string    `json:"instrument"`
+	Sequence   uint64    `json:"sequence"`
+	Snapshot   bool      `json:"snapshot"`
+	Timestamp  int64     `json:"timestamp"`
+	Events     []L3Event `json:"events,omitempty"`
+	Bids       []L3Order `json:"bids,omitempty"` // Snapshots only, best price first, in queue order within a price
+	Asks       []L3Order `json:"asks,omitempty"`
+}
+
+// traceOrder records an event for the order-by-order feed while a command being
+// applied has L3 subscribers; the caller must hold mu
+func (ob *OrderBook) traceOrder(kind L3EventType, order *models.Order, price, quantity models.Decimal, tradeID uint64) {
+	if !ob.tracing {
+		return
+	}
+	ob.orderFlow = append(ob.orderFlow, L3Event{
+		Type:     kind,
+		OrderID:  order.ID,
+		Side:     order.Side,
+		Price:    price,
+		Quantity: quantity,
+		TradeID:  tradeID,
+	})
+}
+
+// publishOrders puts the events of the command just applied on the market data
+// bus; the caller must hold mu
+func (ob *OrderBook) publishOrders() {
+	if len(ob.orderFlow) == 0 {
+		return
+	}
+	ob.orderSequence++
+	ob.marketData.publish(MarketData{Instrument: ob.instrument.Symbol, Orders: &L3Update{
+		Instrument: ob.instrument.Symbol,
+		Sequence:   ob.orderSequence,
+		Timestamp:  time.Now().UnixNano(),
+		Events:     ob.orderFlow,
+	}})
+	ob.orderFlow = nil // Subscribers own the published slice
+}
+
+// orderSnapshot returns every resting order as a snapshot update at the last
+// sequence published; the caller must hold mu
+func (ob *OrderBook) orderSnapshot() *L3Update {
+	return &L3Update{
+		Instrument: ob.instrument.Symbol,
+		Sequence:   ob.orderSequence,
+		Snapshot:   true,
+		Timestamp:  time.Now().UnixNano(),
+		Bids:       ob.bids.restingOrders(),
+		Asks:       ob.asks.restingOrders(),
+	}
+}
+
+// restingOrders lists the side's orders in priority order
+func (os *OrderSide) restingOrders() []L3Order {
+	orders := make([]L3Order, 0, os.counter)
+	for level := os.levels.best(); level != nil; level = level.forward[0] {
+		for node := level.head; node != nil; node = node.next {
+			orders = append(orders, L3Order{OrderID: node.order.ID, Price: level.price, Quantity: node.order.Remaining})
+		}
+	}
+	return orders
+}
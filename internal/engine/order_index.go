@@ -0,0 +1,133 @@
+package engine
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/aeromatch/internal/models"
+)
+
+// closedOrdersKept is how many closed orders a book keeps answering queries about
+const closedOrdersKept = 10000
+
+// orderIndex holds the latest reported state of a book's orders for queries:
+// every open order, and closed ones until closedOrdersKept newer ones have
+// closed. States are copies that are never modified once indexed. The book
+// updates it under its write lock; readers hold the read lock.
+type orderIndex struct {
+	byID        map[uint64]*models.Order
+	byClientOID map[clientOrderKey]uint64
+	open        map[string]map[uint64]struct{} // Open order IDs by account
+	closed      []uint64                       // Oldest first
+}
+
+func newOrderIndex() *orderIndex {
+	return &orderIndex{
+		byID:        make(map[uint64]*models.Order),
+		byClientOID: make(map[clientOrderKey]uint64),
+		open:        make(map[string]map[uint64]struct{}),
+	}
+}
+
+// update records the order's new state
+func (x *orderIndex) update(state *models.Order) {
+	previous, known := x.byID[state.ID]
+	x.byID[state.ID] = state
+	if state.ClientOID != "" {
+		x.byClientOID[clientOrderKey{state.Account, state.ClientOID}] = state.ID
+	}
+
+	if state.IsActive() {
+		ids, ok := x.open[state.Account]
+		if !ok {
+			ids = make(map[uint64]struct{})
+			x.open[state.Account] = ids
+		}
+		ids[state.ID] = struct{}{}
+		return
+	}
+	if known && !previous.IsActive() {
+		return // Already closed
+	}
+	if ids, ok := x.open[state.Account]; ok {
+		delete(ids, state.ID)
+		if len(ids) == 0 {
+			delete(x.open, state.Account)
+		}
+	}
+	x.closed = append(x.closed, state.ID)
+	for len(x.closed) > closedOrdersKept {
+		x.forget(x.closed[0])
+		x.closed = x.closed[1:]
+	}
+}
+
+// forget drops a closed order
+func (x *orderIndex) forget(id uint64) {
+	state, ok := x.byID[id]
+	if !ok {
+		return
+	}
+	delete(x.byID, id)
+	if state.ClientOID == "" {
+		return
+	}
+	key := clientOrderKey{state.Account, state.ClientOID}
+	if x.byClientOID[key] == id {
+		delete(x.byClientOID, key)
+	}
+}
+
+// openOrders returns the account's open orders, oldest ID first
+func (x *orderIndex) openOrders(account string) []*models.Order {
+	ids := x.open[account]
+	orders := make([]*models.Order, 0, len(ids))
+	for id := range ids {
+		orders = append(orders, x.byID[id])
+	}
+	slices.SortFunc(orders, func(a, b *models.Order) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return orders
+}
+
+// GetOrder returns a copy of the order's latest state, open or recently closed
+func (ob *OrderBook) GetOrder(orderID uint64) (*models.Order, bool) {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	return ob.indexedOrder(orderID)
+}
+
+// GetOrderByClientID returns a copy of the latest state of the account's order
+// with the client order ID, open or recently closed
+func (ob *OrderBook) GetOrderByClientID(account, clientOID string) (*models.Order, bool) {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	id, ok := ob.index.byClientOID[clientOrderKey{account, clientOID}]
+	if !ok {
+		return nil, false
+	}
+	return ob.indexedOrder(id)
+}
+
+// indexedOrder returns a copy of the order's indexed state; the caller must hold mu
+func (ob *OrderBook) indexedOrder(orderID uint64) (*models.Order, bool) {
+	state, ok := ob.index.byID[orderID]
+	if !ok {
+		return nil, false
+	}
+	order := *state
+	return &order, true
+}
+
+// OpenOrders returns copies of the account's open orders, oldest ID first
+func (ob *OrderBook) OpenOrders(account string) []*models.Order {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	orders := ob.index.openOrders(account)
+	for i, state := range orders {
+		order := *state
+		orders[i] = &order
+	}
+	return orders
+}
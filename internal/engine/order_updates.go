@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/aeromatch/internal/models"
+)
+
+// ErrOrderUpdatesBehind ends an order update subscription whose queue filled up.
+// Execution reports cannot be skipped or conflated, so a client that falls
+// behind must reconnect and reconcile its orders.
+var ErrOrderUpdatesBehind = errors.New("order update subscriber fell behind")
+
+// OrderUpdateFeed routes order events to subscribers of the account that owns
+// the order. Like the market data bus it never blocks the publisher: each
+// subscriber has its own bounded queue.
+type OrderUpdateFeed struct {
+	mu       sync.RWMutex
+	accounts map[string]map[*OrderUpdateSubscriber]struct{}
+}
+
+func newOrderUpdateFeed() *OrderUpdateFeed {
+	return &OrderUpdateFeed{accounts: make(map[string]map[*OrderUpdateSubscriber]struct{})}
+}
+
+// Subscribe adds a subscriber for the account's order events, queueing up to
+// buffer of them; zero means 1024
+func (f *OrderUpdateFeed) Subscribe(account string, buffer int) *OrderUpdateSubscriber {
+	if buffer <= 0 {
+		buffer = defaultSubscriberBuffer
+	}
+	s := &OrderUpdateSubscriber{
+		feed:    f,
+		account: account,
+		queue:   make(chan *models.OrderEvent, buffer),
+		done:    make(chan struct{}),
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	subscribers, ok := f.accounts[account]
+	if !ok {
+		subscribers = make(map[*OrderUpdateSubscriber]struct{})
+		f.accounts[account] = subscribers
+	}
+	subscribers[s] = struct{}{}
+	return s
+}
+
+// publish offers the event to every subscriber of the order's account
+func (f *OrderUpdateFeed) publish(event *models.OrderEvent) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for s := range f.accounts[event.Order.Account] {
+		s.offer(event)
+	}
+}
+
+// unsubscribe removes the subscriber and ends it with err
+func (f *OrderUpdateFeed) unsubscribe(s *OrderUpdateSubscriber, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	subscribers := f.accounts[s.account]
+	if _, ok := subscribers[s]; !ok {
+		return
+	}
+	delete(subscribers, s)
+	if len(subscribers) == 0 {
+		delete(f.accounts, s.account)
+	}
+	s.mu.Lock()
+	s.closeLocked(err)
+	s.mu.Unlock()
+}
+
+// OrderUpdateSubscriber reads one account's order events through Next, in the
+// order they happened per instrument
+type OrderUpdateSubscriber struct {
+	feed    *OrderUpdateFeed
+	account string
+	queue   chan *models.OrderEvent
+	done    chan struct{} // Closed when the subscription ends
+
+	mu  sync.Mutex
+	err error
+}
+
+// Next returns the subscriber's next order event, waiting for one until ctx is
+// done or the subscription ends
+func (s *OrderUpdateSubscriber) Next(ctx context.Context) (*models.OrderEvent, error) {
+	select {
+	case <-s.done:
+		return nil, s.err // Whatever is still queued is abandoned
+	default:
+	}
+	select {
+	case event := <-s.queue:
+		return event, nil
+	case <-s.done:
+		return nil, s.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close ends the subscription; Next then returns ErrSubscriptionClosed
+func (s *OrderUpdateSubscriber) Close() {
+	s.feed.unsubscribe(s, ErrSubscriptionClosed)
+}
+
+// offer queues the event, ending the subscription if there is no room.
+// The caller holds the feed's read lock.
+func (s *OrderUpdateSubscriber) offer(event *models.OrderEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return
+	}
+	select {
+	case s.queue <- event:
+	default:
+		s.closeLocked(ErrOrderUpdatesBehind)
+		go s.feed.unsubscribe(s, ErrOrderUpdatesBehind) // The caller holds the feed's read lock
+	}
+}
+
+// closeLocked ends the subscription with err; the caller must hold mu
+func (s *OrderUpdateSubscriber) closeLocked(err error) {
+	if s.err != nil {
+		return
+	}
+	s.err = err
+	close(s.done)
+}
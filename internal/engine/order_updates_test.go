@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aeromatch/internal/models"
+)
+
+// nextEvent waits for the subscriber's next order event
+func nextEvent(t *testing.T, sub *OrderUpdateSubscriber) *models.OrderEvent {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	event, err := sub.Next(ctx)
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	return event
+}
+
+func TestOrderUpdatesReportEveryTransition(t *testing.T) {
+	m, _ := startMarket(t, "BTC-USD")
+	maker := m.SubscribeOrderUpdates("maker", 0)
+	defer maker.Close()
+	taker := m.SubscribeOrderUpdates("taker", 0)
+	defer taker.Close()
+
+	submit := func(id uint64, account string, side models.OrderSide, orderType models.OrderType, price, qty string) {
+		order := newTestOrder(id, side, orderType, price, qty)
+		order.Account = account
+		if err := m.SubmitOrder(order); err != nil {
+			t.Fatal(err)
+		}
+	}
+	submit(1, "maker", models.Sell, models.Limit, "100", "2")
+	submit(2, "maker", models.Sell, models.Limit, "101", "5")
+	submit(3, "taker", models.Buy, models.IOC, "100", "4")
+	barrier(t, m, "BTC-USD")
+	if _, err := m.CancelOrder("BTC-USD", "", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	type report struct {
+		id             uint64
+		old, status    models.OrderStatus
+		cumulative     string
+		lastQty, price string
+		reason         models.RejectReason
+	}
+	check := func(sub *OrderUpdateSubscriber, want []report) {
+		t.Helper()
+		for _, w := range want {
+			event := nextEvent(t, sub)
+			order := event.Order
+			cumulative := order.Quantity.Sub(order.Remaining)
+			if order.ID != w.id || event.OldStatus != w.old || order.Status != w.status || event.Reason != w.reason ||
+				!cumulative.Equal(models.MustParseDecimal(w.cumulative)) {
+				t.Fatalf("got order %d %v -> %v filled %v reason %v, want %+v",
+					order.ID, event.OldStatus, order.Status, cumulative, event.Reason, w)
+			}
+			if w.lastQty == "" {
+				if event.TradeID != 0 {
+					t.Fatalf("order %d: got trade %d on a report without a fill", order.ID, event.TradeID)
+				}
+				continue
+			}
+			if event.TradeID == 0 || !event.TradeSize.Equal(models.MustParseDecimal(w.lastQty)) || !event.TradePrice.Equal(models.MustParseDecimal(w.price)) {
+				t.Fatalf("order %d: got fill %v @ %v trade %d, want %v @ %v", order.ID, event.TradeSize, event.TradePrice, event.TradeID, w.lastQty, w.price)
+			}
+		}
+	}
+
+	check(maker, []report{
+		{id: 1, old: models.New, status: models.New, cumulative: "0"},
+		{id: 2, old: models.New, status: models.New, cumulative: "0"},
+		{id: 1, old: models.New, status: models.Filled, cumulative: "2", lastQty: "2", price: "100"},
+		{id: 2, old: models.New, status: models.Cancelled, cumulative: "0"},
+	})
+	check(taker, []report{
+		{id: 3, old: models.New, status: models.New, cumulative: "0"},
+		{id: 3, old: models.New, status: models.Partial, cumulative: "2", lastQty: "2", price: "100"},
+		{id: 3, old: models.Partial, status: models.Cancelled, cumulative: "2", reason: models.ReasonExpired},
+	})
+}
+
+func TestOrderUpdatesDisconnectSlowSubscriber(t *testing.T) {
+	feed := newOrderUpdateFeed()
+	sub := feed.Subscribe("acct", 1)
+	other := feed.Subscribe("other", 1)
+	defer other.Close()
+
+	for id := uint64(1); id <= 2; id++ {
+		feed.publish(&models.OrderEvent{Order: &models.Order{ID: id, Account: "acct"}})
+	}
+	if _, err := sub.Next(context.Background()); !errors.Is(err, ErrOrderUpdatesBehind) {
+		t.Fatalf("got %v, want ErrOrderUpdatesBehind", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if event, err := other.Next(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("another account's subscriber got %+v, %v", event, err)
+	}
+}
@@ -0,0 +1,172 @@
+package engine
+
+import "github.com/aeromatch/internal/models"
+
+const (
+	maxSkipLevel   = 24     // Enough for ~16M price levels per side
+	skipLevelShift = 2      // P(level promotion) = 1/4
+	skipSeed       = 0x9e37 // Fixed seed keeps level layout reproducible
+)
+
+// priceLevel holds all resting orders at a single price in FIFO (time) order
+type priceLevel struct {
+	price   models.Decimal
+	head    *OrderNode     // Oldest order, first to match
+	tail    *OrderNode     // Newest order
+	count   int32          // Number of orders resting at this price
+	volume  models.Decimal // Sum of remaining quantity at this price
+	forward []*priceLevel
+}
+
+// priceLevels is a skip list of price levels ordered best-first.
+// Search, insert and delete are O(log n) expected; the best level is O(1).
+type priceLevels struct {
+	head   priceLevel // Sentinel, never holds orders
+	height int
+	length int
+	better func(a, b models.Decimal) bool // Reports whether price a ranks ahead of price b
+	rng    uint64
+}
+
+func newPriceLevels(better func(a, b models.Decimal) bool) *priceLevels {
+	return &priceLevels{
+		head:   priceLevel{forward: make([]*priceLevel, maxSkipLevel)},
+		height: 1,
+		better: better,
+		rng:    skipSeed,
+	}
+}
+
+// descending ranks higher prices first (bids)
+func descending(a, b models.Decimal) bool { return a.GreaterThan(b) }
+
+// ascending ranks lower prices first (asks)
+func ascending(a, b models.Decimal) bool { return a.LessThan(b) }
+
+// randomHeight picks a tower height using a xorshift generator
+func (pl *priceLevels) randomHeight() int {
+	height := 1
+	for height < maxSkipLevel {
+		pl.rng ^= pl.rng << 13
+		pl.rng ^= pl.rng >> 7
+		pl.rng ^= pl.rng << 17
+		if pl.rng&(1<<skipLevelShift-1) != 0 {
+			break
+		}
+		height++
+	}
+	return height
+}
+
+// best returns the top-of-book level, or nil if the side is empty
+func (pl *priceLevels) best() *priceLevel {
+	return pl.head.forward[0]
+}
+
+// find returns the level at price, or nil if none exists
+func (pl *priceLevels) find(price models.Decimal) *priceLevel {
+	x := &pl.head
+	for i := pl.height - 1; i >= 0; i-- {
+		for x.forward[i] != nil && pl.better(x.forward[i].price, price) {
+			x = x.forward[i]
+		}
+	}
+	x = x.forward[0]
+	if x != nil && x.price.Equal(price) {
+		return x
+	}
+	return nil
+}
+
+// getOrCreate returns the level at price, inserting an empty one if needed
+func (pl *priceLevels) getOrCreate(price models.Decimal) *priceLevel {
+	var update [maxSkipLevel]*priceLevel
+	x := &pl.head
+	for i := pl.height - 1; i >= 0; i-- {
+		for x.forward[i] != nil && pl.better(x.forward[i].price, price) {
+			x = x.forward[i]
+		}
+		update[i] = x
+	}
+	if next := x.forward[0]; next != nil && next.price.Equal(price) {
+		return next
+	}
+
+	height := pl.randomHeight()
+	if height > pl.height {
+		for i := pl.height; i < height; i++ {
+			update[i] = &pl.head
+		}
+		pl.height = height
+	}
+
+	level := &priceLevel{
+		price:   price,
+		forward: make([]*priceLevel, height),
+	}
+	for i := 0; i < height; i++ {
+		level.forward[i] = update[i].forward[i]
+		update[i].forward[i] = level
+	}
+	pl.length++
+	return level
+}
+
+// remove unlinks the level at price from the list
+func (pl *priceLevels) remove(price models.Decimal) {
+	var update [maxSkipLevel]*priceLevel
+	x := &pl.head
+	for i := pl.height - 1; i >= 0; i-- {
+		for x.forward[i] != nil && pl.better(x.forward[i].price, price) {
+			x = x.forward[i]
+		}
+		update[i] = x
+	}
+	target := x.forward[0]
+	if target == nil || !target.price.Equal(price) {
+		return
+	}
+
+	for i := 0; i < pl.height; i++ {
+		if update[i].forward[i] != target {
+			break
+		}
+		update[i].forward[i] = target.forward[i]
+	}
+	for pl.height > 1 && pl.head.forward[pl.height-1] == nil {
+		pl.height--
+	}
+	pl.length--
+}
+
+// pushBack appends a node to the tail of the level's queue
+func (l *priceLevel) pushBack(node *OrderNode) {
+	node.level = l
+	node.prev = l.tail
+	node.next = nil
+	if l.tail != nil {
+		l.tail.next = node
+	} else {
+		l.head = node
+	}
+	l.tail = node
+	l.count++
+	l.volume = l.volume.Add(node.order.Remaining)
+}
+
+// unlink removes a node from the level's queue
+func (l *priceLevel) unlink(node *OrderNode) {
+	if node.prev != nil {
+		node.prev.next = node.next
+	} else {
+		l.head = node.next
+	}
+	if node.next != nil {
+		node.next.prev = node.prev
+	} else {
+		l.tail = node.prev
+	}
+	node.prev, node.next, node.level = nil, nil, nil
+	l.count--
+	l.volume = l.volume.Sub(node.order.Remaining)
+}
@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/aeromatch/internal/models"
+)
+
+// levelPrices walks the bottom lane of the skip list, best level first
+func levelPrices(pl *priceLevels) []string {
+	var prices []string
+	for level := pl.best(); level != nil; level = level.forward[0] {
+		prices = append(prices, level.price.String())
+	}
+	return prices
+}
+
+func TestPriceLevelsOrderBestFirst(t *testing.T) {
+	// Enough levels to grow towers several lanes high, inserted out of order
+	const n = 500
+	bids, asks := newOrderSide(descending), newOrderSide(ascending)
+	for i := 0; i < n; i++ {
+		tick := (i * 7919) % n // A permutation of 0..n-1
+		price := fmt.Sprintf("%d.%02d", 100+tick/100, tick%100)
+		bids.add(newTestOrder(uint64(i+1), models.Buy, models.Limit, price, "1"))
+		asks.add(newTestOrder(uint64(n+i+1), models.Sell, models.Limit, price, "1"))
+	}
+
+	if best, _ := bids.bestOrder(); best.Price.String() != "104.99" {
+		t.Fatalf("best bid %s, want the highest price 104.99", best.Price)
+	}
+	if best, _ := asks.bestOrder(); best.Price.String() != "100.00" {
+		t.Fatalf("best ask %s, want the lowest price 100.00", best.Price)
+	}
+	if bids.levels.height < 3 {
+		t.Fatalf("skip list only %d lanes high after %d levels", bids.levels.height, n)
+	}
+
+	for _, side := range []struct {
+		name   string
+		levels *priceLevels
+		want   int // Sign of the comparison between consecutive prices
+	}{
+		{"bids", bids.levels, -1},
+		{"asks", asks.levels, 1},
+	} {
+		prices := levelPrices(side.levels)
+		if len(prices) != n || side.levels.length != n {
+			t.Fatalf("%s: walked %d levels, length %d, want %d", side.name, len(prices), side.levels.length, n)
+		}
+		for i := 1; i < len(prices); i++ {
+			if models.MustParseDecimal(prices[i]).Cmp(models.MustParseDecimal(prices[i-1])) != side.want {
+				t.Fatalf("%s: %s follows %s", side.name, prices[i], prices[i-1])
+			}
+		}
+		// Every level is found through the upper lanes too
+		for _, price := range prices {
+			if level := side.levels.find(models.MustParseDecimal(price)); level == nil || level.price.String() != price {
+				t.Fatalf("%s: find(%s) returned %v", side.name, price, level)
+			}
+		}
+	}
+	if bids.levels.find(models.MustParseDecimal("99.99")) != nil {
+		t.Fatal("found a level that was never added")
+	}
+
+	depth := asks.depth(3)
+	if len(depth) != 3 || depth[0].Price.String() != "100.00" || depth[2].Price.String() != "100.02" {
+		t.Fatalf("ask depth %+v, want the three lowest prices in order", depth)
+	}
+	if all := bids.depth(0); len(all) != n || all[0].Price.String() != "104.99" {
+		t.Fatalf("unlimited bid depth has %d levels starting at %s", len(all), all[0].Price)
+	}
+}
+
+func TestPriceLevelQueue(t *testing.T) {
+	asks := newOrderSide(ascending)
+	nodes := make(map[uint64]*OrderNode)
+	for _, o := range []struct {
+		id    uint64
+		price string
+		qty   string
+	}{{1, "100", "1"}, {2, "100", "2"}, {3, "100", "3"}, {4, "101", "4"}} {
+		nodes[o.id] = asks.add(newTestOrder(o.id, models.Sell, models.Limit, o.price, o.qty))
+	}
+	queue := func(price string) []uint64 {
+		level := asks.levels.find(models.MustParseDecimal(price))
+		if level == nil {
+			return nil
+		}
+		var ids []uint64
+		for node := level.head; node != nil; node = node.next {
+			ids = append(ids, node.order.ID)
+		}
+		return ids
+	}
+
+	if got := queue("100"); !slices.Equal(got, []uint64{1, 2, 3}) {
+		t.Fatalf("queue at 100 is %v, want arrival order [1 2 3]", got)
+	}
+	level := nodes[1].level
+	if level.count != 3 || level.volume.String() != "6" || level.tail != nodes[3] {
+		t.Fatalf("level holds %d orders, volume %s", level.count, level.volume)
+	}
+
+	asks.removeNode(nodes[2])
+	if got := queue("100"); !slices.Equal(got, []uint64{1, 3}) || level.volume.String() != "4" {
+		t.Fatalf("queue at 100 is %v with volume %s after removing order 2", got, level.volume)
+	}
+	asks.removeNode(nodes[1])
+	asks.removeNode(nodes[3])
+	if asks.levels.find(models.MustParseDecimal("100")) != nil || asks.levels.length != 1 {
+		t.Fatalf("empty level still listed; %d levels", asks.levels.length)
+	}
+	if best, _ := asks.bestOrder(); best.ID != 4 {
+		t.Fatalf("best ask is order %d after its level emptied, want 4", best.ID)
+	}
+
+	asks.removeNode(nodes[4])
+	if asks.best() != nil || asks.levels.length != 0 || asks.levels.height != 1 {
+		t.Fatalf("emptied side still has levels: length %d, height %d", asks.levels.length, asks.levels.height)
+	}
+	asks.levels.remove(models.MustParseDecimal("100")) // Removing a missing level is a no-op
+}
@@ -1,7 +1,8 @@
 package engine
 
 import (
-	"encoding/json"
+	"errors"
+	"fmt"
 	"maps"
 	"sync/atomic"
 	"time"
@@ -15,11 +16,19 @@ type SnapshotManager struct {
 	orderBooks unsafe.Pointer // *map[string]*OrderBook (atomic)
 	snapshots  unsafe.Pointer // *map[string]*OrderBookSnapshot (atomic)
 	interval   time.Duration
+	depth      int32 // Price levels kept per side
 	shutdown   chan struct{}
-	sequenceID uint64 // sequence ID for snapshots (atomic)
 }
 
-// OrderBookSnapshot represents a point-in-time view of the order book
+// Snapshot errors
+var (
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+	ErrInvalidSnapshot  = errors.New("invalid snapshot")
+)
+
+// OrderBookSnapshot represents a point-in-time view of the order book. Depth views
+// only aggregate price levels; full snapshots taken by OrderBook.Snapshot also hold
+// everything needed to restore the book.
 type OrderBookSnapshot struct {
 	Instrument string        `json:"instrument"`
 	Sequence   uint64        `json:"sequence"`
@@ -27,33 +36,46 @@ type OrderBookSnapshot struct {
 	Bids       []PriceLevel  `json:"bids"`
 	Asks       []PriceLevel  `json:"asks"`
 	Stats      SnapshotStats `json:"stats"`
+
+	// Full snapshots only
+	Definition  *models.Instrument `json:"definition,omitempty"`
+	BidOrders   []*models.Order    `json:"bid_orders,omitempty"`   // Resting bids, best price first, in queue order within a price
+	AskOrders   []*models.Order    `json:"ask_orders,omitempty"`   // Resting asks, likewise
+	TradeID     uint64             `json:"trade_id,omitempty"`     // Last trade ID handed out when taken
+	ExecutionID uint64             `json:"execution_id,omitempty"` // Last execution ID handed out when taken
+}
+
+// Full reports whether the snapshot can restore a book
+func (s *OrderBookSnapshot) Full() bool {
+	return s.Definition != nil
 }
 
 // PriceLevel represents a price level in the order book
 type PriceLevel struct {
-	Price    float64 `json:"price"`
-	Quantity float64 `json:"quantity"`
-	Orders   int     `json:"order_count"`
+	Price    models.Decimal `json:"price"`
+	Quantity models.Decimal `json:"quantity"`
+	Orders   int            `json:"order_count"`
 }
 
 // SnapshotStats contains order book statistics
 type SnapshotStats struct {
-	TotalBidQuantity float64 `json:"total_bid_quantity"`
-	TotalAskQuantity float64 `json:"total_ask_quantity"`
-	BidOrders        int     `json:"bid_orders"`
-	AskOrders        int     `json:"ask_orders"`
-	Spread           float64 `json:"spread"`    // Difference between the highest bid and lowest ask
-	MidPrice         float64 `json:"mid_price"` // Average of the highest bid and lowest ask
+	TotalBidQuantity models.Decimal `json:"total_bid_quantity"`
+	TotalAskQuantity models.Decimal `json:"total_ask_quantity"`
+	BidOrders        int            `json:"bid_orders"`
+	AskOrders        int            `json:"ask_orders"`
+	Spread           models.Decimal `json:"spread"`    // Difference between the highest bid and lowest ask
+	MidPrice         models.Decimal `json:"mid_price"` // Average of the highest bid and lowest ask
 }
 
-// SnapshotStorage defines the interface for snapshot persistence
+// SnapshotStorage defines the interface for snapshot persistence.
+// LoadSnapshot returns the latest snapshot saved for the instrument, or ErrSnapshotNotFound.
 type SnapshotStorage interface {
 	SaveSnapshot(snapshot *OrderBookSnapshot) error
 	LoadSnapshot(instrument string) (*OrderBookSnapshot, error)
 }
 
-// NewSnapshotManager creates a new snapshot manager
-func NewSnapshotManager(interval time.Duration) *SnapshotManager {
+// NewSnapshotManager creates a manager that snapshots depth price levels a side every interval
+func NewSnapshotManager(interval time.Duration, depth int) *SnapshotManager {
 	initialBooks := make(map[string]*OrderBook)
 	initialSnapshots := make(map[string]*OrderBookSnapshot)
 
@@ -61,6 +83,7 @@ func NewSnapshotManager(interval time.Duration) *SnapshotManager {
 		orderBooks: unsafe.Pointer(&initialBooks),
 		snapshots:  unsafe.Pointer(&initialSnapshots),
 		interval:   interval,
+		depth:      int32(max(depth, 1)),
 		shutdown:   make(chan struct{}),
 	}
 }
@@ -83,6 +106,43 @@ func (sm *SnapshotManager) RegisterOrderBook(instrument string, book *OrderBook)
 	}
 }
 
+// UnregisterOrderBook stops snapshotting the book, unless another has replaced it,
+// and drops its last snapshot
+func (sm *SnapshotManager) UnregisterOrderBook(instrument string, book *OrderBook) {
+	for {
+		oldPtr := atomic.LoadPointer(&sm.orderBooks)
+		oldBooks := *(*map[string]*OrderBook)(oldPtr)
+		if oldBooks[instrument] != book {
+			return
+		}
+
+		newBooks := maps.Clone(oldBooks)
+		delete(newBooks, instrument)
+		if atomic.CompareAndSwapPointer(&sm.orderBooks, oldPtr, unsafe.Pointer(&newBooks)) {
+			break
+		}
+	}
+
+	for {
+		oldPtr := atomic.LoadPointer(&sm.snapshots)
+		oldSnapshots := *(*map[string]*OrderBookSnapshot)(oldPtr)
+		if _, exists := oldSnapshots[instrument]; !exists {
+			return
+		}
+
+		newSnapshots := maps.Clone(oldSnapshots)
+		delete(newSnapshots, instrument)
+		if atomic.CompareAndSwapPointer(&sm.snapshots, oldPtr, unsafe.Pointer(&newSnapshots)) {
+			return
+		}
+	}
+}
+
+// Depth returns the number of price levels a side each snapshot keeps
+func (sm *SnapshotManager) Depth() int {
+	return int(sm.depth)
+}
+
 // Start begins periodic snapshotting
 func (sm *SnapshotManager) Start() {
 	go sm.snapshotLoop()
@@ -111,7 +171,7 @@ func (sm *SnapshotManager) snapshotLoop() {
 // TakeSnapshots creates snapshots for all registered order books
 func (sm *SnapshotManager) TakeSnapshots() {
 	booksPtr := atomic.LoadPointer(&sm.orderBooks)
-	books := *(*map[string]OrderBook)(booksPtr)
+	books := *(*map[string]*OrderBook)(booksPtr)
 
 	newSnapshots := make(map[string]*OrderBookSnapshot, len(books))
 
@@ -124,40 +184,43 @@ func (sm *SnapshotManager) TakeSnapshots() {
 }
 
 // takeSnapshot creates a snapshot for a single order book
-func (sm *SnapshotManager) takeSnapshot(instrument string, book OrderBook) *OrderBookSnapshot {
-	depth := book.GetMarketDepth(100) // Top 100 levels
+func (sm *SnapshotManager) takeSnapshot(instrument string, book *OrderBook) *OrderBookSnapshot {
+	depth := book.GetMarketDepth(sm.depth)
 
 	stats := sm.calculateStats(depth)
 
 	return &OrderBookSnapshot{
 		Instrument: instrument,
-		Sequence:   atomic.AddUint64(&sm.sequenceID, 1),
-		Timestamp:  time.Now().UnixNano(),
+		Sequence:   depth.Sequence,
+		Timestamp:  depth.Timestamp,
 		Bids:       depth.Bids,
 		Asks:       depth.Asks,
 		Stats:      stats,
 	}
 }
 
+// half is 0.5, used to take the mid price exactly
+var half = models.NewDecimal(5, 1)
+
 // calculateStats calculates order book statistics
 func (sm *SnapshotManager) calculateStats(depth *OrderBookSnapshot) SnapshotStats {
 	var stats SnapshotStats
 
 	for _, bid := range depth.Bids {
-		stats.TotalBidQuantity += bid.Quantity
+		stats.TotalBidQuantity = stats.TotalBidQuantity.Add(bid.Quantity)
 		stats.BidOrders += bid.Orders
 	}
 
 	for _, ask := range depth.Asks {
-		stats.TotalAskQuantity += ask.Quantity
+		stats.TotalAskQuantity = stats.TotalAskQuantity.Add(ask.Quantity)
 		stats.AskOrders += ask.Orders
 	}
 
 	if len(depth.Bids) > 0 && len(depth.Asks) > 0 {
 		bestBid := depth.Bids[0].Price
 		bestAsk := depth.Asks[0].Price
-		stats.Spread = bestAsk - bestBid
-		stats.MidPrice = (bestBid + bestAsk) / 2
+		stats.Spread = bestAsk.Sub(bestBid)
+		stats.MidPrice = bestBid.Add(bestAsk).Mul(half)
 	}
 
 	return stats
@@ -218,16 +281,6 @@ func copyPriceLevels(levels []PriceLevel) []PriceLevel {
 	return copy
 }
 
-// MarshalBinary serializes snapshot to binary format
-func (s *OrderBookSnapshot) MarshalBinary() ([]byte, error) {
-	return json.Marshal(s)
-}
-
-// UnmarshalBinary deserializes snapshot from binary format
-func (s *OrderBookSnapshot) UnmarshalBinary(data []byte) error {
-	return json.Unmarshal(data, s)
-}
-
 // GetPriceLevels returns price levels for a specific side
 func (s *OrderBookSnapshot) GetPriceLevels(side models.OrderSide) []PriceLevel {
 	if side == models.Buy {
@@ -237,10 +290,199 @@ func (s *OrderBookSnapshot) GetPriceLevels(side models.OrderSide) []PriceLevel {
 }
 
 // GetBestPrice returns the best bid or ask price
-func (s *OrderBookSnapshot) GetBestPrice(side models.OrderSide) (float64, bool) {
+func (s *OrderBookSnapshot) GetBestPrice(side models.OrderSide) (models.Decimal, bool) {
 	levels := s.GetPriceLevels(side)
 	if len(levels) == 0 {
-		return 0, false
+		return models.Decimal{}, false
 	}
 	return levels[0].Price, true
 }
+
+// Snapshot captures the book's full state: its definition, every resting order in
+// priority order and the sequence of the last command applied. The orders are copies.
+func (ob *OrderBook) Snapshot() *OrderBookSnapshot {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	definition := *ob.instrument
+	snapshot := &OrderBookSnapshot{
+		Instrument:  definition.Symbol,
+		Sequence:    ob.sequence,
+		Timestamp:   time.Now().UnixNano(),
+		Definition:  &definition,
+		TradeID:     atomic.LoadUint64(&tradeIDCounter),
+		ExecutionID: atomic.LoadUint64(&executionCounter),
+	}
+	snapshot.Bids, snapshot.BidOrders = snapshotSide(ob.bids)
+	snapshot.Asks, snapshot.AskOrders = snapshotSide(ob.asks)
+	for _, bid := range snapshot.Bids {
+		snapshot.Stats.TotalBidQuantity = snapshot.Stats.TotalBidQuantity.Add(bid.Quantity)
+	}
+	for _, ask := range snapshot.Asks {
+		snapshot.Stats.TotalAskQuantity = snapshot.Stats.TotalAskQuantity.Add(ask.Quantity)
+	}
+	snapshot.Stats.BidOrders = len(snapshot.BidOrders)
+	snapshot.Stats.AskOrders = len(snapshot.AskOrders)
+	if len(snapshot.Bids) > 0 && len(snapshot.Asks) > 0 {
+		bestBid, bestAsk := snapshot.Bids[0].Price, snapshot.Asks[0].Price
+		snapshot.Stats.Spread = bestAsk.Sub(bestBid)
+		snapshot.Stats.MidPrice = bestBid.Add(bestAsk).Mul(half)
+	}
+	return snapshot
+}
+
+// snapshotSide copies a side's levels and orders in priority order
+func snapshotSide(side *OrderSide) ([]PriceLevel, []*models.Order) {
+	var levels []PriceLevel
+	orders := make([]*models.Order, 0, side.counter)
+	for level := side.levels.best(); level != nil; level = level.forward[0] {
+		levels = append(levels, PriceLevel{Price: level.price, Quantity: level.volume, Orders: int(level.count)})
+		for node := level.head; node != nil; node = node.next {
+			order := *node.order
+			orders = append(orders, &order)
+		}
+	}
+	return levels, orders
+}
+
+// RestoreOrderBook rebuilds a book from a full snapshot. Orders keep their queue
+// positions, and the book resumes numbering after the snapshot's sequence, so the
+// journal tail can be replayed on top of it.
+func RestoreOrderBook(snapshot *OrderBookSnapshot, bufferSize int, wait WaitStrategy) (*OrderBook, error) {
+	if !snapshot.Full() {
+		return nil, fmt.Errorf("%w: %s has no book state", ErrInvalidSnapshot, snapshot.Instrument)
+	}
+	definition := *snapshot.Definition
+	if err := definition.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+
+	ob := NewOrderBook(&definition, bufferSize, wait)
+	restore := func(orders []*models.Order, side models.OrderSide) error {
+		for _, o := range orders {
+			if o.Side != side || o.Instrument != definition.Symbol || o.Remaining.Sign() <= 0 {
+				return fmt.Errorf("%w: %s order %d does not belong on this side", ErrInvalidSnapshot, definition.Symbol, o.ID)
+			}
+			if _, exists := ob.orders[o.ID]; exists {
+				return fmt.Errorf("%w: %s order %d appears twice", ErrInvalidSnapshot, definition.Symbol, o.ID)
+			}
+			order, state := *o, *o
+			if side == models.Buy {
+				ob.addBid(&order)
+			} else {
+				ob.addAsk(&order)
+			}
+			ob.index.update(&state)
+		}
+		return nil
+	}
+	if err := restore(snapshot.BidOrders, models.Buy); err != nil {
+		return nil, err
+	}
+	if err := restore(snapshot.AskOrders, models.Sell); err != nil {
+		return nil, err
+	}
+
+	ob.sequence, ob.nextSeq = snapshot.Sequence, snapshot.Sequence
+	restoreCounter(&tradeIDCounter, snapshot.TradeID)
+	restoreCounter(&executionCounter, snapshot.ExecutionID)
+	return ob, nil
+}
+
+// RestoreSnapshot replaces the instrument's book with one rebuilt from a full
+// snapshot. Like Replay it may only be used before Start.
+func (m *MatchingEngine) RestoreSnapshot(snapshot *OrderBookSnapshot) error {
+	m.lifecycleMu.Lock()
+	defer m.lifecycleMu.Unlock()
+	if m.running {
+		return ErrEngineRunning
+	}
+
+	book, err := RestoreOrderBook(snapshot, m.bookBufferSize, m.wait)
+	if err != nil {
+		return err
+	}
+	book.marketData = m.marketData
+	m.orderBooks.Store(book.instrument.Symbol, book)
+	if m.depthCache != nil {
+		m.depthCache.RegisterOrderBook(book.instrument.Symbol, book)
+	}
+	return nil
+}
+
+// SaveSnapshots writes a full snapshot of every listed book to storage
+func (m *MatchingEngine) SaveSnapshots(storage SnapshotStorage) error {
+	var errs []error
+	m.orderBooks.Range(func(_, value any) bool {
+		if err := storage.SaveSnapshot(value.(*OrderBook).Snapshot()); err != nil {
+			errs = append(errs, err)
+		}
+		return true
+	})
+	return errors.Join(errs...)
+}
+
+// Recover rebuilds the engine's state before Start. Every listed instrument is
+// restored from its latest snapshot, if storage has one, and then replay feeds the
+// journal through Replay, which skips what the snapshots already cover.
+// Instruments the journal lists are restored from their snapshots as they appear.
+//
+// Only the journal after its last JournalRun marker, and snapshots taken since,
+// describe the books: a run that did not recover started over from there. replay
+// is called twice, first to find that marker.
+func (m *MatchingEngine) Recover(storage SnapshotStorage, replay func(fn func(*JournalEntry) error) error) error {
+	var runs int
+	var runStart int64
+	err := replay(func(entry *JournalEntry) error {
+		if entry.Type == JournalRun {
+			runs, runStart = runs+1, entry.Timestamp
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	restore := func(symbol string) error {
+		snapshot, err := storage.LoadSnapshot(symbol)
+		if errors.Is(err, ErrSnapshotNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if snapshot.Timestamp < runStart {
+			return nil // Taken by a run the marker voids
+		}
+		return m.RestoreSnapshot(snapshot)
+	}
+
+	for _, instrument := range m.ListInstruments() {
+		if err := restore(instrument.Symbol); err != nil {
+			return err
+		}
+	}
+	err = replay(func(entry *JournalEntry) error {
+		if runs > 0 {
+			if entry.Type == JournalRun {
+				runs--
+			}
+			return nil // Before the last marker
+		}
+		listed := entry.Type == JournalInstrument && m.getOrderBook(entry.Instrument) == nil
+		if err := m.Replay(entry); err != nil {
+			return err
+		}
+		if listed {
+			return restore(entry.Instrument)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	m.lifecycleMu.Lock()
+	m.recovered = true
+	m.lifecycleMu.Unlock()
+	return nil
+}
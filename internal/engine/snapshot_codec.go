@@ -0,0 +1,455 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"time"
+
+	"github.com/aeromatch/internal/models"
+)
+
+// SnapshotFormat selects how snapshots are encoded for storage
+type SnapshotFormat uint8
+
+const (
+	SnapshotBinary SnapshotFormat = iota // Compact, checksummed; the default
+	SnapshotJSON                         // Readable, for debugging
+)
+
+func (f SnapshotFormat) String() string {
+	switch f {
+	case SnapshotBinary:
+		return "binary"
+	case SnapshotJSON:
+		return "json"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseSnapshotFormat accepts "binary" or "json"
+func ParseSnapshotFormat(s string) (SnapshotFormat, error) {
+	switch s {
+	case "binary":
+		return SnapshotBinary, nil
+	case "json":
+		return SnapshotJSON, nil
+	default:
+		return 0, fmt.Errorf("unknown snapshot format %q", s)
+	}
+}
+
+// Binary snapshot layout, little-endian:
+//
+//	header: magic "AMS" | version uint8 | body length uint32 | CRC-32C of body uint32
+//	body:   instrument, sequence, timestamp, trade ID, execution ID,
+//	        bid levels, ask levels, stats, [definition], bid orders, ask orders
+//
+// Integers are varints and strings are length-prefixed. A decimal is its scale byte
+// and zigzag units; prices hold their units as a delta from the previous price in
+// the same list, so levels a tick apart and orders at one level cost a byte or two.
+var snapshotMagic = []byte("AMS")
+
+const (
+	snapshotVersion    = 1
+	snapshotHeaderSize = 3 + 1 + 4 + 4
+)
+
+// Per-order flags marking optional fields
+const (
+	orderOtherInstrument = 1 << iota // Instrument differs from the snapshot's
+	orderHasTags
+	orderHasMargin
+	orderHasFills // Partially filled: FilledValue follows
+)
+
+var snapshotCRC = crc32.MakeTable(crc32.Castagnoli)
+
+// Encode serializes the snapshot in the given format
+func (s *OrderBookSnapshot) Encode(format SnapshotFormat) ([]byte, error) {
+	if format == SnapshotJSON {
+		return json.Marshal(s)
+	}
+	return s.AppendBinary(nil)
+}
+
+// MarshalBinary serializes snapshot to binary format
+func (s *OrderBookSnapshot) MarshalBinary() ([]byte, error) {
+	return s.AppendBinary(nil)
+}
+
+// AppendBinary appends the binary encoding to buf. It does not allocate when buf
+// has room, so a snapshot loop can reuse one buffer.
+func (s *OrderBookSnapshot) AppendBinary(buf []byte) ([]byte, error) {
+	start := len(buf)
+	buf = append(buf, snapshotMagic...)
+	buf = append(buf, snapshotVersion, 0, 0, 0, 0, 0, 0, 0, 0)
+	body := len(buf)
+
+	buf = appendString(buf, s.Instrument)
+	buf = binary.AppendUvarint(buf, s.Sequence)
+	buf = binary.AppendVarint(buf, s.Timestamp)
+	buf = binary.AppendUvarint(buf, s.TradeID)
+	buf = binary.AppendUvarint(buf, s.ExecutionID)
+	buf = appendLevels(buf, s.Bids)
+	buf = appendLevels(buf, s.Asks)
+
+	buf = appendDecimal(buf, s.Stats.TotalBidQuantity)
+	buf = appendDecimal(buf, s.Stats.TotalAskQuantity)
+	buf = binary.AppendUvarint(buf, uint64(s.Stats.BidOrders))
+	buf = binary.AppendUvarint(buf, uint64(s.Stats.AskOrders))
+	buf = appendDecimal(buf, s.Stats.Spread)
+	buf = appendDecimal(buf, s.Stats.MidPrice)
+
+	if d := s.Definition; d == nil {
+		buf = append(buf, 0)
+	} else {
+		buf = append(buf, 1)
+		buf = appendString(buf, d.Symbol)
+		buf = appendString(buf, d.BaseCurrency)
+		buf = appendString(buf, d.QuoteCurrency)
+		for _, v := range [...]models.Decimal{d.TickSize, d.LotSize, d.MinQuantity, d.MaxQuantity, d.MinNotional, d.MinPrice, d.MaxPrice} {
+			buf = appendDecimal(buf, v)
+		}
+		buf = append(buf, byte(d.Status))
+	}
+	buf = s.appendOrders(buf, s.BidOrders)
+	buf = s.appendOrders(buf, s.AskOrders)
+
+	if len(buf)-body > math.MaxUint32 {
+		return buf[:start], fmt.Errorf("%w: %s is too large to encode", ErrInvalidSnapshot, s.Instrument)
+	}
+	binary.LittleEndian.PutUint32(buf[body-8:], uint32(len(buf)-body))
+	binary.LittleEndian.PutUint32(buf[body-4:], crc32.Checksum(buf[body:], snapshotCRC))
+	return buf, nil
+}
+
+func (s *OrderBookSnapshot) appendOrders(buf []byte, orders []*models.Order) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(orders)))
+	var prev int64
+	for _, o := range orders {
+		var flags byte
+		if o.Instrument != s.Instrument {
+			flags |= orderOtherInstrument
+		}
+		if len(o.Tags) > 0 {
+			flags |= orderHasTags
+		}
+		if o.MarginParams != nil {
+			flags |= orderHasMargin
+		}
+		if !o.FilledValue.IsZero() {
+			flags |= orderHasFills
+		}
+
+		buf = append(buf, flags)
+		buf = binary.AppendUvarint(buf, o.ID)
+		buf = appendPrice(buf, o.Price, &prev)
+		buf = appendDecimal(buf, o.Quantity)
+		buf = appendDecimal(buf, o.Remaining)
+		buf = append(buf, byte(o.Side), byte(o.Type), byte(o.Status), byte(o.PostOnly))
+		buf = binary.AppendUvarint(buf, o.Sequence)
+		buf = appendTime(buf, o.Timestamp)
+		buf = appendTime(buf, o.LastUpdated)
+		buf = appendString(buf, o.Account)
+		buf = appendString(buf, o.ClientOID)
+		if flags&orderOtherInstrument != 0 {
+			buf = appendString(buf, o.Instrument)
+		}
+		if flags&orderHasTags != 0 {
+			buf = binary.AppendUvarint(buf, uint64(len(o.Tags)))
+			for k, v := range o.Tags {
+				buf = appendString(buf, k)
+				buf = appendString(buf, v)
+			}
+		}
+		if m := o.MarginParams; m != nil {
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(m.Leverage))
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(m.BorrowCost))
+			buf = appendDecimal(buf, m.Liquidation)
+			buf = appendBool(buf, m.IsIsolated)
+		}
+		if flags&orderHasFills != 0 {
+			buf = appendDecimal(buf, o.FilledValue)
+		}
+	}
+	return buf
+}
+
+func appendLevels(buf []byte, levels []PriceLevel) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(levels)))
+	var prev int64
+	for _, level := range levels {
+		buf = appendPrice(buf, level.Price, &prev)
+		buf = appendDecimal(buf, level.Quantity)
+		buf = binary.AppendUvarint(buf, uint64(level.Orders))
+	}
+	return buf
+}
+
+func appendDecimal(buf []byte, d models.Decimal) []byte {
+	buf = append(buf, d.Scale())
+	return binary.AppendVarint(buf, d.Units())
+}
+
+// appendPrice writes the price's units as a delta from the previous price
+func appendPrice(buf []byte, price models.Decimal, prev *int64) []byte {
+	buf = append(buf, price.Scale())
+	buf = binary.AppendVarint(buf, price.Units()-*prev)
+	*prev = price.Units()
+	return buf
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+func appendBool(buf []byte, b bool) []byte {
+	if b {
+		return append(buf, 1)
+	}
+	return append(buf, 0)
+}
+
+// appendTime writes nanoseconds since the epoch, or zero for the zero time
+func appendTime(buf []byte, t time.Time) []byte {
+	if t.IsZero() {
+		return binary.AppendVarint(buf, 0)
+	}
+	return binary.AppendVarint(buf, t.UnixNano())
+}
+
+// UnmarshalBinary deserializes a snapshot from the binary format, or from JSON
+func (s *OrderBookSnapshot) UnmarshalBinary(data []byte) error {
+	*s = OrderBookSnapshot{}
+	if !bytes.HasPrefix(data, snapshotMagic) {
+		if trimmed := bytes.TrimLeft(data, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '{' {
+			return json.Unmarshal(data, s)
+		}
+		return fmt.Errorf("%w: unknown encoding", ErrInvalidSnapshot)
+	}
+
+	if len(data) < snapshotHeaderSize {
+		return fmt.Errorf("%w: truncated header", ErrInvalidSnapshot)
+	}
+	if version := data[3]; version != snapshotVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, version)
+	}
+	size := binary.LittleEndian.Uint32(data[4:8])
+	body := data[snapshotHeaderSize:]
+	if uint64(len(body)) != uint64(size) {
+		return fmt.Errorf("%w: body is %d bytes, header says %d", ErrInvalidSnapshot, len(body), size)
+	}
+	if crc32.Checksum(body, snapshotCRC) != binary.LittleEndian.Uint32(data[8:12]) {
+		return fmt.Errorf("%w: checksum mismatch", ErrInvalidSnapshot)
+	}
+
+	r := &snapshotReader{data: body}
+	s.Instrument = r.string()
+	s.Sequence = r.uvarint()
+	s.Timestamp = r.varint()
+	s.TradeID = r.uvarint()
+	s.ExecutionID = r.uvarint()
+	s.Bids = r.levels()
+	s.Asks = r.levels()
+
+	s.Stats.TotalBidQuantity = r.decimal()
+	s.Stats.TotalAskQuantity = r.decimal()
+	s.Stats.BidOrders = int(r.uvarint())
+	s.Stats.AskOrders = int(r.uvarint())
+	s.Stats.Spread = r.decimal()
+	s.Stats.MidPrice = r.decimal()
+
+	if r.byte() == 1 {
+		d := &models.Instrument{
+			Symbol:        r.string(),
+			BaseCurrency:  r.string(),
+			QuoteCurrency: r.string(),
+		}
+		for _, v := range [...]*models.Decimal{&d.TickSize, &d.LotSize, &d.MinQuantity, &d.MaxQuantity, &d.MinNotional, &d.MinPrice, &d.MaxPrice} {
+			*v = r.decimal()
+		}
+		d.Status = models.TradingStatus(r.byte())
+		s.Definition = d
+	}
+	s.BidOrders = r.orders(s.Instrument)
+	s.AskOrders = r.orders(s.Instrument)
+
+	if r.err == nil && r.pos != len(r.data) {
+		r.fail("trailing bytes")
+	}
+	return r.err
+}
+
+// snapshotReader decodes a checksummed body; the first error sticks and later
+// reads return zero values
+type snapshotReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (r *snapshotReader) fail(what string) {
+	if r.err == nil {
+		r.err = fmt.Errorf("%w: %s at offset %d", ErrInvalidSnapshot, what, r.pos)
+	}
+	r.pos = len(r.data)
+}
+
+func (r *snapshotReader) byte() byte {
+	if r.pos >= len(r.data) {
+		r.fail("truncated body")
+		return 0
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *snapshotReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		r.fail("bad varint")
+		return 0
+	}
+	r.pos += n
+	return v
+}
+
+func (r *snapshotReader) varint() int64 {
+	v, n := binary.Varint(r.data[r.pos:])
+	if n <= 0 {
+		r.fail("bad varint")
+		return 0
+	}
+	r.pos += n
+	return v
+}
+
+// count reads a length and checks that at least min bytes per element remain, so
+// a damaged count cannot trigger a huge allocation
+func (r *snapshotReader) count(min int) int {
+	n := r.uvarint()
+	if n > uint64(len(r.data)-r.pos)/uint64(min) {
+		r.fail("bad count")
+		return 0
+	}
+	return int(n)
+}
+
+func (r *snapshotReader) string() string {
+	n := r.count(1)
+	s := string(r.data[r.pos : r.pos+n])
+	r.pos += n
+	return s
+}
+
+func (r *snapshotReader) decimal() models.Decimal {
+	scale := r.scale()
+	return models.NewDecimal(r.varint(), scale)
+}
+
+func (r *snapshotReader) price(prev *int64) models.Decimal {
+	scale := r.scale()
+	*prev += r.varint()
+	return models.NewDecimal(*prev, scale)
+}
+
+// scale reads a decimal's scale; arithmetic on one beyond MaxScale would panic
+func (r *snapshotReader) scale() uint8 {
+	scale := r.byte()
+	if scale > models.MaxScale {
+		r.fail("decimal scale out of range")
+		return 0
+	}
+	return scale
+}
+
+func (r *snapshotReader) time() time.Time {
+	if nanos := r.varint(); nanos != 0 {
+		return time.Unix(0, nanos)
+	}
+	return time.Time{}
+}
+
+func (r *snapshotReader) levels() []PriceLevel {
+	n := r.count(5)
+	if n == 0 {
+		return nil
+	}
+	levels := make([]PriceLevel, n)
+	var prev int64
+	for i := range levels {
+		levels[i].Price = r.price(&prev)
+		levels[i].Quantity = r.decimal()
+		levels[i].Orders = int(r.uvarint())
+	}
+	return levels
+}
+
+func (r *snapshotReader) orders(instrument string) []*models.Order {
+	n := r.count(17)
+	if n == 0 {
+		return nil
+	}
+	orders := make([]*models.Order, n)
+	var prev int64
+	for i := range orders {
+		flags := r.byte()
+		o := &models.Order{
+			ID:         r.uvarint(),
+			Price:      r.price(&prev),
+			Quantity:   r.decimal(),
+			Remaining:  r.decimal(),
+			Side:       models.OrderSide(r.byte()),
+			Type:       models.OrderType(r.byte()),
+			Status:     models.OrderStatus(r.byte()),
+			PostOnly:   models.PostOnlyMode(r.byte()),
+			Sequence:   r.uvarint(),
+			Timestamp:  r.time(),
+			Instrument: instrument,
+		}
+		o.LastUpdated = r.time()
+		o.Account = r.string()
+		o.ClientOID = r.string()
+		if flags&orderOtherInstrument != 0 {
+			o.Instrument = r.string()
+		}
+		if flags&orderHasTags != 0 {
+			tags := r.count(2)
+			o.Tags = make(map[string]string, tags)
+			for range tags {
+				k := r.string()
+				o.Tags[k] = r.string()
+			}
+		}
+		if flags&orderHasMargin != 0 {
+			o.MarginParams = &models.MarginParams{
+				Leverage:   math.Float64frombits(r.fixed64()),
+				BorrowCost: math.Float64frombits(r.fixed64()),
+			}
+			o.MarginParams.Liquidation = r.decimal()
+			o.MarginParams.IsIsolated = r.byte() == 1
+		}
+		if flags&orderHasFills != 0 {
+			o.FilledValue = r.decimal()
+		}
+		orders[i] = o
+	}
+	return orders
+}
+
+func (r *snapshotReader) fixed64() uint64 {
+	if len(r.data)-r.pos < 8 {
+		r.fail("truncated body")
+		return 0
+	}
+	v := binary.LittleEndian.Uint64(r.data[r.pos:])
+	r.pos += 8
+	return v
+}
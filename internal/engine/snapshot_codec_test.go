@@ -0,0 +1,125 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aeromatch/internal/models"
+)
+
+// deepBook builds a book with levels levels a side, each holding perLevel orders
+func deepBook(levels, perLevel int) *OrderBook {
+	ob := newTestBook("0.01", "0.001")
+	id := uint64(1)
+	for i := range levels {
+		for range perLevel {
+			ask := newTestOrder(id, models.Sell, models.Limit, fmt.Sprintf("%d.%02d", 30001+i/100, i%100), "1.25")
+			bid := newTestOrder(id+1, models.Buy, models.Limit, fmt.Sprintf("%d.%02d", 29999-i/100, 99-i%100), "0.5")
+			ask.Timestamp, bid.Timestamp = time.Now(), time.Now()
+			ob.AddAsk(ask)
+			ob.AddBid(bid)
+			id += 2
+		}
+	}
+	ob.sequence = id
+	return ob
+}
+
+func TestSnapshotBinaryRoundTrip(t *testing.T) {
+	ob := deepBook(100, 3)
+	ob.AddBid(&models.Order{
+		ID:           9999,
+		Price:        models.MustParseDecimal("29000"),
+		Quantity:     models.MustParseDecimal("2"),
+		Remaining:    models.MustParseDecimal("1.5"),
+		Side:         models.Buy,
+		Type:         models.PostOnly,
+		PostOnly:     models.PostOnlySlide,
+		Instrument:   "BTC-USD",
+		Account:      "acct-7",
+		ClientOID:    "client-1",
+		Status:       models.Partial,
+		Sequence:     42,
+		FilledValue:  models.MustParseDecimal("14512.25"),
+		Tags:         map[string]string{"desk": "a", "strategy": "mm"},
+		MarginParams: &models.MarginParams{Leverage: 5, IsIsolated: true, Liquidation: models.MustParseDecimal("25000.5"), BorrowCost: 0.01},
+	})
+	snapshot := ob.Snapshot()
+	snapshot.Definition.MaxPrice = models.MustParseDecimal("100000")
+
+	data, err := snapshot.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonData, err := snapshot.Encode(SnapshotJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data)*3 > len(jsonData) {
+		t.Fatalf("binary snapshot is %d bytes against %d for JSON", len(data), len(jsonData))
+	}
+
+	for name, encoded := range map[string][]byte{"binary": data, "json": jsonData} {
+		var decoded OrderBookSnapshot
+		if err := decoded.UnmarshalBinary(encoded); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got, _ := json.Marshal(&decoded); !bytes.Equal(got, jsonData) {
+			t.Fatalf("%s decoded to\n%s\nwant\n%s", name, got, jsonData)
+		}
+	}
+}
+
+func TestSnapshotBinaryRejectsDamage(t *testing.T) {
+	data, err := deepBook(5, 2).Snapshot().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flipped := bytes.Clone(data)
+	flipped[len(flipped)/2] ^= 0x10
+	newer := bytes.Clone(data)
+	newer[3] = snapshotVersion + 1
+	// Checksummed like any other, but carrying a scale no Decimal can hold
+	scaled := deepBook(1, 1).Snapshot()
+	scaled.Stats.Spread = models.NewDecimal(1, models.MaxScale+1)
+	oversized, err := scaled.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, damaged := range map[string][]byte{
+		"truncated": data[:len(data)-1],
+		"flipped":   flipped,
+		"version":   newer,
+		"garbage":   []byte("not a snapshot"),
+		"scale":     oversized,
+	} {
+		var decoded OrderBookSnapshot
+		if err := decoded.UnmarshalBinary(damaged); !errors.Is(err, ErrInvalidSnapshot) {
+			t.Errorf("%s: got %v", name, err)
+		}
+	}
+}
+
+func TestSnapshotAppendBinaryReusesBuffer(t *testing.T) {
+	snapshot := deepBook(100, 2).Snapshot()
+	buf, err := snapshot.AppendBinary(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = snapshot.AppendBinary(buf[:0])
+	})
+	if allocs != 0 {
+		t.Fatalf("encoding into a reused buffer allocated %v times", allocs)
+	}
+
+	prefixed, err := snapshot.AppendBinary([]byte("prefix"))
+	if err != nil || !bytes.Equal(prefixed[6:], buf) {
+		t.Fatal("encoding after existing bytes changed the output")
+	}
+}
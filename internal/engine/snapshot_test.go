@@ -0,0 +1,259 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aeromatch/internal/models"
+)
+
+// snapshotMap stores encoded snapshots by instrument
+type snapshotMap map[string][]byte
+
+func (s snapshotMap) SaveSnapshot(snapshot *OrderBookSnapshot) error {
+	data, err := snapshot.MarshalBinary()
+	s[snapshot.Instrument] = data
+	return err
+}
+
+func (s snapshotMap) LoadSnapshot(instrument string) (*OrderBookSnapshot, error) {
+	data, ok := s[instrument]
+	if !ok {
+		return nil, ErrSnapshotNotFound
+	}
+	snapshot := &OrderBookSnapshot{}
+	return snapshot, snapshot.UnmarshalBinary(data)
+}
+
+func TestSnapshotKeepsQueuePositions(t *testing.T) {
+	ob := newTestBook("0.01", "1")
+	ob.AddAsk(newTestOrder(1, models.Sell, models.Limit, "101", "1"))
+	ob.AddAsk(newTestOrder(2, models.Sell, models.Limit, "100", "2"))
+	ob.AddAsk(newTestOrder(3, models.Sell, models.Limit, "100", "3"))
+	ob.AddBid(newTestOrder(4, models.Buy, models.Limit, "99", "4"))
+	ob.sequence = 7
+
+	snapshot := ob.Snapshot()
+	if snapshot.Sequence != 7 || len(snapshot.Asks) != 2 || snapshot.Asks[0].Orders != 2 {
+		t.Fatalf("snapshot sequence %d with ask levels %+v", snapshot.Sequence, snapshot.Asks)
+	}
+	restored, err := RestoreOrderBook(snapshot, 64, WaitPark)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := describeBook(restored), describeBook(ob); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("restored as %v, want %v", got, want)
+	}
+
+	// Order 2 is still ahead of order 3 at 100
+	restored.ProcessBuyOrder(newTestOrder(5, models.Buy, models.Limit, "100", "2"))
+	if trades := drainTrades(restored); len(trades) != 1 || trades[0].MakerOrderID != 2 {
+		t.Fatalf("got trades %+v, want one against order 2", trades)
+	}
+	if _, resting := ob.orders[2]; !resting {
+		t.Fatal("restoring shared orders with the original book")
+	}
+
+	snapshot.AskOrders = append(snapshot.AskOrders, snapshot.AskOrders[0])
+	if _, err := RestoreOrderBook(snapshot, 64, WaitPark); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Fatalf("duplicate order: got %v", err)
+	}
+	if _, err := RestoreOrderBook(&OrderBookSnapshot{Instrument: "BTC-USD"}, 64, WaitPark); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Fatalf("depth-only snapshot: got %v", err)
+	}
+}
+
+func TestRecoverFromSnapshotAndJournalTail(t *testing.T) {
+	atomic.StoreUint64(&tradeIDCounter, 0)
+	atomic.StoreUint64(&executionCounter, 0)
+
+	journal := &encodedJournal{}
+	m := NewMatchingEngine(64, 64, WaitPark)
+	if _, err := m.AddInstrument(newTestInstrument("BTC-USD")); err != nil {
+		t.Fatal(err)
+	}
+	m.SetJournal(journal)
+	m.Start()
+	if _, err := m.AddInstrument(newTestInstrument("ETH-USD")); err != nil {
+		t.Fatal(err)
+	}
+
+	submit := func(id uint64, symbol string, side models.OrderSide, price, qty string) {
+		order := newTestOrder(id, side, models.Limit, price, qty)
+		order.Instrument = symbol
+		if err := m.SubmitOrder(order); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, price := range []string{"101", "100", "100"} {
+		submit(uint64(i+1), "BTC-USD", models.Sell, price, "2")
+		submit(uint64(i+11), "ETH-USD", models.Buy, price, "3")
+	}
+	submit(4, "BTC-USD", models.Buy, "100", "1")
+	barrier(t, m, "BTC-USD")
+	barrier(t, m, "ETH-USD")
+
+	snapshots := snapshotMap{}
+	if err := m.SaveSnapshots(snapshots); err != nil {
+		t.Fatal(err)
+	}
+
+	// The tail: matching, a cancel, an amend and a halt after the snapshots
+	submit(5, "BTC-USD", models.Buy, "101", "4")
+	submit(14, "ETH-USD", models.Sell, "100", "4")
+	if _, err := m.CancelOrder("BTC-USD", "", 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.AmendOrder("ETH-USD", "", 13, models.MustParseDecimal("100"), models.MustParseDecimal("5")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.HaltInstrument("ETH-USD"); err != nil {
+		t.Fatal(err)
+	}
+	barrier(t, m, "BTC-USD")
+	barrier(t, m, "ETH-USD")
+
+	trades := atomic.LoadUint64(&tradeIDCounter)
+	executions := atomic.LoadUint64(&executionCounter)
+	for deadline := time.Now().Add(time.Second); journal.trades() < int(trades); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("journaled %d of %d trades", journal.trades(), trades)
+		}
+	}
+	want := map[string][]string{
+		"BTC-USD": describeBook(m.getOrderBook("BTC-USD")),
+		"ETH-USD": describeBook(m.getOrderBook("ETH-USD")),
+	}
+	m.Stop()
+
+	atomic.StoreUint64(&tradeIDCounter, 0)
+	atomic.StoreUint64(&executionCounter, 0)
+	recovered := NewMatchingEngine(64, 64, WaitPark)
+	if _, err := recovered.AddInstrument(newTestInstrument("BTC-USD")); err != nil {
+		t.Fatal(err)
+	}
+	var replayed int
+	err := recovered.Recover(snapshots, func(fn func(*JournalEntry) error) error {
+		journal.mu.Lock()
+		defer journal.mu.Unlock()
+		replayed = 0 // Recover reads the journal twice; count the last pass
+		for _, payload := range journal.entries {
+			var entry JournalEntry
+			if err := json.Unmarshal(payload, &entry); err != nil {
+				return err
+			}
+			if book := recovered.getOrderBook(entry.Instrument); entry.Type != JournalTrade && book != nil && entry.Sequence > book.nextSeq {
+				replayed++
+			}
+			if err := fn(&entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if replayed != 7 {
+		t.Fatalf("replayed %d journal entries on top of the snapshots, want the 5 commands and 2 barriers in the tail", replayed)
+	}
+	for symbol, orders := range want {
+		got := describeBook(recovered.getOrderBook(symbol))
+		if fmt.Sprint(got) != fmt.Sprint(orders) {
+			t.Fatalf("%s recovered as\n%v\nwant\n%v", symbol, got, orders)
+		}
+	}
+	if got := atomic.LoadUint64(&tradeIDCounter); got != trades {
+		t.Fatalf("trade ID counter %d, want %d", got, trades)
+	}
+	if got := atomic.LoadUint64(&executionCounter); got != executions {
+		t.Fatalf("execution counter %d, want %d", got, executions)
+	}
+}
+
+func TestMarketDepthAggregatesLevels(t *testing.T) {
+	ob := newTestBook("0.01", "1")
+	ob.AddAsk(newTestOrder(1, models.Sell, models.Limit, "101", "1"))
+	ob.AddAsk(newTestOrder(2, models.Sell, models.Limit, "100", "2"))
+	ob.AddAsk(newTestOrder(3, models.Sell, models.Limit, "100", "3"))
+	ob.AddAsk(newTestOrder(4, models.Sell, models.Limit, "102", "1"))
+	ob.AddBid(newTestOrder(5, models.Buy, models.Limit, "99", "4"))
+	ob.sequence = 9
+
+	depth := ob.GetMarketDepth(2)
+	if depth.Sequence != 9 || len(depth.Bids) != 1 || len(depth.Asks) != 2 {
+		t.Fatalf("got sequence %d with %d bid and %d ask levels", depth.Sequence, len(depth.Bids), len(depth.Asks))
+	}
+	best := depth.Asks[0]
+	if !best.Price.Equal(models.MustParseDecimal("100")) || !best.Quantity.Equal(models.MustParseDecimal("5")) || best.Orders != 2 {
+		t.Fatalf("best ask level %+v, want 5 over 2 orders at 100", best)
+	}
+	if !depth.Asks[1].Price.Equal(models.MustParseDecimal("101")) {
+		t.Fatalf("second ask level at %s, want 101", depth.Asks[1].Price)
+	}
+	if all := ob.GetMarketDepth(0); len(all.Asks) != 3 {
+		t.Fatalf("unlimited depth returned %d ask levels, want 3", len(all.Asks))
+	}
+}
+
+func TestGetOrderBookCachedOrFresh(t *testing.T) {
+	m := NewMatchingEngine(64, 64, WaitPark)
+	if _, err := m.AddInstrument(newTestInstrument("BTC-USD")); err != nil {
+		t.Fatal(err)
+	}
+	manager := NewSnapshotManager(time.Hour, 2)
+	m.SetSnapshotManager(manager)
+	m.Start()
+	defer m.Stop()
+
+	for i, price := range []string{"100", "101", "102"} {
+		order := newTestOrder(uint64(i+1), models.Sell, models.Limit, price, "1")
+		order.Instrument = "BTC-USD"
+		if err := m.SubmitOrder(order); err != nil {
+			t.Fatal(err)
+		}
+	}
+	barrier(t, m, "BTC-USD")
+	manager.TakeSnapshots()
+
+	order := newTestOrder(4, models.Sell, models.Limit, "99", "1")
+	order.Instrument = "BTC-USD"
+	if err := m.SubmitOrder(order); err != nil {
+		t.Fatal(err)
+	}
+	barrier(t, m, "BTC-USD")
+
+	cached, err := m.GetOrderBook("BTC-USD", 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cached.Asks) != 1 || !cached.Asks[0].Price.Equal(models.MustParseDecimal("100")) {
+		t.Fatalf("cached asks %+v, want the single level at 100", cached.Asks)
+	}
+	fresh, err := m.GetOrderBook("BTC-USD", 1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fresh.Asks) != 1 || !fresh.Asks[0].Price.Equal(models.MustParseDecimal("99")) || fresh.Sequence <= cached.Sequence {
+		t.Fatalf("fresh asks %+v at sequence %d, cached at %d", fresh.Asks, fresh.Sequence, cached.Sequence)
+	}
+	// Deeper than the manager keeps, so read from the book
+	if deep, _ := m.GetOrderBook("BTC-USD", 10, false); len(deep.Asks) != 4 {
+		t.Fatalf("deep read returned %d ask levels, want 4", len(deep.Asks))
+	}
+
+	if _, err := m.DelistInstrument("BTC-USD"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := manager.GetSnapshot("BTC-USD"); ok {
+		t.Fatal("snapshot kept after delist")
+	}
+	if _, err := m.GetOrderBook("BTC-USD", 1, false); !errors.Is(err, ErrUnknownInstrument) {
+		t.Fatalf("delisted instrument: got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package engine
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aeromatch/internal/models"
+)
+
+// ErrCandleInterval is returned for candles at an interval that is not built
+var ErrCandleInterval = errors.New("unsupported candle interval")
+
+// CandleIntervals are the intervals candles are built at
+var CandleIntervals = []time.Duration{time.Second, time.Minute, 5 * time.Minute, time.Hour}
+
+const (
+	recentTradesKept = 1000 // Trades a book keeps for GetRecentTrades
+	candlesKept      = 1000 // Candles a book keeps per interval
+)
+
+// ring keeps the last len(items) values pushed to it
+type ring[T any] struct {
+	items []T
+	next  int // Where the next value goes
+	full  bool
+}
+
+func newRing[T any](size int) *ring[T] {
+	return &ring[T]{items: make([]T, size)}
+}
+
+func (r *ring[T]) push(v T) {
+	r.items[r.next] = v
+	r.next = (r.next + 1) % len(r.items)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+func (r *ring[T]) len() int {
+	if r.full {
+		return len(r.items)
+	}
+	return r.next
+}
+
+// at returns the i-th oldest value
+func (r *ring[T]) at(i int) T {
+	if r.full {
+		i = (r.next + i) % len(r.items)
+	}
+	return r.items[i]
+}
+
+// last returns a pointer to the newest value, or nil if the ring is empty
+func (r *ring[T]) last() *T {
+	if r.len() == 0 {
+		return nil
+	}
+	return &r.items[(r.next+len(r.items)-1)%len(r.items)]
+}
+
+// tradeHistory keeps a book's recent trades and the candles built from its trades.
+// It is fed from the book's trade output, so it has its own lock rather than the book's.
+type tradeHistory struct {
+	mu      sync.RWMutex
+	trades  *ring[*models.Trade]
+	candles []*ring[models.Candle] // Per CandleIntervals entry
+}
+
+func newTradeHistory() *tradeHistory {
+	h := &tradeHistory{
+		trades:  newRing[*models.Trade](recentTradesKept),
+		candles: make([]*ring[models.Candle], len(CandleIntervals)),
+	}
+	for i := range h.candles {
+		h.candles[i] = newRing[models.Candle](candlesKept)
+	}
+	return h
+}
+
+// record adds a trade and returns the candles it changed, one per interval.
+// A trade stamped before the current candle, after the wall clock stepped back,
+// is folded into the current candle so candles never go back in time.
+func (h *tradeHistory) record(trade *models.Trade) []models.Candle {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.trades.push(trade)
+	changed := make([]models.Candle, len(CandleIntervals))
+	for i, interval := range CandleIntervals {
+		current := h.candles[i].last()
+		if current == nil || models.CandleStart(trade.Timestamp, interval) > current.Start {
+			h.candles[i].push(models.NewCandle(trade, interval))
+			current = h.candles[i].last()
+		} else {
+			current.Add(trade)
+		}
+		changed[i] = *current
+	}
+	return changed
+}
+
+// recentTrades returns up to limit of the newest trades, newest first; a limit
+// that is not positive returns every trade kept
+func (h *tradeHistory) recentTrades(limit int) []*models.Trade {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	n := h.trades.len()
+	if limit > 0 && limit < n {
+		n = limit
+	}
+	trades := make([]*models.Trade, n)
+	for i := range trades {
+		trades[i] = h.trades.at(h.trades.len() - 1 - i)
+	}
+	return trades
+}
+
+// candlesBetween returns up to limit of the newest candles of the interval that
+// start in [from, to), oldest first. Zero times leave that end open; a limit that
+// is not positive returns every match. Intervals without trades have no candle.
+func (h *tradeHistory) candlesBetween(interval time.Duration, from, to time.Time, limit int) ([]models.Candle, error) {
+	i := slices.Index(CandleIntervals, interval)
+	if i < 0 {
+		return nil, ErrCandleInterval
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	kept := h.candles[i]
+	var candles []models.Candle
+	for j := kept.len() - 1; j >= 0 && (limit <= 0 || len(candles) < limit); j-- {
+		candle := kept.at(j)
+		if !to.IsZero() && candle.Start >= to.UnixNano() {
+			continue
+		}
+		if !from.IsZero() && candle.Start < from.UnixNano() {
+			break
+		}
+		candles = append(candles, candle)
+	}
+	slices.Reverse(candles)
+	return candles, nil
+}
+
+// RecentTrades returns up to limit of the book's newest trades, newest first
+func (ob *OrderBook) RecentTrades(limit int) []*models.Trade {
+	return ob.history.recentTrades(limit)
+}
+
+// Candles returns up to limit of the book's newest candles of the interval
+// starting in [from, to), oldest first
+func (ob *OrderBook) Candles(interval time.Duration, from, to time.Time, limit int) ([]models.Candle, error) {
+	return ob.history.candlesBetween(interval, from, to, limit)
+}
@@ -0,0 +1,89 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aeromatch/internal/models"
+)
+
+func TestCandlesAggregateTrades(t *testing.T) {
+	h := newTradeHistory()
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	trade := func(id uint64, at time.Duration, price, qty string) {
+		h.record(&models.Trade{
+			TradeID:    id,
+			Instrument: "BTC-USD",
+			Timestamp:  base.Add(at).UnixNano(),
+			Price:      models.MustParseDecimal(price),
+			Quantity:   models.MustParseDecimal(qty),
+		})
+	}
+	trade(1, 0, "100", "1")
+	trade(2, 400*time.Millisecond, "103", "2")
+	trade(3, 900*time.Millisecond, "99", "1")
+	trade(4, 1500*time.Millisecond, "101", "0.5")
+	trade(5, 61*time.Second, "102", "1")
+	trade(6, 60*time.Second, "98", "1") // The clock stepped back: folded into the current candles
+
+	describe := func(candles []models.Candle) string {
+		var s string
+		for _, c := range candles {
+			s += fmt.Sprintf("[%v %v/%v/%v/%v %v×%d]", time.Unix(0, c.Start).Sub(base), c.Open, c.High, c.Low, c.Close, c.Volume, c.Trades)
+		}
+		return s
+	}
+	for _, tt := range []struct {
+		interval time.Duration
+		from, to time.Time
+		limit    int
+		want     string
+	}{
+		{interval: time.Second, want: "[0s 100/103/99/99 4×3][1s 101/101/101/101 0.5×1][1m1s 102/102/98/98 2×2]"},
+		{interval: time.Minute, want: "[0s 100/103/99/101 4.5×4][1m0s 102/102/98/98 2×2]"},
+		{interval: time.Hour, want: "[0s 100/103/98/98 6.5×6]"},
+		{interval: time.Second, limit: 2, want: "[1s 101/101/101/101 0.5×1][1m1s 102/102/98/98 2×2]"},
+		{interval: time.Second, from: base.Add(time.Second), to: base.Add(time.Minute), want: "[1s 101/101/101/101 0.5×1]"},
+	} {
+		candles, err := h.candlesBetween(tt.interval, tt.from, tt.to, tt.limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := describe(candles); got != tt.want {
+			t.Errorf("%v candles from %v to %v limit %d: got %s, want %s", tt.interval, tt.from, tt.to, tt.limit, got, tt.want)
+		}
+	}
+	if _, err := h.candlesBetween(2*time.Minute, time.Time{}, time.Time{}, 0); !errors.Is(err, ErrCandleInterval) {
+		t.Fatalf("unsupported interval: got %v", err)
+	}
+
+	recent := h.recentTrades(2)
+	if len(recent) != 2 || recent[0].TradeID != 6 || recent[1].TradeID != 5 {
+		t.Fatalf("got %d recent trades, want trades 6 and 5", len(recent))
+	}
+}
+
+func TestTradeHistoryKeepsNewest(t *testing.T) {
+	h := newTradeHistory()
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	total := recentTradesKept + 10
+	for i := 1; i <= total; i++ {
+		h.record(&models.Trade{
+			TradeID:   uint64(i),
+			Timestamp: base.Add(time.Duration(i) * time.Second).UnixNano(),
+			Price:     models.MustParseDecimal("100"),
+			Quantity:  models.MustParseDecimal("1"),
+		})
+	}
+
+	trades := h.recentTrades(0)
+	if len(trades) != recentTradesKept || trades[0].TradeID != uint64(total) || trades[len(trades)-1].TradeID != 11 {
+		t.Fatalf("got %d trades from %d to %d", len(trades), trades[0].TradeID, trades[len(trades)-1].TradeID)
+	}
+	candles, _ := h.candlesBetween(time.Second, time.Time{}, time.Time{}, 0)
+	if len(candles) != candlesKept || candles[0].Start != base.Add(11*time.Second).UnixNano() {
+		t.Fatalf("got %d one-second candles from %v", len(candles), time.Unix(0, candles[0].Start).Sub(base))
+	}
+}
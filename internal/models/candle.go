@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// Candle is an OHLCV bar: the trades of one instrument over one interval
+type Candle struct {
+	Instrument string
+	Interval   time.Duration
+	Start      int64 // Unix nanoseconds, a multiple of Interval
+	Open       Decimal
+	High       Decimal
+	Low        Decimal
+	Close      Decimal
+	Volume     Decimal // Sum of trade quantities
+	Trades     int
+}
+
+// NewCandle starts the candle of the given interval that contains the trade
+func NewCandle(trade *Trade, interval time.Duration) Candle {
+	return Candle{
+		Instrument: trade.Instrument,
+		Interval:   interval,
+		Start:      CandleStart(trade.Timestamp, interval),
+		Open:       trade.Price,
+		High:       trade.Price,
+		Low:        trade.Price,
+		Close:      trade.Price,
+		Volume:     trade.Quantity,
+		Trades:     1,
+	}
+}
+
+// Add folds a later trade into the candle
+func (c *Candle) Add(trade *Trade) {
+	if trade.Price.GreaterThan(c.High) {
+		c.High = trade.Price
+	}
+	if trade.Price.LessThan(c.Low) {
+		c.Low = trade.Price
+	}
+	c.Close = trade.Price
+	c.Volume = c.Volume.Add(trade.Quantity)
+	c.Trades++
+}
+
+// CandleStart returns the start of the interval containing the Unix nanosecond timestamp
+func CandleStart(timestamp int64, interval time.Duration) int64 {
+	start := timestamp - timestamp%int64(interval)
+	if start > timestamp {
+		start -= int64(interval) // Before 1970, % rounds towards zero
+	}
+	return start
+}
@@ -0,0 +1,350 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// MaxScale is the largest number of fractional digits a Decimal can carry
+const MaxScale = 18
+
+var pow10 = [MaxScale + 1]int64{
+	1, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18,
+}
+
+// Decimal is a fixed-point number equal to units × 10^-scale.
+// Prices and quantities of an instrument share one scale, so the hot path
+// compares and adds plain int64s; mixed scales are aligned on the fly.
+type Decimal struct {
+	units int64
+	scale uint8
+}
+
+var (
+	ErrInvalidDecimal  = errors.New("invalid decimal")
+	ErrDecimalScale    = errors.New("decimal exceeds supported precision")
+	ErrDecimalOverflow = errors.New("decimal out of range")
+)
+
+// NewDecimal returns units × 10^-scale
+func NewDecimal(units int64, scale uint8) Decimal {
+	return Decimal{units: units, scale: scale}
+}
+
+// DecimalFromInt returns v as a Decimal with no fractional digits
+func DecimalFromInt(v int64) Decimal {
+	return Decimal{units: v}
+}
+
+// ParseDecimal parses a base-10 string such as "-12.345"; the scale is the
+// number of digits after the point, so "1.50" keeps scale 2.
+func ParseDecimal(s string) (Decimal, error) {
+	if s == "" {
+		return Decimal{}, ErrInvalidDecimal
+	}
+
+	intPart, fracPart, hasPoint := strings.Cut(s, ".")
+	if hasPoint && fracPart == "" {
+		return Decimal{}, ErrInvalidDecimal
+	}
+	if len(fracPart) > MaxScale {
+		return Decimal{}, ErrDecimalScale
+	}
+	if strings.ContainsAny(fracPart, "+-") {
+		return Decimal{}, ErrInvalidDecimal
+	}
+
+	units, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return Decimal{}, ErrInvalidDecimal
+	}
+	return Decimal{units: units, scale: uint8(len(fracPart))}, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics on error. Intended for constants.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Units returns the scaled integer value
+func (d Decimal) Units() int64 { return d.units }
+
+// Scale returns the number of fractional digits
+func (d Decimal) Scale() uint8 { return d.scale }
+
+// Rescale converts d to the given scale. It reports false if that would lose
+// precision or overflow.
+func (d Decimal) Rescale(scale uint8) (Decimal, bool) {
+	switch {
+	case scale > MaxScale:
+		return d, false
+	case scale == d.scale:
+		return d, true
+	case scale > d.scale:
+		units, ok := scaleUp(d.units, scale-d.scale)
+		if !ok {
+			return d, false
+		}
+		return Decimal{units: units, scale: scale}, true
+	default:
+		factor := pow10[d.scale-scale]
+		if d.units%factor != 0 {
+			return d, false
+		}
+		return Decimal{units: d.units / factor, scale: scale}, true
+	}
+}
+
+// scaleUp returns units × 10^digits, reporting false if that overflows
+func scaleUp(units int64, digits uint8) (int64, bool) {
+	factor := pow10[digits]
+	if units > math.MaxInt64/factor || units < math.MinInt64/factor {
+		return 0, false
+	}
+	return units * factor, true
+}
+
+// align returns both operands at the larger of their scales, or at a smaller one
+// that trailing zeros of the finer operand allow if the larger one overflows. It
+// reports false if they cannot share a scale; big then compares them exactly.
+func align(a, b Decimal) (int64, int64, uint8, bool) {
+	if a.scale == b.scale {
+		return a.units, b.units, a.scale, true
+	}
+	if a.scale < b.scale {
+		y, x, scale, ok := align(b, a)
+		return x, y, scale, ok
+	}
+	if x, ok := scaleUp(b.units, a.scale-b.scale); ok {
+		return a.units, x, a.scale, true
+	}
+	for a.scale > b.scale && a.units%10 == 0 {
+		a.units /= 10
+		a.scale--
+	}
+	x, ok := scaleUp(b.units, a.scale-b.scale)
+	return a.units, x, a.scale, ok
+}
+
+// big returns d's units at a scale at least its own, exactly
+func (d Decimal) big(scale uint8) *big.Int {
+	n := big.NewInt(d.units)
+	return n.Mul(n, big.NewInt(pow10[scale-d.scale]))
+}
+
+// decimalFromBig returns units × 10^-scale, dropping trailing zeros if the units
+// do not fit in an int64
+func decimalFromBig(units *big.Int, scale int) (Decimal, error) {
+	ten, digit := big.NewInt(10), new(big.Int)
+	for !units.IsInt64() && scale > 0 {
+		quo, _ := new(big.Int).QuoRem(units, ten, digit)
+		if digit.Sign() != 0 {
+			break
+		}
+		units, scale = quo, scale-1
+	}
+	if !units.IsInt64() {
+		return Decimal{}, ErrDecimalOverflow
+	}
+	return Decimal{units: units.Int64(), scale: uint8(scale)}, nil
+}
+
+// CheckedAdd returns d + o, or ErrDecimalOverflow if the sum cannot be held
+func (d Decimal) CheckedAdd(o Decimal) (Decimal, error) {
+	if a, b, scale, ok := align(d, o); ok {
+		if sum := a + b; (sum > a) == (b > 0) {
+			return Decimal{units: sum, scale: scale}, nil
+		}
+	}
+	scale := max(d.scale, o.scale)
+	return decimalFromBig(new(big.Int).Add(d.big(scale), o.big(scale)), int(scale))
+}
+
+// CheckedSub returns d - o, or ErrDecimalOverflow if the difference cannot be held
+func (d Decimal) CheckedSub(o Decimal) (Decimal, error) {
+	if a, b, scale, ok := align(d, o); ok {
+		if diff := a - b; (diff < a) == (b > 0) {
+			return Decimal{units: diff, scale: scale}, nil
+		}
+	}
+	scale := max(d.scale, o.scale)
+	return decimalFromBig(new(big.Int).Sub(d.big(scale), o.big(scale)), int(scale))
+}
+
+// CheckedMul returns d × o, or ErrDecimalOverflow if the product cannot be held.
+// Digits beyond MaxScale are truncated.
+func (d Decimal) CheckedMul(o Decimal) (Decimal, error) {
+	units, scale := d.units*o.units, int(d.scale)+int(o.scale)
+	if d.units != 0 && (units/d.units != o.units || (d.units == -1 && o.units == math.MinInt64)) {
+		product := new(big.Int).Mul(big.NewInt(d.units), big.NewInt(o.units))
+		if scale > MaxScale {
+			product.Quo(product, big.NewInt(pow10[scale-MaxScale]))
+			scale = MaxScale
+		}
+		return decimalFromBig(product, scale)
+	}
+	for scale > MaxScale {
+		units /= 10
+		scale--
+	}
+	return Decimal{units: units, scale: uint8(scale)}, nil
+}
+
+// Add returns d + o. It panics if the sum cannot be held; use CheckedAdd for
+// operands that are not already within an instrument's limits.
+func (d Decimal) Add(o Decimal) Decimal {
+	return mustDecimal(d.CheckedAdd(o))
+}
+
+// Sub returns d - o. It panics if the difference cannot be held, like Add.
+func (d Decimal) Sub(o Decimal) Decimal {
+	return mustDecimal(d.CheckedSub(o))
+}
+
+// Mul returns d × o. Trailing zeros are trimmed if the product would exceed
+// MaxScale. It panics if the product cannot be held, like Add.
+func (d Decimal) Mul(o Decimal) Decimal {
+	return mustDecimal(d.CheckedMul(o))
+}
+
+func mustDecimal(d Decimal, err error) Decimal {
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// MulInt returns d × n at the same scale. It panics if the product cannot be held, like Add.
+func (d Decimal) MulInt(n int64) Decimal {
+	return d.Mul(Decimal{units: n})
+}
+
+// Div returns d ÷ o with scale fractional digits, truncated toward zero. It is
+// meant for statistics such as average prices, off the hot path; o must not be zero.
+func (d Decimal) Div(o Decimal, scale uint8) Decimal {
+	scale = min(scale, MaxScale)
+	num := new(big.Int).Mul(big.NewInt(d.units), big.NewInt(pow10[scale]))
+	num.Mul(num, big.NewInt(pow10[o.scale]))
+	den := new(big.Int).Mul(big.NewInt(o.units), big.NewInt(pow10[d.scale]))
+	return mustDecimal(decimalFromBig(num.Quo(num, den), int(scale)))
+}
+
+// Cmp returns -1, 0 or +1 as d is less than, equal to or greater than o
+func (d Decimal) Cmp(o Decimal) int {
+	a, b, _, ok := align(d, o)
+	if !ok {
+		scale := max(d.scale, o.scale)
+		return d.big(scale).Cmp(o.big(scale))
+	}
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (d Decimal) Equal(o Decimal) bool       { return d.Cmp(o) == 0 }
+func (d Decimal) LessThan(o Decimal) bool    { return d.Cmp(o) < 0 }
+func (d Decimal) GreaterThan(o Decimal) bool { return d.Cmp(o) > 0 }
+
+// IsMultipleOf reports whether d is a whole number of step increments
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	if step.units == 0 {
+		return false
+	}
+	a, b, _, ok := align(d, step)
+	if !ok {
+		scale := max(d.scale, step.scale)
+		return new(big.Int).Rem(d.big(scale), step.big(scale)).Sign() == 0
+	}
+	return a%b == 0
+}
+
+// Sign returns -1, 0 or +1
+func (d Decimal) Sign() int {
+	switch {
+	case d.units < 0:
+		return -1
+	case d.units > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (d Decimal) IsZero() bool { return d.units == 0 }
+
+// Float64 returns the nearest float64; for display and statistics only
+func (d Decimal) Float64() float64 {
+	return float64(d.units) / float64(pow10[d.scale])
+}
+
+func (d Decimal) String() string {
+	return string(d.AppendText(nil))
+}
+
+// AppendText appends the decimal string form of d to buf
+func (d Decimal) AppendText(buf []byte) []byte {
+	units := d.units
+	if units < 0 {
+		buf = append(buf, '-')
+	}
+
+	var digits [24]byte
+	n := strconv.AppendUint(digits[:0], absUnits(units), 10)
+	if d.scale == 0 {
+		return append(buf, n...)
+	}
+
+	scale := int(d.scale)
+	if len(n) <= scale {
+		buf = append(buf, '0', '.')
+		for i := len(n); i < scale; i++ {
+			buf = append(buf, '0')
+		}
+		return append(buf, n...)
+	}
+	buf = append(buf, n[:len(n)-scale]...)
+	buf = append(buf, '.')
+	return append(buf, n[len(n)-scale:]...)
+}
+
+func absUnits(v int64) uint64 {
+	if v < 0 {
+		return uint64(-v)
+	}
+	return uint64(v)
+}
+
+// MarshalText encodes the decimal as its exact string form (JSON uses this too)
+func (d Decimal) MarshalText() ([]byte, error) {
+	return d.AppendText(nil), nil
+}
+
+func (d *Decimal) UnmarshalText(text []byte) error {
+	parsed, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MinDecimal returns the smaller of a and b
+func MinDecimal(a, b Decimal) Decimal {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+	return b
+}
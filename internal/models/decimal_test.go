@@ -0,0 +1,181 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in      string
+		units   int64
+		scale   uint8
+		out     string
+		wantErr bool
+	}{
+		{in: "0", units: 0, scale: 0, out: "0"},
+		{in: "67012.50", units: 6701250, scale: 2, out: "67012.50"},
+		{in: "0.00000001", units: 1, scale: 8, out: "0.00000001"},
+		{in: "-1.5", units: -15, scale: 1, out: "-1.5"},
+		{in: ".25", units: 25, scale: 2, out: "0.25"},
+		{in: "", wantErr: true},
+		{in: "1.", wantErr: true},
+		{in: "1.-5", wantErr: true},
+		{in: "1e5", wantErr: true},
+		{in: "0.1234567890123456789", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		d, err := ParseDecimal(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseDecimal(%q) = %v, want error", tt.in, d)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("ParseDecimal(%q): %v", tt.in, err)
+		}
+		if d.Units() != tt.units || d.Scale() != tt.scale || d.String() != tt.out {
+			t.Errorf("ParseDecimal(%q) = %d×10^-%d %q, want %d×10^-%d %q",
+				tt.in, d.Units(), d.Scale(), d.String(), tt.units, tt.scale, tt.out)
+		}
+	}
+}
+
+func TestDecimalRescale(t *testing.T) {
+	d := MustParseDecimal("1.25")
+
+	up, ok := d.Rescale(8)
+	if !ok || up.String() != "1.25000000" {
+		t.Fatalf("Rescale(8) = %v, %v", up, ok)
+	}
+	if _, ok := d.Rescale(1); ok {
+		t.Fatal("Rescale(1) of 1.25 should lose precision")
+	}
+	down, ok := up.Rescale(2)
+	if !ok || !down.Equal(d) {
+		t.Fatalf("Rescale(2) = %v, %v", down, ok)
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	// Summing 0.1 ten times drifts in float64 but must be exact here
+	sum := Decimal{}
+	tenth := MustParseDecimal("0.1")
+	for i := 0; i < 10; i++ {
+		sum = sum.Add(tenth)
+	}
+	if !sum.Equal(DecimalFromInt(1)) {
+		t.Fatalf("sum = %v, want 1", sum)
+	}
+
+	if got := MustParseDecimal("1.5").Sub(MustParseDecimal("0.25")); got.String() != "1.25" {
+		t.Errorf("1.5 - 0.25 = %v", got)
+	}
+	if got := MustParseDecimal("67012.50").Mul(MustParseDecimal("0.015")); got.String() != "1005.18750" {
+		t.Errorf("67012.50 × 0.015 = %v", got)
+	}
+	if got := MustParseDecimal("301.50").Div(MustParseDecimal("3"), 2); got.String() != "100.50" {
+		t.Errorf("301.50 ÷ 3 = %v", got)
+	}
+	if got := MustParseDecimal("-1").Div(MustParseDecimal("0.3"), 3); got.String() != "-3.333" {
+		t.Errorf("-1 ÷ 0.3 = %v, want truncation toward zero", got)
+	}
+	if MustParseDecimal("2").Cmp(MustParseDecimal("1.99")) != 1 {
+		t.Error("2 should compare above 1.99")
+	}
+	if !MinDecimal(MustParseDecimal("0.3"), MustParseDecimal("0.25")).Equal(MustParseDecimal("0.25")) {
+		t.Error("MinDecimal picked the larger value")
+	}
+}
+
+func TestDecimalRangeEdges(t *testing.T) {
+	max := NewDecimal(math.MaxInt64, 0)
+	min := NewDecimal(math.MinInt64, 0)
+	fine := MustParseDecimal("9.000000000000000000") // 9e18 units: any coarser operand overflows when aligned
+
+	// Comparisons are exact even when the operands cannot share a scale
+	for _, tt := range []struct {
+		a, b Decimal
+		want int
+	}{
+		{fine, MustParseDecimal("1000.00"), -1},
+		{fine, MustParseDecimal("9"), 0},
+		{MustParseDecimal("9.000000000000000001"), MustParseDecimal("10"), -1},
+		{MustParseDecimal("-9.000000000000000001"), MustParseDecimal("-10"), 1},
+		{min, MustParseDecimal("-0.000000000000000001"), -1},
+	} {
+		if got := tt.a.Cmp(tt.b); got != tt.want {
+			t.Errorf("%v cmp %v = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+	if !fine.IsMultipleOf(MustParseDecimal("0.01")) || MustParseDecimal("9.000000000000000001").IsMultipleOf(MustParseDecimal("0.01")) {
+		t.Error("IsMultipleOf is wrong beyond the aligned range")
+	}
+
+	// Results that fit are exact, trading trailing zeros for range
+	for _, tt := range []struct {
+		got  Decimal
+		want string
+	}{
+		{fine.Mul(MustParseDecimal("1.000000000000000000")), "9.000000000000000000"},
+		{MustParseDecimal("5.000000000000000000").Mul(MustParseDecimal("1.000000000000000000")), "5.000000000000000000"},
+		{fine.Add(MustParseDecimal("0.01")), "9.010000000000000000"},
+		{fine.Add(MustParseDecimal("1000")), "1009"},
+		{fine.Sub(MustParseDecimal("10.5")), "-1.5"},
+		{max.Sub(NewDecimal(1, 0)).Add(NewDecimal(1, 0)), "9223372036854775807"},
+		{min.Add(max), "-1"},
+	} {
+		if tt.got.String() != tt.want {
+			t.Errorf("got %v, want %s", tt.got, tt.want)
+		}
+	}
+
+	// Results that do not fit are errors, never wrapped values
+	for name, op := range map[string]func() (Decimal, error){
+		"max + 1":     func() (Decimal, error) { return max.CheckedAdd(NewDecimal(1, 0)) },
+		"min - 1":     func() (Decimal, error) { return min.CheckedSub(NewDecimal(1, 0)) },
+		"max + 0.1":   func() (Decimal, error) { return max.CheckedAdd(MustParseDecimal("0.1")) },
+		"max × 2":     func() (Decimal, error) { return max.CheckedMul(NewDecimal(2, 0)) },
+		"min × -1":    func() (Decimal, error) { return min.CheckedMul(NewDecimal(-1, 0)) },
+		"9e18 × 9e18": func() (Decimal, error) { return NewDecimal(9e18, 0).CheckedMul(NewDecimal(9e18, 0)) },
+		"fine - -max": func() (Decimal, error) { return fine.CheckedSub(NewDecimal(-math.MaxInt64, 0)) },
+	} {
+		if got, err := op(); !errors.Is(err, ErrDecimalOverflow) {
+			t.Errorf("%s = %v, %v; want ErrDecimalOverflow", name, got, err)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Add did not panic on overflow")
+		}
+	}()
+	max.Add(NewDecimal(1, 0))
+}
+
+func TestDecimalJSON(t *testing.T) {
+	in := struct {
+		Price Decimal `json:"price"`
+	}{MustParseDecimal("100.10")}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"price":"100.10"}` {
+		t.Fatalf("got %s", data)
+	}
+
+	var out struct {
+		Price Decimal `json:"price"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Price != in.Price {
+		t.Fatalf("round trip got %v", out.Price)
+	}
+}
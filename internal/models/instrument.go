@@ -0,0 +1,150 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+type TradingStatus uint8
+
+const (
+	StatusTrading  TradingStatus = iota // Accepting and matching orders
+	StatusHalted                        // Book kept, new orders and amends refused
+	StatusDelisted                      // Book closed, resting orders cancelled
+)
+
+func (s TradingStatus) String() string {
+	switch s {
+	case StatusTrading:
+		return "trading"
+	case StatusHalted:
+		return "halted"
+	case StatusDelisted:
+		return "delisted"
+	default:
+		return "unknown"
+	}
+}
+
+func (s TradingStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *TradingStatus) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "trading":
+		*s = StatusTrading
+	case "halted":
+		*s = StatusHalted
+	case "delisted":
+		*s = StatusDelisted
+	default:
+		return fmt.Errorf("unknown trading status %q", text)
+	}
+	return nil
+}
+
+// Instrument describes a tradable symbol and the rules its orders must follow.
+// Zero-valued limits (MaxQuantity, MinNotional, MinPrice, MaxPrice) are not enforced.
+type Instrument struct {
+	Symbol        string        `json:"symbol"` // e.g. "BTC-USD"
+	BaseCurrency  string        `json:"base_currency"`
+	QuoteCurrency string        `json:"quote_currency"`
+	TickSize      Decimal       `json:"tick_size"` // Price increment; its scale is the price scale
+	LotSize       Decimal       `json:"lot_size"`  // Quantity increment; its scale is the quantity scale
+	MinQuantity   Decimal       `json:"min_quantity"`
+	MaxQuantity   Decimal       `json:"max_quantity"`
+	MinNotional   Decimal       `json:"min_notional"` // Minimum price × quantity for priced orders
+	MinPrice      Decimal       `json:"min_price"`    // Lower edge of the price band
+	MaxPrice      Decimal       `json:"max_price"`    // Upper edge of the price band
+	Status        TradingStatus `json:"status"`
+}
+
+// PriceScale is the number of fractional digits prices are held at
+func (i *Instrument) PriceScale() uint8 {
+	return i.TickSize.Scale()
+}
+
+// QuantityScale is the number of fractional digits quantities are held at
+func (i *Instrument) QuantityScale() uint8 {
+	return i.LotSize.Scale()
+}
+
+// Validate checks that the instrument definition itself is usable
+func (i *Instrument) Validate() error {
+	switch {
+	case i.Symbol == "":
+		return ErrMissingInstrument
+	case i.TickSize.Sign() <= 0:
+		return fmt.Errorf("%s: tick size must be positive", i.Symbol)
+	case i.LotSize.Sign() <= 0:
+		return fmt.Errorf("%s: lot size must be positive", i.Symbol)
+	case i.MaxQuantity.Sign() > 0 && i.MaxQuantity.LessThan(i.MinQuantity):
+		return fmt.Errorf("%s: max quantity below min quantity", i.Symbol)
+	case i.MaxPrice.Sign() > 0 && i.MaxPrice.LessThan(i.MinPrice):
+		return fmt.Errorf("%s: price band is inverted", i.Symbol)
+	}
+	return nil
+}
+
+// NormalizeOrder brings the order's price and quantities to the instrument's
+// scales, so that its rules are checked on the values the book will hold. It
+// returns ErrInvalidPrecision, leaving the order unchanged, if the order is finer
+// than the tick or lot size scale.
+func (i *Instrument) NormalizeOrder(o *Order) error {
+	price, okPrice := o.Price.Rescale(i.PriceScale())
+	quantity, okQty := o.Quantity.Rescale(i.QuantityScale())
+	remaining, okRem := o.Remaining.Rescale(i.QuantityScale())
+	if !okPrice || !okQty || !okRem {
+		return ErrInvalidPrecision
+	}
+	o.Price, o.Quantity, o.Remaining = price, quantity, remaining
+	return nil
+}
+
+// ValidateOrder checks an order against the instrument's trading status and rules
+func (i *Instrument) ValidateOrder(o *Order) error {
+	if i.Status != StatusTrading {
+		return ErrInstrumentNotTrading
+	}
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	if !o.Quantity.IsMultipleOf(i.LotSize) {
+		return ErrOffLot
+	}
+	if o.Quantity.LessThan(i.MinQuantity) || (i.MaxQuantity.Sign() > 0 && o.Quantity.GreaterThan(i.MaxQuantity)) {
+		return ErrQuantityOutOfRange
+	}
+
+	if o.Type == Market {
+		return nil
+	}
+	if !o.Price.IsMultipleOf(i.TickSize) {
+		return ErrOffTick
+	}
+	if o.Price.LessThan(i.MinPrice) || (i.MaxPrice.Sign() > 0 && o.Price.GreaterThan(i.MaxPrice)) {
+		return ErrPriceOutOfBand
+	}
+	notional, err := o.Price.CheckedMul(o.Quantity)
+	if err != nil {
+		return ErrNotionalOutOfRange
+	}
+	if notional.LessThan(i.MinNotional) {
+		return ErrBelowMinNotional
+	}
+	return nil
+}
+
+// Instrument validation errors
+var (
+	ErrInstrumentNotTrading = errors.New("instrument is not trading")
+	ErrInvalidPrecision     = errors.New("price or quantity finer than the instrument's scale")
+	ErrOffTick              = errors.New("price is not a multiple of the tick size")
+	ErrOffLot               = errors.New("quantity is not a multiple of the lot size")
+	ErrQuantityOutOfRange   = errors.New("quantity outside instrument limits")
+	ErrPriceOutOfBand       = errors.New("price outside instrument price band")
+	ErrBelowMinNotional     = errors.New("order value below minimum notional")
+	ErrNotionalOutOfRange   = errors.New("order value too large to represent")
+)
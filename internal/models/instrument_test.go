@@ -0,0 +1,69 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInstrumentValidateOrder(t *testing.T) {
+	instrument := &Instrument{
+		Symbol:      "BTC-USD",
+		TickSize:    MustParseDecimal("0.50"),
+		LotSize:     MustParseDecimal("0.001"),
+		MinQuantity: MustParseDecimal("0.01"),
+		MaxQuantity: MustParseDecimal("100"),
+		MinNotional: MustParseDecimal("10"),
+		MinPrice:    MustParseDecimal("1000"),
+		MaxPrice:    MustParseDecimal("200000"),
+	}
+
+	tests := []struct {
+		name      string
+		orderType OrderType
+		price     string
+		qty       string
+		want      error
+	}{
+		{"valid limit", Limit, "67012.50", "0.015", nil},
+		{"valid market ignores price rules", Market, "0", "0.015", nil},
+		{"off tick", Limit, "67012.25", "0.015", ErrOffTick},
+		{"off lot", Limit, "67012.50", "0.0155", ErrOffLot},
+		{"below min quantity", Limit, "67012.50", "0.005", ErrQuantityOutOfRange},
+		{"above max quantity", Limit, "67012.50", "100.001", ErrQuantityOutOfRange},
+		{"below price band", Limit, "999.50", "0.02", ErrPriceOutOfBand},
+		{"above price band", Limit, "200000.50", "0.01", ErrPriceOutOfBand},
+		{"exactly min notional", IOC, "1000", "0.01", nil},
+		{"non-positive price", Limit, "0", "1", ErrInvalidPrice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &Order{
+				Type:       tt.orderType,
+				Price:      MustParseDecimal(tt.price),
+				Quantity:   MustParseDecimal(tt.qty),
+				Instrument: "BTC-USD",
+			}
+			if err := instrument.ValidateOrder(order); !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	instrument.MinPrice = Decimal{}
+	order := &Order{Price: MustParseDecimal("500"), Quantity: MustParseDecimal("0.01"), Instrument: "BTC-USD"}
+	if err := instrument.ValidateOrder(order); !errors.Is(err, ErrBelowMinNotional) {
+		t.Fatalf("got %v, want %v", err, ErrBelowMinNotional)
+	}
+
+	instrument.MaxPrice, instrument.MaxQuantity = Decimal{}, Decimal{}
+	order.Price, order.Quantity = MustParseDecimal("9000000000000000"), MustParseDecimal("9000")
+	if err := instrument.ValidateOrder(order); !errors.Is(err, ErrNotionalOutOfRange) {
+		t.Fatalf("got %v, want %v", err, ErrNotionalOutOfRange)
+	}
+
+	instrument.Status = StatusHalted
+	if err := instrument.ValidateOrder(order); !errors.Is(err, ErrInstrumentNotTrading) {
+		t.Fatalf("got %v for halted instrument", err)
+	}
+}
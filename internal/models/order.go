@@ -23,6 +23,14 @@ const (
 	Sell
 )
 
+// PostOnlyMode controls what happens to a post-only order that would cross
+type PostOnlyMode uint8
+
+const (
+	PostOnlyReject PostOnlyMode = iota // Reject the order
+	PostOnlySlide                      // Re-price one tick behind the opposite best
+)
+
 type OrderStatus uint8
 
 const (
@@ -33,16 +41,50 @@ const (
 	Rejected                     // Rejected by system
 )
 
+// RejectReason explains why an order was rejected or killed
+type RejectReason uint8
+
+const (
+	ReasonNone                  RejectReason = iota
+	ReasonInsufficientLiquidity              // FOK could not be filled in full
+	ReasonPostOnlyWouldCross                 // Post-only order would have taken liquidity
+	ReasonInvalidPrecision                   // Price or quantity finer than the instrument scale
+	ReasonInstrumentNotTrading               // Instrument halted or delisted before the order was matched
+	ReasonExpired                            // Market or IOC remainder left unfilled
+	ReasonNotionalOutOfRange                 // A fill's value would not fit the order's filled value
+)
+
+func (r RejectReason) String() string {
+	switch r {
+	case ReasonNone:
+		return "none"
+	case ReasonInsufficientLiquidity:
+		return "insufficient liquidity to fill in full"
+	case ReasonPostOnlyWouldCross:
+		return "post-only order would cross the spread"
+	case ReasonInvalidPrecision:
+		return "price or quantity exceeds instrument precision"
+	case ReasonInstrumentNotTrading:
+		return "instrument is not trading"
+	case ReasonExpired:
+		return "unfilled remainder expired"
+	case ReasonNotionalOutOfRange:
+		return "filled value out of range"
+	default:
+		return "unknown"
+	}
+}
+
 // Order represents a single order in the order book
 type Order struct {
 	// Hot Path Fields (64 bytes cache-line aligned)
 	ID        uint64  // Order ID (unique per instrument)
-	Price     float64 // Limit price (NaN for market orders)
-	Quantity  float64 // Original quantity
-	Remaining float64 // Remaining quantity
+	Price     Decimal // Limit price (zero for market orders)
+	Quantity  Decimal // Original quantity
+	Remaining Decimal // Remaining quantity
 	Side      OrderSide
 	Type      OrderType
-	_         [30]byte // Padding to align to 64 bytes
+	_         [6]byte // Padding to align to 64 bytes
 
 	// Warm Path Fields (less frequently accessed)
 	Instrument  string // Trading pair (e.g., "BTC-USD")
@@ -50,6 +92,9 @@ type Order struct {
 	Timestamp   time.Time // Order creation time
 	Status      OrderStatus
 	LastUpdated time.Time
+	PostOnly    PostOnlyMode // Only used by PostOnly orders
+	Sequence    uint64       // Per-instrument sequence number assigned on entry to the engine
+	FilledValue Decimal      // Sum of price × quantity over fills
 
 	// Cold Path Fields (rarely accessed)
 	ClientOID    string
@@ -61,17 +106,20 @@ type Order struct {
 type MarginParams struct {
 	Leverage    float64
 	IsIsolated  bool
-	Liquidation float64
+	Liquidation Decimal
 	BorrowCost  float64
 }
 
-// OrderEvent represents changes to an order's state
+// OrderEvent represents changes to an order's state. Order is a copy taken when
+// the event happened; the trade fields are set only for fills.
 type OrderEvent struct {
 	Order       *Order
 	OldStatus   OrderStatus
+	TradeID     uint64
 	ExecutionID uint64
-	TradePrice  float64
-	TradeSize   float64
+	TradePrice  Decimal
+	TradeSize   Decimal
+	Reason      RejectReason // Set when the order was rejected or killed
 	Timestamp   time.Time
 }
 
@@ -83,11 +131,49 @@ func (o *Order) IsActive() bool {
 	return o.Status == New || o.Status == Partial
 }
 
+// CheckFill returns ErrDecimalOverflow if the order's filled value cannot take a
+// fill of qty at price. Fill panics where CheckFill fails.
+func (o *Order) CheckFill(qty, price Decimal) error {
+	value, err := price.CheckedMul(qty)
+	if err == nil {
+		_, err = o.FilledValue.CheckedAdd(value)
+	}
+	return err
+}
+
+// Fill records a fill of qty at price, reducing the remaining quantity and
+// advancing the status accordingly
+func (o *Order) Fill(qty, price Decimal) {
+	o.Remaining = o.Remaining.Sub(qty)
+	o.FilledValue = o.FilledValue.Add(price.Mul(qty))
+	if o.Remaining.Sign() <= 0 {
+		o.Status = Filled
+	} else {
+		o.Status = Partial
+	}
+	o.LastUpdated = time.Now()
+}
+
+// Filled returns the quantity filled so far
+func (o *Order) Filled() Decimal {
+	return o.Quantity.Sub(o.Remaining)
+}
+
+// AveragePrice returns the quantity-weighted average price of the fills so far,
+// or zero if there are none
+func (o *Order) AveragePrice() Decimal {
+	filled := o.Filled()
+	if filled.Sign() <= 0 {
+		return Decimal{}
+	}
+	return o.FilledValue.Div(filled, o.FilledValue.Scale())
+}
+
 func (o *Order) Validate() error {
-	if o.Quantity <= 0 {
+	if o.Quantity.Sign() <= 0 {
 		return ErrInvalidQuantity
 	}
-	if o.Type == Limit && (o.Price <= 0 || o.Price != o.Price) { // NaN check
+	if o.Type != Market && o.Price.Sign() <= 0 {
 		return ErrInvalidPrice
 	}
 	if len(o.Instrument) == 0 {
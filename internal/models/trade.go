@@ -1,19 +1,20 @@
 package models
 
 type Trade struct {
-	// Hot Path (first cache line - 64 bytes)
-	TradeID     uint64
-	ExecutionID uint64   
-	Price       float64  
-	Quantity    float64  
-	Timestamp   int64
-	MakerOrderID uint64    
-	TakerOrderID uint64  
-	Fee         float64 // Fee charged for the trade  
+	// Hot Path (first cache lines)
+	TradeID      uint64
+	ExecutionID  uint64
+	Price        Decimal
+	Quantity     Decimal
+	Timestamp    int64
+	MakerOrderID uint64
+	TakerOrderID uint64
+	Fee          Decimal // Fee charged for the trade
 
-	// Warm Path (second cache line - 64 bytes)
-	Instrument   string
-	Side         OrderSide
+	// Warm Path
+	Instrument  string
+	Sequence    uint64 // Sequence number of the command that produced the trade
+	Side        OrderSide
 	FeeCurrency string
 	Tags        map[string]string
-}
\ No newline at end of file
+}
@@ -0,0 +1,140 @@
+package protocol
+
+import (
+	"context"
+
+	grpcapi "github.com/aeromatch/api/grpc"
+	"github.com/aeromatch/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Admin service: instrument lifecycle on a running engine
+
+// ListInstruments returns every listed instrument, halted ones included
+func (s *GRPCServer) ListInstruments(ctx context.Context, req *grpcapi.ListInstrumentsRequest) (*grpcapi.ListInstrumentsResponse, error) {
+	instruments := s.engine.ListInstruments()
+	resp := &grpcapi.ListInstrumentsResponse{
+		Instruments: make([]*grpcapi.Instrument, 0, len(instruments)),
+	}
+	for _, instrument := range instruments {
+		resp.Instruments = append(resp.Instruments, s.convertInstrumentToProto(instrument))
+	}
+	return resp, nil
+}
+
+// AddInstrument lists a new instrument and starts its book
+func (s *GRPCServer) AddInstrument(ctx context.Context, req *grpcapi.Instrument) (*grpcapi.Instrument, error) {
+	instrument, err := s.convertInstrument(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid instrument: %v", err)
+	}
+
+	added, err := s.engine.AddInstrument(instrument)
+	if err != nil {
+		return nil, s.convertEngineError(err)
+	}
+	return s.convertInstrumentToProto(added), nil
+}
+
+// HaltInstrument stops matching; resting orders stay and can be cancelled
+func (s *GRPCServer) HaltInstrument(ctx context.Context, req *grpcapi.InstrumentRequest) (*grpcapi.Instrument, error) {
+	instrument, err := s.engine.HaltInstrument(req.Symbol)
+	if err != nil {
+		return nil, s.convertEngineError(err)
+	}
+	return s.convertInstrumentToProto(instrument), nil
+}
+
+// ResumeInstrument reopens a halted instrument
+func (s *GRPCServer) ResumeInstrument(ctx context.Context, req *grpcapi.InstrumentRequest) (*grpcapi.Instrument, error) {
+	instrument, err := s.engine.ResumeInstrument(req.Symbol)
+	if err != nil {
+		return nil, s.convertEngineError(err)
+	}
+	return s.convertInstrumentToProto(instrument), nil
+}
+
+// DelistInstrument cancels all resting orders and removes the book
+func (s *GRPCServer) DelistInstrument(ctx context.Context, req *grpcapi.InstrumentRequest) (*grpcapi.DelistInstrumentResponse, error) {
+	cancelled, err := s.engine.DelistInstrument(req.Symbol)
+	if err != nil {
+		return nil, s.convertEngineError(err)
+	}
+
+	resp := &grpcapi.DelistInstrumentResponse{
+		Symbol:            req.Symbol,
+		CancelledOrderIds: make([]uint64, 0, len(cancelled)),
+	}
+	for _, order := range cancelled {
+		resp.CancelledOrderIds = append(resp.CancelledOrderIds, order.ID)
+	}
+	return resp, nil
+}
+
+// convertInstrument converts a gRPC Instrument to models.Instrument
+func (s *GRPCServer) convertInstrument(req *grpcapi.Instrument) (*models.Instrument, error) {
+	instrument := &models.Instrument{
+		Symbol:        req.Symbol,
+		BaseCurrency:  req.BaseCurrency,
+		QuoteCurrency: req.QuoteCurrency,
+	}
+
+	fields := []struct {
+		value string
+		dst   *models.Decimal
+	}{
+		{req.TickSize, &instrument.TickSize},
+		{req.LotSize, &instrument.LotSize},
+		{req.MinQuantity, &instrument.MinQuantity},
+		{req.MaxQuantity, &instrument.MaxQuantity},
+		{req.MinNotional, &instrument.MinNotional},
+		{req.MinPrice, &instrument.MinPrice},
+		{req.MaxPrice, &instrument.MaxPrice},
+	}
+	for _, field := range fields {
+		value, err := s.convertDecimal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		*field.dst = value
+	}
+
+	switch req.Status {
+	case grpcapi.InstrumentStatus_TRADING:
+		instrument.Status = models.StatusTrading
+	case grpcapi.InstrumentStatus_HALTED:
+		instrument.Status = models.StatusHalted
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "cannot add instrument with status %v", req.Status)
+	}
+	return instrument, nil
+}
+
+// convertInstrumentToProto converts models.Instrument to gRPC Instrument
+func (s *GRPCServer) convertInstrumentToProto(instrument *models.Instrument) *grpcapi.Instrument {
+	return &grpcapi.Instrument{
+		Symbol:        instrument.Symbol,
+		BaseCurrency:  instrument.BaseCurrency,
+		QuoteCurrency: instrument.QuoteCurrency,
+		TickSize:      instrument.TickSize.String(),
+		LotSize:       instrument.LotSize.String(),
+		MinQuantity:   instrument.MinQuantity.String(),
+		MaxQuantity:   instrument.MaxQuantity.String(),
+		MinNotional:   instrument.MinNotional.String(),
+		MinPrice:      instrument.MinPrice.String(),
+		MaxPrice:      instrument.MaxPrice.String(),
+		Status:        s.convertInstrumentStatusToProto(instrument.Status),
+	}
+}
+
+func (s *GRPCServer) convertInstrumentStatusToProto(st models.TradingStatus) grpcapi.InstrumentStatus {
+	switch st {
+	case models.StatusHalted:
+		return grpcapi.InstrumentStatus_HALTED
+	case models.StatusDelisted:
+		return grpcapi.InstrumentStatus_DELISTED
+	default:
+		return grpcapi.InstrumentStatus_TRADING
+	}
+}
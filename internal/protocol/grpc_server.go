@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"sync"
@@ -12,21 +13,32 @@ import (
 	"github.com/aeromatch/internal/models"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // gRPC server for AeroMatch order submission and market data
 
+const defaultBookDepth = 20 // Price levels a side when an order book request leaves depth unset
+
+const accountMetadata = "account" // Call metadata naming the caller's account
+
 type GRPCServer struct {
 	engine                             *engine.MatchingEngine
 	server                             *grpc.Server
 	listener                           net.Listener
-	shutdownWg                         sync.WaitGroup // Wait for all goroutines to finish
-	grpcapi.UnimplementedTradingServer                // Embed the unimplemented server to satisfy the interface
+	marketData                         engine.SubscriptionOptions // Queue size and slow consumer policy for market data streams
+	shutdownWg                         sync.WaitGroup             // Wait for all goroutines to finish
+	grpcapi.UnimplementedTradingServer                            // Embed the unimplemented server to satisfy the interface
+	grpcapi.UnimplementedAdminServer
 }
 
-// NewGRPCServer creates a new gRPC server for AeroMatch
-func NewGRPCServer(matchingEngine *engine.MatchingEngine, port int, maxMessageSize int) (*GRPCServer, error) {
+// NewGRPCServer creates a new gRPC server for AeroMatch. Each market data stream
+// gets its own queue of marketData.Buffer messages, handled by marketData.Policy
+// when the client cannot keep up. Order update streams get a queue of the same size.
+func NewGRPCServer(matchingEngine *engine.MatchingEngine, port int, maxMessageSize int, marketData engine.SubscriptionOptions) (*GRPCServer, error) {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		return nil, err
@@ -38,12 +50,14 @@ func NewGRPCServer(matchingEngine *engine.MatchingEngine, port int, maxMessageSi
 	)
 
 	s := &GRPCServer{
-		engine:   matchingEngine,
-		server:   grpcServer,
-		listener: lis,
+		engine:     matchingEngine,
+		server:     grpcServer,
+		listener:   lis,
+		marketData: marketData,
 	}
 
 	grpcapi.RegisterTradingServer(grpcServer, s)
+	grpcapi.RegisterAdminServer(grpcServer, s)
 	return s, nil
 }
 
@@ -72,19 +86,12 @@ func (s *GRPCServer) SubmitOrder(ctx context.Context, req *grpcapi.OrderRequest)
 		return nil, status.Errorf(codes.InvalidArgument, "invalid order: %v", err)
 	}
 
-	// Validate order
-	if err := order.Validate(); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+	// Validate against instrument rules and submit to matching engine
+	response, err := s.submitOrder(ctx, order, req.WaitForResult)
+	if err != nil {
+		return nil, s.convertEngineError(err)
 	}
-
-	// Submit to matching engine
-	s.engine.SubmitOrder(order)
-
-	return &grpcapi.OrderResponse{
-		OrderId:   order.ID,
-		Status:    grpcapi.OrderStatus_PENDING,
-		Timestamp: order.Timestamp.UnixNano(),
-	}, nil
+	return response, nil
 }
 
 // SubmitOrderStream handles streaming order submission
@@ -99,33 +106,162 @@ func (s *GRPCServer) SubmitOrderStream(stream grpcapi.Trading_SubmitOrderStreamS
 		if err != nil {
 			// Send error response but continue processing stream
 			stream.Send(&grpcapi.OrderResponse{
-				OrderId: req.OrderId,
-				Status:  grpcapi.OrderStatus_REJECTED,
-				Error:   err.Error(),
+				Status:       grpcapi.OrderStatus_REJECTED,
+				Error:        err.Error(),
+				RejectReason: grpcapi.RejectReason_INVALID_ORDER,
 			})
 			continue
 		}
 
-		s.engine.SubmitOrder(order)
+		response, err := s.submitOrder(stream.Context(), order, req.WaitForResult)
+		if err != nil {
+			if ctxErr := stream.Context().Err(); ctxErr != nil {
+				return ctxErr
+			}
+			stream.Send(&grpcapi.OrderResponse{
+				OrderId:      order.ID,
+				Status:       grpcapi.OrderStatus_REJECTED,
+				Error:        err.Error(),
+				RejectReason: s.convertEngineErrorToReason(err),
+			})
+			continue
+		}
+		stream.Send(response)
+	}
+}
 
-		// Send acknowledgment
-		stream.Send(&grpcapi.OrderResponse{
+// submitOrder hands the order to the engine and replies with an acknowledgement,
+// or with the matching result if wait is set. A client order ID the account
+// already used is answered with the original order's acknowledgement.
+func (s *GRPCServer) submitOrder(ctx context.Context, order *models.Order, wait bool) (*grpcapi.OrderResponse, error) {
+	var response *grpcapi.OrderResponse
+	var err error
+	if wait {
+		response, err = s.executeOrder(ctx, order)
+	} else if err = s.engine.SubmitOrder(order); err == nil {
+		response = &grpcapi.OrderResponse{
 			OrderId:   order.ID,
 			Status:    grpcapi.OrderStatus_PENDING,
 			Timestamp: order.Timestamp.UnixNano(),
-		})
+		}
 	}
+
+	var duplicate *engine.DuplicateOrderError
+	if errors.As(err, &duplicate) {
+		return &grpcapi.OrderResponse{
+			OrderId:   duplicate.OrderID,
+			Status:    grpcapi.OrderStatus_PENDING,
+			Timestamp: duplicate.Timestamp.UnixNano(),
+			Duplicate: true,
+		}, nil
+	}
+	return response, err
+}
+
+// CancelOrder cancels a resting order via gRPC. Another account's order is
+// reported as not found.
+func (s *GRPCServer) CancelOrder(ctx context.Context, req *grpcapi.CancelOrderRequest) (*grpcapi.CancelOrderResponse, error) {
+	if req.Account == "" {
+		return nil, status.Error(codes.InvalidArgument, "account is required")
+	}
+	order, err := s.engine.CancelOrder(req.Instrument, req.Account, req.OrderId)
+	if err != nil {
+		return nil, s.convertEngineError(err)
+	}
+
+	return &grpcapi.CancelOrderResponse{
+		OrderId:           order.ID,
+		Status:            s.convertOrderStatusToProto(order.Status),
+		RemainingQuantity: order.Remaining.String(),
+		Timestamp:         order.LastUpdated.UnixNano(),
+	}, nil
 }
 
-// GetOrderBook returns current order book state
+// AmendOrder changes price and/or quantity of a resting order via gRPC. Like
+// CancelOrder, it only finds the account's own orders.
+func (s *GRPCServer) AmendOrder(ctx context.Context, req *grpcapi.AmendOrderRequest) (*grpcapi.AmendOrderResponse, error) {
+	if req.Account == "" {
+		return nil, status.Error(codes.InvalidArgument, "account is required")
+	}
+	price, err := s.convertDecimal(req.Price)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid price: %v", err)
+	}
+	quantity, err := s.convertDecimal(req.Quantity)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid quantity: %v", err)
+	}
+
+	order, err := s.engine.AmendOrder(req.Instrument, req.Account, req.OrderId, price, quantity)
+	if err != nil {
+		return nil, s.convertEngineError(err)
+	}
+
+	return &grpcapi.AmendOrderResponse{
+		OrderId:           order.ID,
+		Status:            s.convertOrderStatusToProto(order.Status),
+		Price:             order.Price.String(),
+		Quantity:          order.Quantity.String(),
+		RemainingQuantity: order.Remaining.String(),
+		Timestamp:         order.LastUpdated.UnixNano(),
+	}, nil
+}
+
+// GetOrderBook returns the aggregated price levels of an instrument. Unless the
+// request asks for a fresh read, they may be up to one snapshot interval old.
 func (s *GRPCServer) GetOrderBook(ctx context.Context, req *grpcapi.OrderBookRequest) (*grpcapi.OrderBookResponse, error) {
-	// TODO: This would require adding order book snapshot capabilities to the engine
-	// For now, return unimplemented
-	return nil, status.Errorf(codes.Unimplemented, "order book snapshot not implemented")
+	depth := int(req.Depth)
+	if depth == 0 {
+		depth = defaultBookDepth
+	}
+
+	snapshot, err := s.engine.GetOrderBook(req.Instrument, depth, req.Fresh)
+	if err != nil {
+		return nil, s.convertEngineError(err)
+	}
+
+	return &grpcapi.OrderBookResponse{
+		Instrument: snapshot.Instrument,
+		Timestamp:  snapshot.Timestamp,
+		Bids:       s.convertPriceLevelsToProto(snapshot.Bids),
+		Asks:       s.convertPriceLevelsToProto(snapshot.Asks),
+		Sequence:   snapshot.Sequence,
+	}, nil
+}
+
+// executeOrder submits the order and waits until the engine has matched it,
+// timing the round trip
+func (s *GRPCServer) executeOrder(ctx context.Context, order *models.Order) (*grpcapi.OrderResponse, error) {
+	start := time.Now()
+	result, err := s.engine.ExecuteOrder(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	elapsed := time.Since(start)
+
+	final := result.Order
+	fills := make([]*grpcapi.Trade, len(result.Trades))
+	for i, trade := range result.Trades {
+		fills[i] = s.convertTradeToProto(trade)
+	}
+	return &grpcapi.OrderResponse{
+		OrderId:           final.ID,
+		Status:            s.convertOrderStatusToProto(final.Status),
+		Timestamp:         final.Timestamp.UnixNano(),
+		RejectReason:      s.convertRejectReasonToProto(result.Reason),
+		FilledQuantity:    final.Filled().String(),
+		RemainingQuantity: final.Remaining.String(),
+		Fills:             fills,
+		RoundTripNanos:    elapsed.Nanoseconds(),
+	}, nil
 }
 
 // convertOrderRequest converts gRPC OrderRequest to internal models.Order
 func (s *GRPCServer) convertOrderRequest(req *grpcapi.OrderRequest) (*models.Order, error) {
+	if hasDoubleDecimals(req.ProtoReflect().GetUnknown()) {
+		return nil, errDoubleDecimals
+	}
+
 	orderType, err := s.convertOrderType(req.OrderType)
 	if err != nil {
 		return nil, err
@@ -136,20 +272,66 @@ func (s *GRPCServer) convertOrderRequest(req *grpcapi.OrderRequest) (*models.Ord
 		return nil, err
 	}
 
+	postOnlyMode, err := s.convertPostOnlyMode(req.PostOnlyMode)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := s.convertDecimal(req.Price)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price: %w", err)
+	}
+
+	quantity, err := s.convertDecimal(req.Quantity)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quantity: %w", err)
+	}
+
+	// The ID is left for the engine to assign; clients' own IDs could collide
 	return &models.Order{
-		ID:         req.OrderId,
-		Price:      req.Price,
-		Quantity:   req.Quantity,
-		Remaining:  req.Quantity, // Initially remaining equals quantity
+		Price:      price,
+		Quantity:   quantity,
+		Remaining:  quantity, // Initially remaining equals quantity
 		Side:       orderSide,
 		Type:       orderType,
 		Instrument: req.Instrument,
 		Timestamp:  time.Now(),
 		Status:     models.New,
+		PostOnly:   postOnlyMode,
 		ClientOID:  req.ClientOrderId,
+		Account:    req.Account,
 	}, nil
 }
 
+// errDoubleDecimals refuses requests from clients built when OrderRequest carried
+// price and quantity as doubles in fields 3 and 4; they would otherwise be read as
+// an order with neither
+var errDoubleDecimals = errors.New("price and quantity must be decimal strings (fields 11 and 12); update the client")
+
+// hasDoubleDecimals reports whether a request's unknown fields include the
+// reserved double price or quantity
+func hasDoubleDecimals(unknown protoreflect.RawFields) bool {
+	for len(unknown) > 0 {
+		number, _, n := protowire.ConsumeField(unknown)
+		if n < 0 {
+			return false
+		}
+		if number == 3 || number == 4 {
+			return true
+		}
+		unknown = unknown[n:]
+	}
+	return false
+}
+
+// convertDecimal parses a wire decimal string; empty means zero
+func (s *GRPCServer) convertDecimal(value string) (models.Decimal, error) {
+	if value == "" {
+		return models.Decimal{}, nil
+	}
+	return models.ParseDecimal(value)
+}
+
 // convertOrderType converts gRPC OrderType to models.OrderType
 func (s *GRPCServer) convertOrderType(t grpcapi.OrderType) (models.OrderType, error) {
 	switch t {
@@ -168,6 +350,69 @@ func (s *GRPCServer) convertOrderType(t grpcapi.OrderType) (models.OrderType, er
 	}
 }
 
+// convertPostOnlyMode converts gRPC PostOnlyMode to models.PostOnlyMode
+func (s *GRPCServer) convertPostOnlyMode(mode grpcapi.PostOnlyMode) (models.PostOnlyMode, error) {
+	switch mode {
+	case grpcapi.PostOnlyMode_POST_ONLY_REJECT:
+		return models.PostOnlyReject, nil
+	case grpcapi.PostOnlyMode_POST_ONLY_SLIDE:
+		return models.PostOnlySlide, nil
+	default:
+		return 0, status.Errorf(codes.InvalidArgument, "unknown post-only mode: %v", mode)
+	}
+}
+
+// convertCandleInterval converts gRPC CandleInterval to its duration
+func (s *GRPCServer) convertCandleInterval(interval grpcapi.CandleInterval) (time.Duration, error) {
+	switch interval {
+	case grpcapi.CandleInterval_INTERVAL_1S:
+		return time.Second, nil
+	case grpcapi.CandleInterval_INTERVAL_1M:
+		return time.Minute, nil
+	case grpcapi.CandleInterval_INTERVAL_5M:
+		return 5 * time.Minute, nil
+	case grpcapi.CandleInterval_INTERVAL_1H:
+		return time.Hour, nil
+	default:
+		return 0, status.Errorf(codes.InvalidArgument, "unknown candle interval: %v", interval)
+	}
+}
+
+// convertEngineError maps matching engine errors to gRPC status errors
+func (s *GRPCServer) convertEngineError(err error) error {
+	switch {
+	case errors.Is(err, engine.ErrUnknownInstrument), errors.Is(err, engine.ErrOrderNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, engine.ErrInstrumentExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, models.ErrInstrumentNotTrading), errors.Is(err, engine.ErrInstrumentDelisted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, engine.ErrInvalidOrder), errors.Is(err, engine.ErrInvalidAmend), errors.Is(err, engine.ErrInvalidInstrument),
+		errors.Is(err, engine.ErrCandleInterval):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, engine.ErrJournal), errors.Is(err, engine.ErrEngineStopped):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, engine.ErrSubscriberBehind), errors.Is(err, engine.ErrOrderUpdatesBehind):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return status.FromContextError(err).Err()
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// convertEngineErrorToReason picks the reject reason reported for an order the engine refused
+func (s *GRPCServer) convertEngineErrorToReason(err error) grpcapi.RejectReason {
+	switch {
+	case errors.Is(err, models.ErrInvalidPrecision):
+		return grpcapi.RejectReason_INVALID_PRECISION
+	case errors.Is(err, models.ErrInstrumentNotTrading):
+		return grpcapi.RejectReason_INSTRUMENT_NOT_TRADING
+	default:
+		return grpcapi.RejectReason_INVALID_ORDER
+	}
+}
+
 // convertOrderSide converts gRPC OrderSide to models.OrderSide
 func (s *GRPCServer) convertOrderSide(side grpcapi.OrderSide) (models.OrderSide, error) {
 	switch side {
@@ -180,37 +425,315 @@ func (s *GRPCServer) convertOrderSide(side grpcapi.OrderSide) (models.OrderSide,
 	}
 }
 
-// MarketDataStream streams market data updates
+// MarketDataStream streams trades and L2 book updates for one instrument, and
+// candles at the requested intervals. Book updates begin with a snapshot; what a
+// client too slow to keep up gets instead depends on the server's slow consumer policy.
 func (s *GRPCServer) MarketDataStream(req *grpcapi.MarketDataRequest, stream grpcapi.Trading_MarketDataStreamServer) error {
-	// Subscribe to trade channel from matching engine
-	tradeChan := s.engine.GetTradesChannel()
+	opts := s.marketData
+	opts.Instruments = []string{req.Instrument}
+	if len(req.CandleIntervals) > 0 {
+		opts.Channels = engine.ChannelTrades | engine.ChannelBook | engine.ChannelCandles
+		for _, interval := range req.CandleIntervals {
+			duration, err := s.convertCandleInterval(interval)
+			if err != nil {
+				return err
+			}
+			opts.CandleIntervals = append(opts.CandleIntervals, duration)
+		}
+	}
+	sub, err := s.engine.SubscribeMarketData(opts)
+	if err != nil {
+		return s.convertEngineError(err)
+	}
+	defer sub.Close()
 
 	for {
-		select {
-		case trade := <-tradeChan:
-			if trade.Instrument == req.Instrument {
-				err := stream.Send(&grpcapi.MarketDataUpdate{
-					Type:      grpcapi.MarketDataType_TRADE,
-					Trade:     s.convertTradeToProto(trade),
-					Timestamp: trade.Timestamp,
-				})
-				if err != nil {
-					return err
-				}
+		data, err := sub.Next(stream.Context())
+		if err != nil {
+			if ctxErr := stream.Context().Err(); ctxErr != nil {
+				return ctxErr
 			}
-		case <-stream.Context().Done():
-			return stream.Context().Err()
+			return s.convertEngineError(err)
+		}
+
+		update := &grpcapi.MarketDataUpdate{}
+		if data.Trade != nil {
+			update.Type = grpcapi.MarketDataType_TRADE
+			update.Trade = s.convertTradeToProto(data.Trade)
+			update.Timestamp = data.Trade.Timestamp
+		} else if data.Candle != nil {
+			update.Type = grpcapi.MarketDataType_CANDLE
+			update.Candle = s.convertCandleToProto(data.Candle)
+			update.Timestamp = data.Candle.Start
+		} else {
+			update.Type = grpcapi.MarketDataType_ORDER_BOOK_UPDATE
+			update.Orderbook = s.convertBookUpdateToProto(data.Book)
+			update.Timestamp = data.Book.Timestamp
+		}
+		if err := stream.Send(update); err != nil {
+			return err
 		}
 	}
 }
 
+// GetRecentTrades returns the instrument's newest trades, newest first
+func (s *GRPCServer) GetRecentTrades(ctx context.Context, req *grpcapi.RecentTradesRequest) (*grpcapi.RecentTradesResponse, error) {
+	trades, err := s.engine.GetRecentTrades(req.Instrument, int(req.Limit))
+	if err != nil {
+		return nil, s.convertEngineError(err)
+	}
+	response := &grpcapi.RecentTradesResponse{Trades: make([]*grpcapi.Trade, len(trades))}
+	for i, trade := range trades {
+		response.Trades[i] = s.convertTradeToProto(trade)
+	}
+	return response, nil
+}
+
+// GetCandles returns the instrument's newest candles of one interval in a time range, oldest first
+func (s *GRPCServer) GetCandles(ctx context.Context, req *grpcapi.CandlesRequest) (*grpcapi.CandlesResponse, error) {
+	interval, err := s.convertCandleInterval(req.Interval)
+	if err != nil {
+		return nil, err
+	}
+	var from, to time.Time
+	if req.StartTime != 0 {
+		from = time.Unix(0, req.StartTime)
+	}
+	if req.EndTime != 0 {
+		to = time.Unix(0, req.EndTime)
+	}
+	candles, err := s.engine.GetCandles(req.Instrument, interval, from, to, int(req.Limit))
+	if err != nil {
+		return nil, s.convertEngineError(err)
+	}
+	response := &grpcapi.CandlesResponse{Candles: make([]*grpcapi.Candle, len(candles))}
+	for i := range candles {
+		response.Candles[i] = s.convertCandleToProto(&candles[i])
+	}
+	return response, nil
+}
+
+// OrderBookL3Stream streams every change to the instrument's resting orders,
+// starting from a snapshot of all of them
+func (s *GRPCServer) OrderBookL3Stream(req *grpcapi.MarketDataRequest, stream grpcapi.Trading_OrderBookL3StreamServer) error {
+	opts := s.marketData
+	opts.Instruments = []string{req.Instrument}
+	opts.Channels = engine.ChannelOrders
+	sub, err := s.engine.SubscribeMarketData(opts)
+	if err != nil {
+		return s.convertEngineError(err)
+	}
+	defer sub.Close()
+
+	for {
+		data, err := sub.Next(stream.Context())
+		if err != nil {
+			if ctxErr := stream.Context().Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return s.convertEngineError(err)
+		}
+		if err := stream.Send(s.convertL3UpdateToProto(data.Orders)); err != nil {
+			return err
+		}
+	}
+}
+
+// OrderUpdates streams an execution report for every change to the caller's
+// orders: acceptance, each fill, amends, cancels, expiry and rejection. A client
+// too slow to keep up is disconnected rather than sent a gap.
+func (s *GRPCServer) OrderUpdates(req *grpcapi.OrderUpdatesRequest, stream grpcapi.Trading_OrderUpdatesServer) error {
+	account, ok := callerAccount(stream.Context())
+	if !ok {
+		return status.Error(codes.Unauthenticated, "account metadata is required")
+	}
+	sub := s.engine.SubscribeOrderUpdates(account, s.marketData.Buffer)
+	defer sub.Close()
+
+	for {
+		event, err := sub.Next(stream.Context())
+		if err != nil {
+			if ctxErr := stream.Context().Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return s.convertEngineError(err)
+		}
+		if err := stream.Send(s.convertOrderEventToProto(event)); err != nil {
+			return err
+		}
+	}
+}
+
+// callerAccount returns the account named once in the call's metadata, where the
+// connection's authentication puts it, rather than anything in the request
+func callerAccount(ctx context.Context) (string, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(accountMetadata)
+	if len(values) != 1 || values[0] == "" {
+		return "", false
+	}
+	return values[0], true
+}
+
+// convertOrderEventToProto converts an order event to a gRPC ExecutionReport message
+func (s *GRPCServer) convertOrderEventToProto(event *models.OrderEvent) *grpcapi.ExecutionReport {
+	order := event.Order
+	report := &grpcapi.ExecutionReport{
+		OrderId:            order.ID,
+		ClientOrderId:      order.ClientOID,
+		Instrument:         order.Instrument,
+		Side:               s.convertOrderSideToProto(order.Side),
+		Status:             s.convertOrderStatusToProto(order.Status),
+		OldStatus:          s.convertOrderStatusToProto(event.OldStatus),
+		Price:              order.Price.String(),
+		Quantity:           order.Quantity.String(),
+		CumulativeQuantity: order.Filled().String(),
+		RemainingQuantity:  order.Remaining.String(),
+		RejectReason:       s.convertRejectReasonToProto(event.Reason),
+		Timestamp:          event.Timestamp.UnixNano(),
+		AveragePrice:       s.convertAveragePrice(order),
+	}
+	if event.TradeID != 0 {
+		report.LastQuantity = event.TradeSize.String()
+		report.LastPrice = event.TradePrice.String()
+		report.TradeId = event.TradeID
+		report.ExecutionId = event.ExecutionID
+	}
+	return report
+}
+
+// GetOrder returns the latest state of an order, found by its ID or by the
+// account's client order ID. An account given with an order ID must own it.
+func (s *GRPCServer) GetOrder(ctx context.Context, req *grpcapi.GetOrderRequest) (*grpcapi.OrderState, error) {
+	var order *models.Order
+	var err error
+	switch id := req.Id.(type) {
+	case *grpcapi.GetOrderRequest_OrderId:
+		order, err = s.engine.GetOrder(id.OrderId)
+		if err == nil && req.Account != "" && order.Account != req.Account {
+			err = engine.ErrOrderNotFound
+		}
+	case *grpcapi.GetOrderRequest_ClientOrderId:
+		order, err = s.engine.GetOrderByClientID(req.Account, id.ClientOrderId)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "order_id or client_order_id is required")
+	}
+	if err != nil {
+		return nil, s.convertEngineError(err)
+	}
+	return s.convertOrderStateToProto(order), nil
+}
+
+// ListOpenOrders returns the account's open orders on one instrument or all of them
+func (s *GRPCServer) ListOpenOrders(ctx context.Context, req *grpcapi.ListOpenOrdersRequest) (*grpcapi.ListOpenOrdersResponse, error) {
+	if req.Account == "" {
+		return nil, status.Error(codes.InvalidArgument, "account is required")
+	}
+	orders, err := s.engine.ListOpenOrders(req.Account, req.Instrument)
+	if err != nil {
+		return nil, s.convertEngineError(err)
+	}
+	response := &grpcapi.ListOpenOrdersResponse{Orders: make([]*grpcapi.OrderState, len(orders))}
+	for i, order := range orders {
+		response.Orders[i] = s.convertOrderStateToProto(order)
+	}
+	return response, nil
+}
+
+// convertOrderStateToProto converts an order's latest state to a gRPC OrderState message
+func (s *GRPCServer) convertOrderStateToProto(order *models.Order) *grpcapi.OrderState {
+	return &grpcapi.OrderState{
+		OrderId:           order.ID,
+		ClientOrderId:     order.ClientOID,
+		Instrument:        order.Instrument,
+		Account:           order.Account,
+		Side:              s.convertOrderSideToProto(order.Side),
+		OrderType:         s.convertOrderTypeToProto(order.Type),
+		Status:            s.convertOrderStatusToProto(order.Status),
+		Price:             order.Price.String(),
+		Quantity:          order.Quantity.String(),
+		FilledQuantity:    order.Filled().String(),
+		RemainingQuantity: order.Remaining.String(),
+		AveragePrice:      s.convertAveragePrice(order),
+		Timestamp:         order.Timestamp.UnixNano(),
+		LastUpdated:       order.LastUpdated.UnixNano(),
+	}
+}
+
+// convertAveragePrice formats the order's average fill price, empty before its first fill
+func (s *GRPCServer) convertAveragePrice(order *models.Order) string {
+	if order.Filled().Sign() <= 0 {
+		return ""
+	}
+	return order.AveragePrice().String()
+}
+
+// convertL3UpdateToProto converts an order-by-order update to a gRPC L3Update message
+func (s *GRPCServer) convertL3UpdateToProto(update *engine.L3Update) *grpcapi.L3Update {
+	result := &grpcapi.L3Update{
+		Instrument: update.Instrument,
+		Sequence:   update.Sequence,
+		Timestamp:  update.Timestamp,
+	}
+	if update.Snapshot {
+		result.Snapshot = &grpcapi.L3Snapshot{
+			Bids: s.convertRestingOrdersToProto(update.Bids),
+			Asks: s.convertRestingOrdersToProto(update.Asks),
+		}
+		return result
+	}
+
+	result.Events = make([]*grpcapi.L3Event, len(update.Events))
+	for i, event := range update.Events {
+		converted := &grpcapi.L3Event{}
+		switch event.Type {
+		case engine.L3Add:
+			converted.Event = &grpcapi.L3Event_Added{Added: &grpcapi.OrderAdded{
+				OrderId:  event.OrderID,
+				Side:     s.convertOrderSideToProto(event.Side),
+				Price:    event.Price.String(),
+				Quantity: event.Quantity.String(),
+			}}
+		case engine.L3Modify:
+			converted.Event = &grpcapi.L3Event_Modified{Modified: &grpcapi.OrderModified{
+				OrderId:  event.OrderID,
+				Quantity: event.Quantity.String(),
+			}}
+		case engine.L3Delete:
+			converted.Event = &grpcapi.L3Event_Deleted{Deleted: &grpcapi.OrderDeleted{OrderId: event.OrderID}}
+		case engine.L3Execute:
+			converted.Event = &grpcapi.L3Event_Executed{Executed: &grpcapi.OrderExecuted{
+				OrderId:  event.OrderID,
+				Price:    event.Price.String(),
+				Quantity: event.Quantity.String(),
+				TradeId:  event.TradeID,
+			}}
+		}
+		result.Events[i] = converted
+	}
+	return result
+}
+
+// convertRestingOrdersToProto converts L3 snapshot orders to gRPC RestingOrder messages
+func (s *GRPCServer) convertRestingOrdersToProto(orders []engine.L3Order) []*grpcapi.RestingOrder {
+	result := make([]*grpcapi.RestingOrder, len(orders))
+	for i, order := range orders {
+		result[i] = &grpcapi.RestingOrder{
+			OrderId:  order.OrderID,
+			Price:    order.Price.String(),
+			Quantity: order.Quantity.String(),
+		}
+	}
+	return result
+}
+
 // convertTradeToProto converts internal trade to gRPC Trade message
 func (s *GRPCServer) convertTradeToProto(trade *models.Trade) *grpcapi.Trade {
 	return &grpcapi.Trade{
 		TradeId:      trade.TradeID,
 		ExecutionId:  trade.ExecutionID,
-		Price:        trade.Price,
-		Quantity:     trade.Quantity,
+		Price:        trade.Price.String(),
+		Quantity:     trade.Quantity.String(),
 		Timestamp:    trade.Timestamp,
 		MakerOrderId: trade.MakerOrderID,
 		TakerOrderId: trade.TakerOrderID,
@@ -219,6 +742,110 @@ func (s *GRPCServer) convertTradeToProto(trade *models.Trade) *grpcapi.Trade {
 	}
 }
 
+// convertCandleToProto converts a candle to a gRPC Candle message
+func (s *GRPCServer) convertCandleToProto(candle *models.Candle) *grpcapi.Candle {
+	return &grpcapi.Candle{
+		Instrument: candle.Instrument,
+		Interval:   s.convertCandleIntervalToProto(candle.Interval),
+		StartTime:  candle.Start,
+		Open:       candle.Open.String(),
+		High:       candle.High.String(),
+		Low:        candle.Low.String(),
+		Close:      candle.Close.String(),
+		Volume:     candle.Volume.String(),
+		TradeCount: uint32(candle.Trades),
+	}
+}
+
+// convertBookUpdateToProto converts an L2 book update to a gRPC OrderBookUpdate message
+func (s *GRPCServer) convertBookUpdateToProto(update *engine.BookUpdate) *grpcapi.OrderBookUpdate {
+	return &grpcapi.OrderBookUpdate{
+		Bids:     s.convertPriceLevelsToProto(update.Bids),
+		Asks:     s.convertPriceLevelsToProto(update.Asks),
+		Sequence: update.Sequence,
+		Snapshot: update.Snapshot,
+	}
+}
+
+// convertPriceLevelsToProto converts aggregated price levels to gRPC PriceLevel messages
+func (s *GRPCServer) convertPriceLevelsToProto(levels []engine.PriceLevel) []*grpcapi.PriceLevel {
+	result := make([]*grpcapi.PriceLevel, len(levels))
+	for i, level := range levels {
+		result[i] = &grpcapi.PriceLevel{
+			Price:      level.Price.String(),
+			Quantity:   level.Quantity.String(),
+			OrderCount: uint32(level.Orders),
+		}
+	}
+	return result
+}
+
+// convertOrderStatusToProto converts internal OrderStatus to gRPC OrderStatus
+func (s *GRPCServer) convertOrderStatusToProto(st models.OrderStatus) grpcapi.OrderStatus {
+	switch st {
+	case models.Partial:
+		return grpcapi.OrderStatus_PARTIALLY_FILLED
+	case models.Filled:
+		return grpcapi.OrderStatus_FILLED
+	case models.Cancelled:
+		return grpcapi.OrderStatus_CANCELLED
+	case models.Rejected:
+		return grpcapi.OrderStatus_REJECTED
+	default:
+		return grpcapi.OrderStatus_PENDING
+	}
+}
+
+// convertOrderTypeToProto converts internal OrderType to gRPC OrderType
+func (s *GRPCServer) convertOrderTypeToProto(t models.OrderType) grpcapi.OrderType {
+	switch t {
+	case models.Market:
+		return grpcapi.OrderType_MARKET
+	case models.IOC:
+		return grpcapi.OrderType_IOC
+	case models.FOK:
+		return grpcapi.OrderType_FOK
+	case models.PostOnly:
+		return grpcapi.OrderType_POST_ONLY
+	default:
+		return grpcapi.OrderType_LIMIT
+	}
+}
+
+// convertCandleIntervalToProto converts a candle interval to gRPC CandleInterval
+func (s *GRPCServer) convertCandleIntervalToProto(interval time.Duration) grpcapi.CandleInterval {
+	switch interval {
+	case time.Minute:
+		return grpcapi.CandleInterval_INTERVAL_1M
+	case 5 * time.Minute:
+		return grpcapi.CandleInterval_INTERVAL_5M
+	case time.Hour:
+		return grpcapi.CandleInterval_INTERVAL_1H
+	default:
+		return grpcapi.CandleInterval_INTERVAL_1S
+	}
+}
+
+// convertRejectReasonToProto converts internal RejectReason to gRPC RejectReason
+func (s *GRPCServer) convertRejectReasonToProto(reason models.RejectReason) grpcapi.RejectReason {
+	switch reason {
+	case models.ReasonInsufficientLiquidity:
+		return grpcapi.RejectReason_INSUFFICIENT_LIQUIDITY
+	case models.ReasonPostOnlyWouldCross:
+		return grpcapi.RejectReason_POST_ONLY_WOULD_CROSS
+	case models.ReasonInvalidPrecision:
+		return grpcapi.RejectReason_INVALID_PRECISION
+	case models.ReasonInstrumentNotTrading:
+		return grpcapi.RejectReason_INSTRUMENT_NOT_TRADING
+	case models.ReasonExpired:
+		return grpcapi.RejectReason_EXPIRED
+	case models.ReasonNotionalOutOfRange:
+		return grpcapi.RejectReason_NOTIONAL_OUT_OF_RANGE
+	default:
+		return grpcapi.RejectReason_NO_REJECT
+	}
+}
+
 // convertOrderSideToProto converts internal OrderSide to gRPC OrderSide
 func (s *GRPCServer) convertOrderSideToProto(side models.OrderSide) grpcapi.OrderSide {
 	if side == models.Buy {
@@ -0,0 +1,209 @@
+package protocol
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	grpcapi "github.com/aeromatch/api/grpc"
+	"github.com/aeromatch/internal/engine"
+	"github.com/aeromatch/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// startServer serves a running engine with one instrument, BTC-USD, on a free port
+func startServer(t *testing.T) grpcapi.TradingClient {
+	t.Helper()
+	m := engine.NewMatchingEngine(64, 64, engine.WaitPark)
+	if _, err := m.AddInstrument(&models.Instrument{
+		Symbol:   "BTC-USD",
+		TickSize: models.MustParseDecimal("0.01"),
+		LotSize:  models.MustParseDecimal("1"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	m.Start()
+	t.Cleanup(m.Stop)
+
+	s, err := NewGRPCServer(m, 0, 4<<20, engine.SubscriptionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Start()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient(s.listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return grpcapi.NewTradingClient(conn)
+}
+
+// TestTradingRPCs calls every unary Trading RPC, so none is left unimplemented
+func TestTradingRPCs(t *testing.T) {
+	client := startServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	submit := func(side grpcapi.OrderSide, price, qty string) *grpcapi.OrderResponse {
+		t.Helper()
+		response, err := client.SubmitOrder(ctx, &grpcapi.OrderRequest{
+			Instrument:    "BTC-USD",
+			Account:       "alice",
+			Side:          side,
+			OrderType:     grpcapi.OrderType_LIMIT,
+			Price:         price,
+			Quantity:      qty,
+			WaitForResult: true,
+		})
+		if err != nil {
+			t.Fatalf("SubmitOrder: %v", err)
+		}
+		return response
+	}
+	resting := submit(grpcapi.OrderSide_SELL, "101", "5")
+	cancelled := submit(grpcapi.OrderSide_SELL, "102", "3")
+
+	amended, err := client.AmendOrder(ctx, &grpcapi.AmendOrderRequest{Instrument: "BTC-USD", Account: "alice", OrderId: resting.OrderId, Quantity: "4"})
+	if err != nil || amended.Quantity != "4" || amended.RemainingQuantity != "4" {
+		t.Fatalf("AmendOrder: got %v, %v", amended, err)
+	}
+
+	cancelResponse, err := client.CancelOrder(ctx, &grpcapi.CancelOrderRequest{Instrument: "BTC-USD", Account: "alice", OrderId: cancelled.OrderId})
+	if err != nil || cancelResponse.Status != grpcapi.OrderStatus_CANCELLED || cancelResponse.RemainingQuantity != "3" {
+		t.Fatalf("CancelOrder: got %v, %v", cancelResponse, err)
+	}
+	if _, err := client.CancelOrder(ctx, &grpcapi.CancelOrderRequest{Instrument: "BTC-USD", Account: "alice", OrderId: cancelled.OrderId}); status.Code(err) != codes.NotFound {
+		t.Fatalf("CancelOrder again: got %v, want NotFound", err)
+	}
+
+	book, err := client.GetOrderBook(ctx, &grpcapi.OrderBookRequest{Instrument: "BTC-USD", Fresh: true})
+	if err != nil || len(book.Asks) != 1 || book.Asks[0].Quantity != "4" || len(book.Bids) != 0 {
+		t.Fatalf("GetOrderBook: got %v, %v", book, err)
+	}
+
+	filled := submit(grpcapi.OrderSide_BUY, "101", "1")
+	if filled.Status != grpcapi.OrderStatus_FILLED || len(filled.Fills) != 1 {
+		t.Fatalf("crossing order: got %v", filled)
+	}
+
+	state, err := client.GetOrder(ctx, &grpcapi.GetOrderRequest{Id: &grpcapi.GetOrderRequest_OrderId{OrderId: resting.OrderId}})
+	if err != nil || state.Status != grpcapi.OrderStatus_PARTIALLY_FILLED || state.AveragePrice != "101.00" {
+		t.Fatalf("GetOrder: got %v, %v", state, err)
+	}
+	open, err := client.ListOpenOrders(ctx, &grpcapi.ListOpenOrdersRequest{Account: "alice"})
+	if err != nil || len(open.Orders) != 1 || open.Orders[0].OrderId != resting.OrderId {
+		t.Fatalf("ListOpenOrders: got %v, %v", open, err)
+	}
+	trades, err := client.GetRecentTrades(ctx, &grpcapi.RecentTradesRequest{Instrument: "BTC-USD"})
+	if err != nil || len(trades.Trades) != 1 {
+		t.Fatalf("GetRecentTrades: got %v, %v", trades, err)
+	}
+	candles, err := client.GetCandles(ctx, &grpcapi.CandlesRequest{Instrument: "BTC-USD", Interval: grpcapi.CandleInterval_INTERVAL_1H})
+	if err != nil || len(candles.Candles) != 1 || candles.Candles[0].TradeCount != 1 {
+		t.Fatalf("GetCandles: got %v, %v", candles, err)
+	}
+}
+
+// TestOrdersOwnedByAccount checks that cancels, amends and order updates only
+// reach the caller's own orders
+func TestOrdersOwnedByAccount(t *testing.T) {
+	client := startServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	submit := func(account string) uint64 {
+		response, err := client.SubmitOrder(ctx, &grpcapi.OrderRequest{
+			Instrument:    "BTC-USD",
+			Account:       account,
+			Side:          grpcapi.OrderSide_SELL,
+			OrderType:     grpcapi.OrderType_LIMIT,
+			Price:         "101",
+			Quantity:      "1",
+			WaitForResult: true,
+		})
+		if err != nil {
+			t.Errorf("SubmitOrder: %v", err)
+			return 0
+		}
+		return response.OrderId
+	}
+	id := submit("alice")
+
+	if _, err := client.CancelOrder(ctx, &grpcapi.CancelOrderRequest{Instrument: "BTC-USD", OrderId: id}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("CancelOrder without account: got %v, want InvalidArgument", err)
+	}
+	if _, err := client.CancelOrder(ctx, &grpcapi.CancelOrderRequest{Instrument: "BTC-USD", Account: "bob", OrderId: id}); status.Code(err) != codes.NotFound {
+		t.Fatalf("CancelOrder by another account: got %v, want NotFound", err)
+	}
+	if _, err := client.AmendOrder(ctx, &grpcapi.AmendOrderRequest{Instrument: "BTC-USD", Account: "bob", OrderId: id, Quantity: "2"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("AmendOrder by another account: got %v, want NotFound", err)
+	}
+	state, err := client.GetOrder(ctx, &grpcapi.GetOrderRequest{Id: &grpcapi.GetOrderRequest_OrderId{OrderId: id}})
+	if err != nil || state.Status != grpcapi.OrderStatus_PENDING || state.Quantity != "1" {
+		t.Fatalf("order after another account's cancel and amend: got %v, %v", state, err)
+	}
+
+	anonymous, err := client.OrderUpdates(ctx, &grpcapi.OrderUpdatesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := anonymous.Recv(); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("OrderUpdates without account metadata: got %v, want Unauthenticated", err)
+	}
+
+	updates, err := client.OrderUpdates(metadata.AppendToOutgoingContext(ctx, "account", "alice"), &grpcapi.OrderUpdatesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The subscription starts at some point after the call returns, so keep
+	// both accounts submitting until a report arrives
+	done, stopped := make(chan struct{}), make(chan struct{})
+	defer func() { close(done); <-stopped }()
+	go func() {
+		defer close(stopped)
+		for {
+			submit("bob")
+			submit("alice")
+			select {
+			case <-done:
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}()
+	report, err := updates.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetOrder(ctx, &grpcapi.GetOrderRequest{Id: &grpcapi.GetOrderRequest_OrderId{OrderId: report.OrderId}, Account: "alice"}); err != nil {
+		t.Fatalf("OrderUpdates for alice reported order %d: %v", report.OrderId, err)
+	}
+}
+
+// TestSubmitOrderRejectsDoubleDecimals sends an order the way clients built before
+// prices became strings did, with doubles in the reserved fields 3 and 4
+func TestSubmitOrderRejectsDoubleDecimals(t *testing.T) {
+	client := startServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := &grpcapi.OrderRequest{Instrument: "BTC-USD", Account: "alice", Side: grpcapi.OrderSide_BUY, OrderType: grpcapi.OrderType_LIMIT}
+	var legacy []byte
+	legacy = protowire.AppendTag(legacy, 3, protowire.Fixed64Type)
+	legacy = protowire.AppendFixed64(legacy, math.Float64bits(101))
+	legacy = protowire.AppendTag(legacy, 4, protowire.Fixed64Type)
+	legacy = protowire.AppendFixed64(legacy, math.Float64bits(2))
+	req.ProtoReflect().SetUnknown(legacy)
+
+	if _, err := client.SubmitOrder(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("SubmitOrder with double price and quantity: got %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,309 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/aeromatch/internal/engine"
+)
+
+// Journal file layout: a magic header, then records of
+// [payload length uint32][CRC-32C of payload uint32][JSON payload], little-endian.
+// The key-value store uses the same record framing.
+var journalMagic = []byte("AMJ1")
+
+const (
+	recordHeaderSize = 8
+	maxRecordSize    = 16 << 20 // Anything larger is treated as a damaged length
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+var (
+	ErrJournalClosed  = errors.New("journal closed")
+	ErrJournalCorrupt = errors.New("journal corrupt")
+
+	errBadHeader = fmt.Errorf("%w: bad header", ErrJournalCorrupt)
+)
+
+// JournalOptions controls how appends are batched into fsyncs
+type JournalOptions struct {
+	SyncInterval time.Duration // Longest an entry waits for fsync; zero syncs on every append
+	SyncBatch    int           // Sync as soon as this many entries are pending; zero means interval only
+}
+
+// FileJournal is an append-only, checksummed engine journal in a single file.
+// Appends are buffered and made durable by group commit: one fsync covers every
+// entry written since the previous one.
+type FileJournal struct {
+	mu       sync.Mutex
+	file     *os.File
+	w        *bufio.Writer
+	opts     JournalOptions
+	buf      []byte         // Record being framed, reused
+	waiters  []chan<- error // Callers waiting for entries not yet synced
+	unsynced int
+	err      error // Sticky: once a write or sync fails the journal refuses appends
+
+	kick chan struct{}
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// OpenFileJournal opens or creates the journal at path. A torn or damaged tail left
+// by a crash is cut off: everything from the first bad record on is discarded.
+func OpenFileJournal(path string, opts JournalOptions) (*FileJournal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := scanRecords(file, journalMagic, func([]byte) error { return nil })
+	if err != nil && (end == 0 || !errors.Is(err, ErrJournalCorrupt)) {
+		file.Close()
+		return nil, err // Not a journal, or unreadable: leave it alone
+	}
+	if end == 0 {
+		if _, err := file.WriteAt(journalMagic, 0); err != nil {
+			file.Close()
+			return nil, err
+		}
+		end = int64(len(journalMagic))
+	}
+	if err := file.Truncate(end); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(end, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	j := &FileJournal{
+		file: file,
+		w:    bufio.NewWriterSize(file, 1<<16),
+		opts: opts,
+		kick: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+	}
+	if opts.SyncInterval > 0 {
+		j.wg.Add(1)
+		go j.syncLoop()
+	}
+	return j, nil
+}
+
+// Append encodes and writes the entry; durable is signalled by the next sync
+func (j *FileJournal) Append(entry *engine.JournalEntry, durable chan<- error) error {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.err != nil {
+		return j.err
+	}
+
+	j.buf = appendRecord(j.buf[:0], payload)
+	if _, err := j.w.Write(j.buf); err != nil {
+		j.err = err
+		return err
+	}
+
+	if durable != nil {
+		j.waiters = append(j.waiters, durable)
+	}
+	j.unsynced++
+
+	switch {
+	case j.opts.SyncInterval <= 0:
+		j.syncLocked()
+	case j.opts.SyncBatch > 0 && j.unsynced >= j.opts.SyncBatch:
+		select {
+		case j.kick <- struct{}{}:
+		default: // A sync is already due
+		}
+	}
+	return nil
+}
+
+// Sync flushes and fsyncs everything appended so far
+func (j *FileJournal) Sync() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.syncLocked()
+	return j.err
+}
+
+// Close syncs outstanding entries and closes the file
+func (j *FileJournal) Close() error {
+	close(j.stop)
+	j.wg.Wait()
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.syncLocked()
+	err := j.err
+	if closeErr := j.file.Close(); err == nil {
+		err = closeErr
+	}
+	j.err = ErrJournalClosed
+	return err
+}
+
+// syncLoop syncs on every interval tick, or sooner when a batch fills up
+func (j *FileJournal) syncLoop() {
+	defer j.wg.Done()
+	ticker := time.NewTicker(j.opts.SyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-j.kick:
+		case <-j.stop:
+			return
+		}
+		j.sync()
+	}
+}
+
+// sync flushes under the lock but fsyncs outside it, so appends carry on meanwhile
+func (j *FileJournal) sync() {
+	j.mu.Lock()
+	if j.unsynced == 0 || j.err != nil {
+		j.mu.Unlock()
+		return
+	}
+	err := j.w.Flush()
+	waiters := j.waiters
+	j.waiters, j.unsynced = nil, 0
+	if err != nil {
+		j.err = err
+	}
+	j.mu.Unlock()
+
+	if err == nil {
+		err = j.file.Sync()
+	}
+	if err != nil {
+		j.mu.Lock()
+		j.err = err
+		j.mu.Unlock()
+	}
+	for _, waiter := range waiters {
+		waiter <- err
+	}
+}
+
+func (j *FileJournal) syncLocked() {
+	if j.unsynced == 0 || j.err != nil {
+		j.notifyLocked(j.err)
+		return
+	}
+	err := j.w.Flush()
+	if err == nil {
+		err = j.file.Sync()
+	}
+	j.err = err
+	j.unsynced = 0
+	j.notifyLocked(err)
+}
+
+func (j *FileJournal) notifyLocked(err error) {
+	for _, waiter := range j.waiters {
+		waiter <- err
+	}
+	j.waiters = nil
+}
+
+// ReadJournal calls fn for every intact entry in the journal at path, oldest first.
+// A damaged tail ends the read without error, matching what OpenFileJournal keeps.
+func ReadJournal(path string, fn func(*engine.JournalEntry) error) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = scanRecords(file, journalMagic, func(payload []byte) error {
+		var entry engine.JournalEntry
+		if err := json.Unmarshal(payload, &entry); err != nil {
+			return fmt.Errorf("decode journal entry: %w", err)
+		}
+		return fn(&entry)
+	})
+	if errors.Is(err, ErrJournalCorrupt) && !errors.Is(err, errBadHeader) {
+		return nil // Damaged tail
+	}
+	return err
+}
+
+// appendRecord frames payload as a record and appends it to buf
+func appendRecord(buf, payload []byte) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(payload)))
+	buf = binary.LittleEndian.AppendUint32(buf, crc32.Checksum(payload, crcTable))
+	return append(buf, payload...)
+}
+
+// scanRecords checks the file's magic, then passes each intact record's payload to
+// fn and returns the offset just past the last intact record. It reports
+// ErrJournalCorrupt if it stopped early. The payload is only valid during the call.
+func scanRecords(r io.ReaderAt, want []byte, fn func([]byte) error) (int64, error) {
+	magic := make([]byte, len(want))
+	if n, err := r.ReadAt(magic, 0); n == 0 && errors.Is(err, io.EOF) {
+		return 0, nil // New file
+	} else if n < len(magic) || !bytes.Equal(magic, want) {
+		return 0, errBadHeader
+	}
+
+	reader := bufio.NewReaderSize(io.NewSectionReader(r, int64(len(magic)), 1<<62), 1<<16)
+	offset := int64(len(magic))
+	var header [recordHeaderSize]byte
+	var payload []byte
+	for {
+		if _, err := io.ReadFull(reader, header[:]); err == io.EOF {
+			return offset, nil
+		} else if err != nil {
+			return offset, fmt.Errorf("%w: torn header at %d", ErrJournalCorrupt, offset)
+		}
+
+		size := binary.LittleEndian.Uint32(header[0:4])
+		if size > maxRecordSize {
+			return offset, fmt.Errorf("%w: bad length at %d", ErrJournalCorrupt, offset)
+		}
+		if cap(payload) < int(size) {
+			payload = make([]byte, size)
+		}
+		payload = payload[:size]
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			return offset, fmt.Errorf("%w: torn record at %d", ErrJournalCorrupt, offset)
+		}
+		if crc32.Checksum(payload, crcTable) != binary.LittleEndian.Uint32(header[4:8]) {
+			return offset, fmt.Errorf("%w: checksum mismatch at %d", ErrJournalCorrupt, offset)
+		}
+
+		if err := fn(payload); err != nil {
+			return offset, err
+		}
+		offset += recordHeaderSize + int64(size)
+	}
+}
@@ -0,0 +1,138 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aeromatch/internal/engine"
+	"github.com/aeromatch/internal/models"
+)
+
+func appendOrders(t *testing.T, j *FileJournal, from, to uint64) []chan error {
+	t.Helper()
+	var waiters []chan error
+	for seq := from; seq <= to; seq++ {
+		durable := make(chan error, 1)
+		err := j.Append(&engine.JournalEntry{
+			Type:       engine.JournalOrder,
+			Sequence:   seq,
+			Instrument: "BTC-USD",
+			Order: &models.Order{
+				ID:       seq,
+				Price:    models.MustParseDecimal("67012.50"),
+				Quantity: models.MustParseDecimal("0.015"),
+			},
+		}, durable)
+		if err != nil {
+			t.Fatal(err)
+		}
+		waiters = append(waiters, durable)
+	}
+	return waiters
+}
+
+func readSequences(t *testing.T, path string) []uint64 {
+	t.Helper()
+	var seqs []uint64
+	err := ReadJournal(path, func(entry *engine.JournalEntry) error {
+		if entry.Order.Price.String() != "67012.50" {
+			t.Fatalf("entry %d price %v did not round-trip", entry.Sequence, entry.Order.Price)
+		}
+		seqs = append(seqs, entry.Sequence)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return seqs
+}
+
+func TestJournalGroupCommit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.log")
+	j, err := OpenFileJournal(path, JournalOptions{SyncInterval: time.Hour, SyncBatch: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	waiters := appendOrders(t, j, 1, 3)
+	select {
+	case <-waiters[0]:
+		t.Fatal("acknowledged before the batch filled or the interval passed")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// The fourth entry fills the batch; one fsync covers all four
+	waiters = append(waiters, appendOrders(t, j, 4, 4)...)
+	for i, durable := range waiters {
+		select {
+		case err := <-durable:
+			if err != nil {
+				t.Fatalf("entry %d: %v", i+1, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("entry %d never acknowledged", i+1)
+		}
+	}
+
+	if err := j.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := readSequences(t, path); len(got) != 4 {
+		t.Fatalf("read back %v, want 4 entries", got)
+	}
+}
+
+func TestJournalDropsTornTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.log")
+	j, err := OpenFileJournal(path, JournalOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	appendOrders(t, j, 1, 3)
+	j.Close()
+
+	// Simulate a crash halfway through writing a fourth record
+	info, _ := os.Stat(path)
+	intact := info.Size()
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	f.Write([]byte{200, 0, 0, 0, 1, 2, 3, 4, '{', '"'})
+	f.Close()
+
+	if got := readSequences(t, path); len(got) != 3 {
+		t.Fatalf("read back %v, want the 3 intact entries", got)
+	}
+
+	j, err = OpenFileJournal(path, JournalOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Stat(path); info.Size() != intact {
+		t.Fatalf("size %d after reopen, want torn tail cut back to %d", info.Size(), intact)
+	}
+	appendOrders(t, j, 4, 5)
+	j.Close()
+
+	got := readSequences(t, path)
+	for i, seq := range got {
+		if seq != uint64(i+1) {
+			t.Fatalf("read back %v, want 1..5", got)
+		}
+	}
+	if len(got) != 5 {
+		t.Fatalf("read back %v, want 1..5", got)
+	}
+}
+
+func TestJournalRejectsForeignFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.log")
+	os.WriteFile(path, []byte("not a journal"), 0o644)
+
+	if _, err := OpenFileJournal(path, JournalOptions{}); err == nil {
+		t.Fatal("opened a file without the journal header")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "not a journal" {
+		t.Fatal("foreign file was modified")
+	}
+}
@@ -0,0 +1,330 @@
+package store
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/aeromatch/internal/engine"
+)
+
+// KV file layout: a magic header, then records framed like the journal's whose
+// payload is [op byte][key length uvarint][key][value]. The newest record for a key wins.
+var kvMagic = []byte("AMK1")
+
+const (
+	kvPut    byte = 1
+	kvDelete byte = 2
+
+	kvCompactMin = 1 << 20 // Logs smaller than this are never compacted
+)
+
+var ErrKVClosed = errors.New("key-value store closed")
+
+// kvCreateTemp creates the file a compaction writes the new log to; tests replace it
+var kvCreateTemp = os.CreateTemp
+
+// kvValue is a live value and the size of the record that holds it
+type kvValue struct {
+	data []byte
+	size int64
+}
+
+// KV is a small embedded key-value store: an append-only log of puts and deletes,
+// with every live value held in memory. Writes are fsynced before they return, and
+// the log is rewritten once more than half of it is stale.
+type KV struct {
+	mu     sync.Mutex
+	path   string
+	file   *os.File
+	values map[string]kvValue
+	size   int64 // Bytes in the log
+	live   int64 // Bytes of records that are still current
+	buf    []byte
+	err    error // Sticky: a failed write may have left a torn record
+}
+
+// OpenKV opens or creates the store at path, cutting off a torn tail left by a crash
+func OpenKV(path string) (*KV, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	kv := &KV{path: path, file: file, values: make(map[string]kvValue)}
+	end, err := scanRecords(file, kvMagic, kv.load)
+	if err != nil && (end == 0 || !errors.Is(err, ErrJournalCorrupt)) {
+		file.Close()
+		return nil, err
+	}
+	if end == 0 {
+		if _, err := file.WriteAt(kvMagic, 0); err != nil {
+			file.Close()
+			return nil, err
+		}
+		end = int64(len(kvMagic))
+	}
+	if err := file.Truncate(end); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(end, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	kv.size = end
+	return kv, nil
+}
+
+// load applies one record read back from the log
+func (kv *KV) load(payload []byte) error {
+	if len(payload) < 1 {
+		return fmt.Errorf("%w: empty record", ErrJournalCorrupt)
+	}
+	keyLen, n := binary.Uvarint(payload[1:])
+	if n <= 0 || uint64(len(payload)-1-n) < keyLen {
+		return fmt.Errorf("%w: bad key length", ErrJournalCorrupt)
+	}
+	key := string(payload[1+n : 1+n+int(keyLen)])
+	size := int64(recordHeaderSize + len(payload))
+
+	kv.drop(key)
+	switch payload[0] {
+	case kvPut:
+		kv.values[key] = kvValue{data: slices.Clone(payload[1+n+int(keyLen):]), size: size}
+		kv.live += size
+	case kvDelete:
+	default:
+		return fmt.Errorf("%w: unknown op %d", ErrJournalCorrupt, payload[0])
+	}
+	return nil
+}
+
+// Get returns the value stored under key; the caller must not modify it
+func (kv *KV) Get(key string) ([]byte, bool) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	value, ok := kv.values[key]
+	return value.data, ok
+}
+
+// Keys returns the keys starting with prefix in ascending order
+func (kv *KV) Keys(prefix string) []string {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	var keys []string
+	for key := range kv.values {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Put durably stores value under key
+func (kv *KV) Put(key string, value []byte) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	size, err := kv.write(kvPut, key, value)
+	if err != nil {
+		return err
+	}
+	kv.drop(key)
+	kv.values[key] = kvValue{data: slices.Clone(value), size: size}
+	kv.live += size
+	return kv.maybeCompact()
+}
+
+// Delete durably removes key
+func (kv *KV) Delete(key string) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	if _, ok := kv.values[key]; !ok {
+		return nil
+	}
+	if _, err := kv.write(kvDelete, key, nil); err != nil {
+		return err
+	}
+	kv.drop(key)
+	return kv.maybeCompact()
+}
+
+// Close closes the log file
+func (kv *KV) Close() error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	if kv.err == ErrKVClosed {
+		return nil
+	}
+	kv.err = ErrKVClosed
+	return kv.file.Close()
+}
+
+func (kv *KV) drop(key string) {
+	if old, ok := kv.values[key]; ok {
+		kv.live -= old.size
+		delete(kv.values, key)
+	}
+}
+
+// write appends and syncs one record, returning its size on disk
+func (kv *KV) write(op byte, key string, value []byte) (int64, error) {
+	if kv.err != nil {
+		return 0, kv.err
+	}
+	kv.buf = appendRecord(kv.buf[:0], kvPayload(op, key, value))
+	if _, err := kv.file.Write(kv.buf); err != nil {
+		kv.err = err
+		return 0, err
+	}
+	if err := kv.file.Sync(); err != nil {
+		kv.err = err
+		return 0, err
+	}
+	kv.size += int64(len(kv.buf))
+	return int64(len(kv.buf)), nil
+}
+
+func kvPayload(op byte, key string, value []byte) []byte {
+	payload := make([]byte, 0, 1+binary.MaxVarintLen64+len(key)+len(value))
+	payload = append(payload, op)
+	payload = binary.AppendUvarint(payload, uint64(len(key)))
+	payload = append(payload, key...)
+	return append(payload, value...)
+}
+
+// maybeCompact rewrites the log with only live records once most of it is stale.
+// The new log is synced and renamed over the old one, so a crash leaves either intact.
+func (kv *KV) maybeCompact() error {
+	if kv.size < kvCompactMin || kv.live*2 > kv.size {
+		return nil
+	}
+
+	tmp, err := kvCreateTemp(filepath.Dir(kv.path), filepath.Base(kv.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	buf := append([]byte(nil), kvMagic...)
+	for key, value := range kv.values {
+		buf = appendRecord(buf, kvPayload(kvPut, key, value.data))
+	}
+	_, err = tmp.Write(buf)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), kv.path)
+	}
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err // The old log is still complete
+	}
+
+	// The old log is gone from the directory, so writes must go to the new one
+	// even if the rename cannot be made durable
+	kv.file.Close()
+	kv.file = tmp // Still positioned at its end
+	kv.size, kv.live = int64(len(buf)), int64(len(buf)-len(kvMagic))
+	return syncDir(filepath.Dir(kv.path))
+}
+
+// KVSnapshotStore keeps snapshots in a KV, keyed by instrument and big-endian
+// sequence so that a key scan returns them oldest first
+type KVSnapshotStore struct {
+	kv   *KV
+	opts SnapshotOptions
+}
+
+// NewKVSnapshotStore stores snapshots in kv, which it closes on Close
+func NewKVSnapshotStore(kv *KV, opts SnapshotOptions) *KVSnapshotStore {
+	return &KVSnapshotStore{kv: kv, opts: opts}
+}
+
+// SaveSnapshot stores the snapshot and drops the instrument's oldest beyond retention
+func (s *KVSnapshotStore) SaveSnapshot(snapshot *engine.OrderBookSnapshot) error {
+	data, err := snapshot.Encode(s.opts.Format)
+	if err != nil {
+		return err
+	}
+	if err := s.kv.Put(snapshotKey(snapshot.Instrument, snapshot.Sequence), data); err != nil {
+		return err
+	}
+
+	keys := s.kv.Keys(snapshotPrefix(snapshot.Instrument))
+	for _, key := range keys[:max(len(keys)-s.opts.retain(), 0)] {
+		if err := s.kv.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadSnapshot returns the instrument's latest snapshot
+func (s *KVSnapshotStore) LoadSnapshot(instrument string) (*engine.OrderBookSnapshot, error) {
+	keys := s.kv.Keys(snapshotPrefix(instrument))
+	if len(keys) == 0 {
+		return nil, engine.ErrSnapshotNotFound
+	}
+	return s.load(instrument, keys[len(keys)-1])
+}
+
+// LoadSnapshotAt returns the instrument's snapshot taken at sequence
+func (s *KVSnapshotStore) LoadSnapshotAt(instrument string, sequence uint64) (*engine.OrderBookSnapshot, error) {
+	return s.load(instrument, snapshotKey(instrument, sequence))
+}
+
+// Sequences lists the sequences of the instrument's retained snapshots, oldest first
+func (s *KVSnapshotStore) Sequences(instrument string) ([]uint64, error) {
+	prefix := snapshotPrefix(instrument)
+	var sequences []uint64
+	for _, key := range s.kv.Keys(prefix) {
+		sequences = append(sequences, binary.BigEndian.Uint64([]byte(key[len(prefix):])))
+	}
+	return sequences, nil
+}
+
+// Clear deletes every snapshot key
+func (s *KVSnapshotStore) Clear() error {
+	for _, key := range s.kv.Keys(snapshotKeys) {
+		if err := s.kv.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the underlying KV
+func (s *KVSnapshotStore) Close() error {
+	return s.kv.Close()
+}
+
+func (s *KVSnapshotStore) load(instrument, key string) (*engine.OrderBookSnapshot, error) {
+	data, ok := s.kv.Get(key)
+	if !ok {
+		return nil, engine.ErrSnapshotNotFound
+	}
+	return decodeSnapshot(instrument, data)
+}
+
+// snapshotKeys prefixes the key of every snapshot
+const snapshotKeys = "snapshot/"
+
+// snapshotPrefix ends in a NUL so one symbol is never a prefix of another's keys
+func snapshotPrefix(instrument string) string {
+	return snapshotKeys + instrument + "\x00"
+}
+
+func snapshotKey(instrument string, sequence uint64) string {
+	return string(binary.BigEndian.AppendUint64([]byte(snapshotPrefix(instrument)), sequence))
+}
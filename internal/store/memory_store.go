@@ -0,0 +1,102 @@
+package store
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"github.com/aeromatch/internal/engine"
+)
+
+// memorySnapshot is an encoded snapshot, so callers never share state with the store
+type memorySnapshot struct {
+	sequence uint64
+	data     []byte
+}
+
+// MemorySnapshotStore keeps snapshots in memory. It is fast but lost on exit, so it
+// only helps within a process, e.g. for tests or rebuilding a book after a delist.
+type MemorySnapshotStore struct {
+	mu        sync.RWMutex
+	snapshots map[string][]memorySnapshot // Oldest first
+	opts      SnapshotOptions
+}
+
+// NewMemorySnapshotStore creates an empty store
+func NewMemorySnapshotStore(opts SnapshotOptions) *MemorySnapshotStore {
+	return &MemorySnapshotStore{
+		snapshots: make(map[string][]memorySnapshot),
+		opts:      opts,
+	}
+}
+
+// SaveSnapshot stores the snapshot and drops the instrument's oldest beyond retention
+func (s *MemorySnapshotStore) SaveSnapshot(snapshot *engine.OrderBookSnapshot) error {
+	data, err := snapshot.Encode(s.opts.Format)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Loads read the slice after unlocking, so it is replaced rather than changed in place
+	stored := slices.Clone(s.snapshots[snapshot.Instrument])
+	i, found := slices.BinarySearchFunc(stored, snapshot.Sequence, func(m memorySnapshot, seq uint64) int {
+		return cmp.Compare(m.sequence, seq)
+	})
+	if found {
+		stored[i].data = data
+	} else {
+		stored = slices.Insert(stored, i, memorySnapshot{sequence: snapshot.Sequence, data: data})
+	}
+	s.snapshots[snapshot.Instrument] = stored[max(len(stored)-s.opts.retain(), 0):]
+	return nil
+}
+
+// LoadSnapshot returns the instrument's latest snapshot
+func (s *MemorySnapshotStore) LoadSnapshot(instrument string) (*engine.OrderBookSnapshot, error) {
+	s.mu.RLock()
+	stored := s.snapshots[instrument]
+	s.mu.RUnlock()
+	if len(stored) == 0 {
+		return nil, engine.ErrSnapshotNotFound
+	}
+	return decodeSnapshot(instrument, stored[len(stored)-1].data)
+}
+
+// LoadSnapshotAt returns the instrument's snapshot taken at sequence
+func (s *MemorySnapshotStore) LoadSnapshotAt(instrument string, sequence uint64) (*engine.OrderBookSnapshot, error) {
+	s.mu.RLock()
+	stored := s.snapshots[instrument]
+	s.mu.RUnlock()
+	for _, snapshot := range stored {
+		if snapshot.sequence == sequence {
+			return decodeSnapshot(instrument, snapshot.data)
+		}
+	}
+	return nil, engine.ErrSnapshotNotFound
+}
+
+// Sequences lists the sequences of the instrument's retained snapshots, oldest first
+func (s *MemorySnapshotStore) Sequences(instrument string) ([]uint64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var sequences []uint64
+	for _, snapshot := range s.snapshots[instrument] {
+		sequences = append(sequences, snapshot.sequence)
+	}
+	return sequences, nil
+}
+
+// Clear drops every snapshot
+func (s *MemorySnapshotStore) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshots = make(map[string][]memorySnapshot)
+	return nil
+}
+
+// Close is a no-op
+func (s *MemorySnapshotStore) Close() error {
+	return nil
+}
@@ -0,0 +1,244 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aeromatch/internal/engine"
+)
+
+// Snapshot store types accepted by OpenSnapshotStore
+const (
+	StorageMemory = "memory"
+	StorageFile   = "file"
+	StorageKV     = "kv"
+)
+
+const (
+	snapshotExt = ".snap"
+	kvFile      = "snapshots.kv"
+)
+
+// SnapshotStore is a SnapshotStorage that retains several snapshots per instrument.
+// Saving a snapshot at a sequence that is already stored replaces it.
+type SnapshotStore interface {
+	engine.SnapshotStorage
+	// LoadSnapshotAt returns the instrument's snapshot taken at sequence, or engine.ErrSnapshotNotFound
+	LoadSnapshotAt(instrument string, sequence uint64) (*engine.OrderBookSnapshot, error)
+	// Sequences lists the sequences of the instrument's retained snapshots, oldest first
+	Sequences(instrument string) ([]uint64, error)
+	// Clear removes every instrument's snapshots. A run that starts over numbers its
+	// books from 1 again, so snapshots of earlier runs would outrank its own.
+	Clear() error
+	Close() error
+}
+
+// SnapshotOptions controls what snapshot stores keep and how they encode it
+type SnapshotOptions struct {
+	Retain int                   // Snapshots kept per instrument, at least one
+	Format engine.SnapshotFormat // Encoding of saved snapshots; any format can be loaded
+}
+
+func (o SnapshotOptions) retain() int {
+	return max(o.Retain, 1)
+}
+
+// CheckStorageType reports whether this binary can open a store of the given type;
+// the SQL types need their driver built in
+func CheckStorageType(kind string) error {
+	switch kind {
+	case StorageMemory, StorageFile, StorageKV:
+		return nil
+	}
+	dialect, err := DialectFor(kind)
+	if err != nil {
+		return fmt.Errorf("unknown storage type %q", kind)
+	}
+	if !dialect.Linked() {
+		return fmt.Errorf("%w: storage type %s needs a build with -tags %s", ErrSQLDriver, kind, dialect)
+	}
+	return nil
+}
+
+// OpenSnapshotStore opens a store of the given type. File and kv stores live under dir.
+func OpenSnapshotStore(kind, dir string, opts SnapshotOptions) (SnapshotStore, error) {
+	switch kind {
+	case StorageMemory:
+		return NewMemorySnapshotStore(opts), nil
+	case StorageFile:
+		return NewSnapshotDir(dir, opts)
+	case StorageKV:
+		kv, err := OpenKV(filepath.Join(dir, kvFile))
+		if err != nil {
+			return nil, err
+		}
+		return NewKVSnapshotStore(kv, opts), nil
+	default:
+		return nil, fmt.Errorf("unknown storage type %q", kind)
+	}
+}
+
+// SnapshotDir keeps each instrument's snapshots as files in a directory, one per
+// sequence, rotating out the oldest. Files are written by atomic rename, so a crash
+// mid-save never leaves a partial snapshot behind.
+type SnapshotDir struct {
+	dir  string
+	opts SnapshotOptions
+}
+
+// NewSnapshotDir uses dir for snapshots, creating it if needed
+func NewSnapshotDir(dir string, opts SnapshotOptions) (*SnapshotDir, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &SnapshotDir{dir: dir, opts: opts}, nil
+}
+
+// SaveSnapshot durably writes the snapshot and removes the instrument's oldest
+// files beyond retention
+func (s *SnapshotDir) SaveSnapshot(snapshot *engine.OrderBookSnapshot) error {
+	data, err := snapshot.Encode(s.opts.Format)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(s.path(snapshot.Instrument, snapshot.Sequence), data); err != nil {
+		return err
+	}
+
+	sequences, err := s.Sequences(snapshot.Instrument)
+	if err != nil {
+		return err
+	}
+	for _, sequence := range sequences[:max(len(sequences)-s.opts.retain(), 0)] {
+		if err := os.Remove(s.path(snapshot.Instrument, sequence)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadSnapshot reads the instrument's latest snapshot
+func (s *SnapshotDir) LoadSnapshot(instrument string) (*engine.OrderBookSnapshot, error) {
+	sequences, err := s.Sequences(instrument)
+	if err != nil {
+		return nil, err
+	}
+	if len(sequences) == 0 {
+		return nil, engine.ErrSnapshotNotFound
+	}
+	return s.LoadSnapshotAt(instrument, sequences[len(sequences)-1])
+}
+
+// LoadSnapshotAt reads the instrument's snapshot taken at sequence
+func (s *SnapshotDir) LoadSnapshotAt(instrument string, sequence uint64) (*engine.OrderBookSnapshot, error) {
+	data, err := os.ReadFile(s.path(instrument, sequence))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, engine.ErrSnapshotNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnapshot(instrument, data)
+}
+
+// Sequences lists the sequences of the instrument's snapshot files, oldest first
+func (s *SnapshotDir) Sequences(instrument string) ([]uint64, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var sequences []uint64
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), snapshotExt)
+		if !ok {
+			continue // Temporary file or not a snapshot
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 || name[:i] != url.PathEscape(instrument) {
+			continue
+		}
+		if sequence, err := strconv.ParseUint(name[i+1:], 10, 64); err == nil {
+			sequences = append(sequences, sequence)
+		}
+	}
+	slices.Sort(sequences)
+	return sequences, nil
+}
+
+// Clear removes every snapshot file in the directory
+func (s *SnapshotDir) Clear() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), snapshotExt) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, entry.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return syncDir(s.dir)
+}
+
+// Close is a no-op: files are closed after every write
+func (s *SnapshotDir) Close() error {
+	return nil
+}
+
+// path escapes the symbol so any instrument name maps to files in dir, and pads the
+// sequence so names sort in sequence order
+func (s *SnapshotDir) path(instrument string, sequence uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%s.%020d%s", url.PathEscape(instrument), sequence, snapshotExt))
+}
+
+func decodeSnapshot(instrument string, data []byte) (*engine.OrderBookSnapshot, error) {
+	snapshot := &engine.OrderBookSnapshot{}
+	if err := snapshot.UnmarshalBinary(data); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", engine.ErrInvalidSnapshot, instrument, err)
+	}
+	return snapshot, nil
+}
+
+// writeFileAtomic writes data to a temporary file, syncs it and renames it over
+// path, then syncs the directory so the rename itself survives a crash
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
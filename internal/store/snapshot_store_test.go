@@ -0,0 +1,368 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/aeromatch/internal/engine"
+	"github.com/aeromatch/internal/models"
+)
+
+func TestSnapshotStores(t *testing.T) {
+	for _, kind := range []string{StorageMemory, StorageFile, StorageKV} {
+		t.Run(kind, func(t *testing.T) {
+			dir := t.TempDir()
+			opts := SnapshotOptions{Retain: 3}
+			snapshots, err := OpenSnapshotStore(kind, dir, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := snapshots.LoadSnapshot("BTC/USD"); !errors.Is(err, engine.ErrSnapshotNotFound) {
+				t.Fatalf("got %v, want not found", err)
+			}
+
+			// "BTC" must not pick up "BTC/USD" snapshots or the other way round
+			for _, symbol := range []string{"BTC/USD", "BTC"} {
+				for sequence := uint64(1); sequence <= 5; sequence++ {
+					snapshot := &engine.OrderBookSnapshot{Instrument: symbol, Sequence: sequence * 10}
+					if err := snapshots.SaveSnapshot(snapshot); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			check := func(snapshots SnapshotStore) {
+				t.Helper()
+				sequences, err := snapshots.Sequences("BTC/USD")
+				if err != nil || !slices.Equal(sequences, []uint64{30, 40, 50}) {
+					t.Fatalf("retained %v (%v), want the latest three", sequences, err)
+				}
+				latest, err := snapshots.LoadSnapshot("BTC/USD")
+				if err != nil || latest.Instrument != "BTC/USD" || latest.Sequence != 50 {
+					t.Fatalf("latest is %+v (%v)", latest, err)
+				}
+				if at, err := snapshots.LoadSnapshotAt("BTC/USD", 40); err != nil || at.Sequence != 40 {
+					t.Fatalf("loading sequence 40: %+v (%v)", at, err)
+				}
+				if _, err := snapshots.LoadSnapshotAt("BTC/USD", 20); !errors.Is(err, engine.ErrSnapshotNotFound) {
+					t.Fatalf("expired snapshot: got %v", err)
+				}
+			}
+			check(snapshots)
+			if err := snapshots.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			if kind == StorageMemory {
+				return
+			}
+			// A store written in one format reads back after switching to the other
+			opts.Format = engine.SnapshotJSON
+			reopened, err := OpenSnapshotStore(kind, dir, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer reopened.Close()
+			check(reopened)
+			if err := reopened.SaveSnapshot(&engine.OrderBookSnapshot{Instrument: "BTC/USD", Sequence: 60}); err != nil {
+				t.Fatal(err)
+			}
+			if sequences, _ := reopened.Sequences("BTC/USD"); !slices.Equal(sequences, []uint64{40, 50, 60}) {
+				t.Fatalf("retained %v after a JSON save", sequences)
+			}
+			if latest, err := reopened.LoadSnapshot("BTC/USD"); err != nil || latest.Sequence != 60 {
+				t.Fatalf("latest is %+v (%v)", latest, err)
+			}
+		})
+	}
+}
+
+func TestSnapshotDirRestoresBook(t *testing.T) {
+	dir := t.TempDir()
+	snapshots, err := NewSnapshotDir(dir, SnapshotOptions{Retain: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	book := engine.NewOrderBook(&models.Instrument{
+		Symbol:   "BTC/USD",
+		TickSize: models.MustParseDecimal("0.01"),
+		LotSize:  models.MustParseDecimal("1"),
+	}, 16, engine.WaitPark)
+	for id := uint64(1); id <= 2; id++ {
+		book.AddAsk(&models.Order{
+			ID:         id,
+			Price:      models.MustParseDecimal("100"),
+			Quantity:   models.MustParseDecimal("1"),
+			Remaining:  models.MustParseDecimal("1"),
+			Side:       models.Sell,
+			Instrument: "BTC/USD",
+		})
+	}
+	if err := snapshots.SaveSnapshot(book.Snapshot()); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := snapshots.LoadSnapshot("BTC/USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.AskOrders) != 2 || loaded.AskOrders[0].ID != 1 || loaded.AskOrders[1].ID != 2 {
+		t.Fatalf("loaded asks %+v", loaded.AskOrders)
+	}
+	if _, err := engine.RestoreOrderBook(loaded, 16, engine.WaitPark); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Name() != "BTC%2FUSD.00000000000000000000.snap" {
+		t.Fatalf("snapshot directory holds %v", files)
+	}
+}
+
+func TestKVCompactsAndDropsTornTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.kv")
+	kv, err := OpenKV(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Overwriting one key until the log passes the compaction threshold
+	value := make([]byte, 64<<10)
+	for i := range 40 {
+		value[0] = byte(i)
+		if err := kv.Put("hot", value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := kv.Put("cold", []byte("kept")); err != nil {
+		t.Fatal(err)
+	}
+	if err := kv.Delete("cold"); err != nil {
+		t.Fatal(err)
+	}
+	if err := kv.Put("cold", []byte("back")); err != nil {
+		t.Fatal(err)
+	}
+	if err := kv.Close(); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() > kvCompactMin {
+		t.Fatalf("log is %d bytes after compaction", info.Size())
+	}
+
+	// A torn record at the end is cut off on open
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Write(appendRecord(nil, kvPayload(kvPut, "torn", []byte("value")))[:10])
+	file.Close()
+
+	kv, err = OpenKV(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer kv.Close()
+	if hot, ok := kv.Get("hot"); !ok || hot[0] != 39 {
+		t.Fatal("lost the latest value of a compacted key")
+	}
+	if cold, ok := kv.Get("cold"); !ok || string(cold) != "back" {
+		t.Fatalf("cold = %q", cold)
+	}
+	if _, ok := kv.Get("torn"); ok {
+		t.Fatal("torn record was loaded")
+	}
+	if keys := kv.Keys(""); !slices.Equal(keys, []string{"cold", "hot"}) {
+		t.Fatalf("keys %v", keys)
+	}
+	if err := kv.Put("after", nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestKVCompactionKeepsLogOnFailedWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.kv")
+	kv, err := OpenKV(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer kv.Close()
+
+	// The compacted log goes to a file opened read-only, so writing it fails
+	kvCreateTemp = func(dir, pattern string) (*os.File, error) {
+		file, err := os.CreateTemp(dir, pattern)
+		if err != nil {
+			return nil, err
+		}
+		file.Close()
+		return os.Open(file.Name())
+	}
+	defer func() { kvCreateTemp = os.CreateTemp }()
+
+	value := make([]byte, 64<<10)
+	var compactErr error
+	for i := 0; i < 40 && compactErr == nil; i++ {
+		value[0] = byte(i)
+		compactErr = kv.Put("hot", value)
+	}
+	if compactErr == nil {
+		t.Fatal("compaction did not report the failed write")
+	}
+	kvCreateTemp = os.CreateTemp
+
+	if files, _ := filepath.Glob(path + ".tmp*"); len(files) != 0 {
+		t.Fatalf("left %v behind", files)
+	}
+	if err := kv.Put("cold", []byte("kept")); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := OpenKV(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if hot, ok := reopened.Get("hot"); !ok || hot[0] != value[0] {
+		t.Fatal("lost the latest value after a failed compaction")
+	}
+	if cold, ok := reopened.Get("cold"); !ok || string(cold) != "kept" {
+		t.Fatalf("cold = %q after a failed compaction", cold)
+	}
+}
+
+// TestMemorySnapshotStoreSharesNothing loads snapshots while they are being
+// replaced and rotated; run with -race
+func TestMemorySnapshotStoreSharesNothing(t *testing.T) {
+	snapshots := NewMemorySnapshotStore(SnapshotOptions{Retain: 3})
+	for sequence := uint64(1); sequence <= 3; sequence++ {
+		snapshots.SaveSnapshot(&engine.OrderBookSnapshot{Instrument: "BTC-USD", Sequence: sequence})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for sequence := uint64(1); sequence <= 200; sequence++ {
+			// Overwrite the latest, then add one that drops the oldest
+			snapshots.SaveSnapshot(&engine.OrderBookSnapshot{Instrument: "BTC-USD", Sequence: sequence + 2})
+			snapshots.SaveSnapshot(&engine.OrderBookSnapshot{Instrument: "BTC-USD", Sequence: sequence + 3})
+		}
+	}()
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		latest, err := snapshots.LoadSnapshot("BTC-USD")
+		if err != nil || latest.Instrument != "BTC-USD" {
+			t.Fatalf("latest is %+v (%v)", latest, err)
+		}
+		sequences, _ := snapshots.Sequences("BTC-USD")
+		snapshots.LoadSnapshotAt("BTC-USD", sequences[0])
+	}
+}
+
+// TestRecoverAfterFreshRun runs an engine three times over one journal and one
+// store: a run, a run that starts over, then a recovery. The fresh run's books are
+// numbered from 1 again, yet its snapshots must be the ones kept and restored.
+func TestRecoverAfterFreshRun(t *testing.T) {
+	for _, kind := range []string{StorageMemory, StorageFile, StorageKV} {
+		t.Run(kind, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "journal.log")
+			snapshots, err := OpenSnapshotStore(kind, dir, SnapshotOptions{Retain: 2})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer snapshots.Close()
+
+			run := func(recover bool, orders int, fn func(m *engine.MatchingEngine)) {
+				t.Helper()
+				m := engine.NewMatchingEngine(64, 64, engine.WaitPark)
+				if _, err := m.AddInstrument(&models.Instrument{
+					Symbol:   "BTC-USD",
+					TickSize: models.MustParseDecimal("0.01"),
+					LotSize:  models.MustParseDecimal("1"),
+				}); err != nil {
+					t.Fatal(err)
+				}
+				if recover {
+					err := m.Recover(snapshots, func(fn func(*engine.JournalEntry) error) error {
+						return ReadJournal(path, fn)
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				} else if err := snapshots.Clear(); err != nil {
+					t.Fatal(err)
+				}
+				journal, err := OpenFileJournal(path, JournalOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				m.SetJournal(journal)
+				m.Start()
+				for i := 0; i < orders; i++ {
+					_, err := m.ExecuteOrder(context.Background(), &models.Order{
+						Instrument: "BTC-USD",
+						Account:    "alice",
+						Side:       models.Sell,
+						Type:       models.Limit,
+						Price:      models.MustParseDecimal("100"),
+						Quantity:   models.MustParseDecimal("1"),
+						Remaining:  models.MustParseDecimal("1"),
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+					if i%5 == 4 {
+						if err := m.SaveSnapshots(snapshots); err != nil {
+							t.Fatal(err)
+						}
+					}
+				}
+				fn(m)
+				m.Stop()
+				if err := journal.Close(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			openOrders := func(m *engine.MatchingEngine) []uint64 {
+				orders, err := m.ListOpenOrders("alice", "")
+				if err != nil {
+					t.Fatal(err)
+				}
+				var ids []uint64
+				for _, order := range orders {
+					ids = append(ids, order.ID)
+				}
+				slices.Sort(ids)
+				return ids
+			}
+
+			run(false, 20, func(*engine.MatchingEngine) {}) // Snapshots at sequences 5 to 20
+			var want []uint64
+			run(false, 7, func(m *engine.MatchingEngine) { want = openOrders(m) }) // Starts over: a snapshot at 5, then 2 more orders
+
+			if sequences, err := snapshots.Sequences("BTC-USD"); err != nil || !slices.Equal(sequences, []uint64{5}) {
+				t.Fatalf("retained %v (%v), want only the fresh run's snapshot", sequences, err)
+			}
+			run(true, 0, func(m *engine.MatchingEngine) {
+				if got := openOrders(m); !slices.Equal(got, want) {
+					t.Fatalf("recovered orders %v, want the fresh run's %v", got, want)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SQL storage types accepted by OpenSQLStore
+const (
+	StoragePostgres = "postgres"
+	StorageSQLite   = "sqlite"
+)
+
+// Dialect holds what differs between the supported SQL databases
+type Dialect struct {
+	name      string
+	driver    string            // database/sql driver name; the driver is linked in by build tag
+	numbered  bool              // $1 placeholders rather than ?
+	maxParams int               // Bind parameters allowed in one statement
+	types     *strings.Replacer // Fills the column types migrations leave open
+}
+
+var (
+	Postgres = &Dialect{
+		name:      StoragePostgres,
+		driver:    "pgx",
+		numbered:  true,
+		maxParams: 65535,
+		types:     strings.NewReplacer("{id}", "BIGSERIAL PRIMARY KEY", "{decimal}", "NUMERIC", "{blob}", "BYTEA"),
+	}
+	// Decimals are TEXT because SQLite would coerce NUMERIC to floating point
+	SQLite = &Dialect{
+		name:      StorageSQLite,
+		driver:    "sqlite",
+		maxParams: 999,
+		types:     strings.NewReplacer("{id}", "INTEGER PRIMARY KEY AUTOINCREMENT", "{decimal}", "TEXT", "{blob}", "BLOB"),
+	}
+)
+
+// DialectFor returns the dialect of a storage type
+func DialectFor(kind string) (*Dialect, error) {
+	switch kind {
+	case StoragePostgres:
+		return Postgres, nil
+	case StorageSQLite:
+		return SQLite, nil
+	default:
+		return nil, fmt.Errorf("unknown SQL storage type %q", kind)
+	}
+}
+
+func (d *Dialect) String() string {
+	return d.name
+}
+
+// Linked reports whether the dialect's driver was built into the binary
+func (d *Dialect) Linked() bool {
+	return slices.Contains(sql.Drivers(), d.driver)
+}
+
+// placeholder returns the bind parameter for the n-th argument, counting from 1
+func (d *Dialect) placeholder(n int) string {
+	if d.numbered {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// bind replaces each ? in query with the dialect's placeholder
+func (d *Dialect) bind(query string) string {
+	if !d.numbered {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString(d.placeholder(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// migration is one schema version. Statements may use {id}, {decimal} and {blob}
+// for the column types that differ between dialects.
+type migration struct {
+	version    int
+	statements []string
+}
+
+// Schema history; append new versions, never edit applied ones
+var migrations = []migration{
+	{1, []string{
+		`CREATE TABLE trades (
+			trade_id       BIGINT PRIMARY KEY,
+			execution_id   BIGINT NOT NULL,
+			instrument     TEXT NOT NULL,
+			sequence       BIGINT NOT NULL,
+			side           SMALLINT NOT NULL,
+			price          {decimal} NOT NULL,
+			quantity       {decimal} NOT NULL,
+			maker_order_id BIGINT NOT NULL,
+			taker_order_id BIGINT NOT NULL,
+			fee            {decimal} NOT NULL,
+			fee_currency   TEXT NOT NULL,
+			executed_at    BIGINT NOT NULL
+		)`,
+		`CREATE INDEX trades_instrument_time ON trades (instrument, executed_at)`,
+		`CREATE TABLE order_events (
+			id              {id},
+			order_id        BIGINT NOT NULL,
+			instrument      TEXT NOT NULL,
+			account         TEXT NOT NULL,
+			client_order_id TEXT NOT NULL,
+			side            SMALLINT NOT NULL,
+			order_type      SMALLINT NOT NULL,
+			price           {decimal} NOT NULL,
+			quantity        {decimal} NOT NULL,
+			remaining       {decimal} NOT NULL,
+			old_status      SMALLINT NOT NULL,
+			status          SMALLINT NOT NULL,
+			reason          SMALLINT NOT NULL,
+			execution_id    BIGINT NOT NULL,
+			occurred_at     BIGINT NOT NULL
+		)`,
+		`CREATE INDEX order_events_order ON order_events (instrument, order_id)`,
+	}},
+	{2, []string{
+		`CREATE TABLE snapshots (
+			instrument TEXT NOT NULL,
+			sequence   BIGINT NOT NULL,
+			data       {blob} NOT NULL,
+			saved_at   BIGINT NOT NULL,
+			PRIMARY KEY (instrument, sequence)
+		)`,
+	}},
+}
+
+// Migrate brings the schema up to date, applying each missing version in its own
+// transaction and recording it in schema_migrations
+func Migrate(db *sql.DB, dialect *Dialect) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at BIGINT NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	var current int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return fmt.Errorf("read schema version: %w", err)
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if err := applyMigration(db, dialect, m); err != nil {
+			return fmt.Errorf("migration %d: %w", m.version, err)
+		}
+	}
+	return nil
+}
+
+func applyMigration(db *sql.DB, dialect *Dialect, m migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // No-op after Commit
+
+	for _, statement := range m.statements {
+		if _, err := tx.Exec(dialect.types.Replace(statement)); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(dialect.bind(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`),
+		m.version, time.Now().UnixNano()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
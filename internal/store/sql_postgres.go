@@ -0,0 +1,6 @@
+//go:build postgres
+
+package store
+
+// Links the PostgreSQL driver, registered as "pgx", when built with -tags postgres
+import _ "github.com/jackc/pgx/v5/stdlib"
@@ -0,0 +1,7 @@
+//go:build sqlite
+
+package store
+
+// Links the pure-Go SQLite driver, registered as "sqlite". Building with -tags sqlite
+// requires modernc.org/sqlite in go.mod.
+import _ "modernc.org/sqlite"
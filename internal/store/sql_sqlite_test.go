@@ -0,0 +1,73 @@
+//go:build sqlite
+
+package store
+
+import (
+	"database/sql"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aeromatch/internal/engine"
+	"github.com/aeromatch/internal/models"
+)
+
+// TestSQLiteStore runs the migrations and every statement against a real SQLite
+// database; go test -tags sqlite
+func TestSQLiteStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aeromatch.db")
+	opts := SQLOptions{FlushInterval: time.Millisecond, Snapshots: SnapshotOptions{Retain: 2}}
+	s, err := OpenSQLStore(StorageSQLite, path, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for id := uint64(1); id <= 3; id++ {
+		s.RecordTrade(testTrade(id))
+	}
+	s.RecordTrade(testTrade(3)) // Recorded again after a restart: kept once
+	order := &models.Order{ID: 7, Instrument: "BTC-USD", Account: "alice", Price: models.MustParseDecimal("100.25"), Status: models.Partial}
+	s.RecordOrderEvent(&models.OrderEvent{Order: order, OldStatus: models.New, Timestamp: time.Now()})
+
+	// Sequence 30 is saved twice, so the upsert runs; retention keeps the latest two
+	for _, sequence := range []uint64{10, 20, 30, 30} {
+		if err := s.SaveSnapshot(&engine.OrderBookSnapshot{Instrument: "BTC-USD", Sequence: sequence}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if sequences, err := s.Sequences("BTC-USD"); err != nil || !slices.Equal(sequences, []uint64{20, 30}) {
+		t.Fatalf("retained %v (%v), want the latest two", sequences, err)
+	}
+	if latest, err := s.LoadSnapshot("BTC-USD"); err != nil || latest.Sequence != 30 {
+		t.Fatalf("latest is %+v (%v)", latest, err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reopening finds the schema current and applies nothing
+	s, err = OpenSQLStore(StorageSQLite, path, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open(SQLite.driver, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for table, want := range map[string]int{"schema_migrations": len(migrations), "trades": 3, "order_events": 1} {
+		var n int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM ` + table).Scan(&n); err != nil || n != want {
+			t.Fatalf("%s has %d rows (%v), want %d", table, n, err, want)
+		}
+	}
+	var price string
+	if err := db.QueryRow(`SELECT price FROM trades WHERE trade_id = 1`).Scan(&price); err != nil || price != "100.25" {
+		t.Fatalf("trade price stored as %q (%v)", price, err)
+	}
+}
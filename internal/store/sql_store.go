@@ -0,0 +1,325 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aeromatch/internal/engine"
+	"github.com/aeromatch/internal/models"
+)
+
+const (
+	defaultSQLBatchSize     = 500
+	defaultSQLFlushInterval = 50 * time.Millisecond
+	defaultSQLQueueSize     = 8192
+	sqlWriteAttempts        = 3
+)
+
+var (
+	ErrSQLStoreClosed = errors.New("SQL store closed")
+	ErrSQLDriver      = errors.New("SQL driver not built in")
+)
+
+// SQLOptions controls the batched writers
+type SQLOptions struct {
+	BatchSize     int             // Rows per INSERT; zero means 500
+	FlushInterval time.Duration   // Longest a row waits to be written, and the pause before a retry; zero means 50ms
+	QueueSize     int             // Rows buffered per table before recording blocks; zero means 8192
+	Snapshots     SnapshotOptions // For snapshots kept in the snapshots table
+	OnError       func(error)     // Told about batches dropped after failed retries; may be nil
+}
+
+// SQLStore records trades and order state transitions in SQL tables and keeps book
+// snapshots. Rows are queued by the engine's output goroutines and written by one
+// background writer per table as multi-row INSERTs, so matching never waits on the
+// database unless the queues fill up.
+type SQLStore struct {
+	db      *sql.DB
+	dialect *Dialect
+	opts    SQLOptions
+	trades  *batchWriter
+	events  *batchWriter
+
+	mu     sync.RWMutex // Guards closed against recording into closed queues
+	closed bool
+}
+
+// OpenSQLStore connects to the database of the given type and migrates its schema.
+// The driver must be linked in with the postgres or sqlite build tag.
+func OpenSQLStore(kind, dsn string, opts SQLOptions) (*SQLStore, error) {
+	dialect, err := DialectFor(kind)
+	if err != nil {
+		return nil, err
+	}
+	if !dialect.Linked() {
+		return nil, fmt.Errorf("%w: build with -tags %s", ErrSQLDriver, dialect)
+	}
+	db, err := sql.Open(dialect.driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	s, err := NewSQLStore(db, dialect, opts)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewSQLStore migrates db and starts the writers; Close closes db
+func NewSQLStore(db *sql.DB, dialect *Dialect, opts SQLOptions) (*SQLStore, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultSQLBatchSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = defaultSQLFlushInterval
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = defaultSQLQueueSize
+	}
+	if err := Migrate(db, dialect); err != nil {
+		return nil, err
+	}
+
+	s := &SQLStore{db: db, dialect: dialect, opts: opts}
+	s.trades = s.newWriter("trades", []string{
+		"trade_id", "execution_id", "instrument", "sequence", "side", "price", "quantity",
+		"maker_order_id", "taker_order_id", "fee", "fee_currency", "executed_at",
+	}, " ON CONFLICT (trade_id) DO NOTHING") // Trades re-recorded after a restart are kept once
+	s.events = s.newWriter("order_events", []string{
+		"order_id", "instrument", "account", "client_order_id", "side", "order_type", "price",
+		"quantity", "remaining", "old_status", "status", "reason", "execution_id", "occurred_at",
+	}, "")
+	return s, nil
+}
+
+// RecordTrade queues the trade for insertion
+func (s *SQLStore) RecordTrade(trade *models.Trade) {
+	s.record(s.trades, []any{
+		int64(trade.TradeID), int64(trade.ExecutionID), trade.Instrument, int64(trade.Sequence),
+		int16(trade.Side), trade.Price.String(), trade.Quantity.String(),
+		int64(trade.MakerOrderID), int64(trade.TakerOrderID), trade.Fee.String(), trade.FeeCurrency,
+		trade.Timestamp,
+	})
+}
+
+// RecordOrderEvent queues the order's state transition for insertion. The order is
+// read now, so later changes to it are not recorded against this event.
+func (s *SQLStore) RecordOrderEvent(event *models.OrderEvent) {
+	o := event.Order
+	s.record(s.events, []any{
+		int64(o.ID), o.Instrument, o.Account, o.ClientOID, int16(o.Side), int16(o.Type),
+		o.Price.String(), o.Quantity.String(), o.Remaining.String(),
+		int16(event.OldStatus), int16(o.Status), int16(event.Reason), int64(event.ExecutionID),
+		event.Timestamp.UnixNano(),
+	})
+}
+
+func (s *SQLStore) record(w *batchWriter, row []any) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.closed {
+		w.rows <- row
+	}
+}
+
+// SaveSnapshot stores the snapshot and drops the instrument's oldest beyond retention
+func (s *SQLStore) SaveSnapshot(snapshot *engine.OrderBookSnapshot) error {
+	data, err := snapshot.Encode(s.opts.Snapshots.Format)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // No-op after Commit
+	if _, err := tx.Exec(s.dialect.bind(`INSERT INTO snapshots (instrument, sequence, data, saved_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (instrument, sequence) DO UPDATE SET data = excluded.data, saved_at = excluded.saved_at`),
+		snapshot.Instrument, int64(snapshot.Sequence), data, time.Now().UnixNano()); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(s.dialect.bind(`DELETE FROM snapshots WHERE instrument = ? AND sequence < (
+		SELECT MIN(sequence) FROM (
+			SELECT sequence FROM snapshots WHERE instrument = ? ORDER BY sequence DESC LIMIT ?
+		) AS kept)`),
+		snapshot.Instrument, snapshot.Instrument, s.opts.Snapshots.retain()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// LoadSnapshot returns the instrument's latest snapshot
+func (s *SQLStore) LoadSnapshot(instrument string) (*engine.OrderBookSnapshot, error) {
+	return s.loadSnapshot(instrument, `SELECT data FROM snapshots WHERE instrument = ? ORDER BY sequence DESC LIMIT 1`, instrument)
+}
+
+// LoadSnapshotAt returns the instrument's snapshot taken at sequence
+func (s *SQLStore) LoadSnapshotAt(instrument string, sequence uint64) (*engine.OrderBookSnapshot, error) {
+	return s.loadSnapshot(instrument, `SELECT data FROM snapshots WHERE instrument = ? AND sequence = ?`, instrument, int64(sequence))
+}
+
+func (s *SQLStore) loadSnapshot(instrument, query string, args ...any) (*engine.OrderBookSnapshot, error) {
+	var data []byte
+	err := s.db.QueryRow(s.dialect.bind(query), args...).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, engine.ErrSnapshotNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnapshot(instrument, data)
+}
+
+// Sequences lists the sequences of the instrument's retained snapshots, oldest first
+func (s *SQLStore) Sequences(instrument string) ([]uint64, error) {
+	rows, err := s.db.Query(s.dialect.bind(`SELECT sequence FROM snapshots WHERE instrument = ? ORDER BY sequence`), instrument)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sequences []uint64
+	for rows.Next() {
+		var sequence int64
+		if err := rows.Scan(&sequence); err != nil {
+			return nil, err
+		}
+		sequences = append(sequences, uint64(sequence))
+	}
+	return sequences, rows.Err()
+}
+
+// Clear deletes every instrument's snapshots
+func (s *SQLStore) Clear() error {
+	_, err := s.db.Exec(`DELETE FROM snapshots`)
+	return err
+}
+
+// Close writes out everything queued, then closes the database. Rows recorded
+// afterwards are dropped.
+func (s *SQLStore) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrSQLStoreClosed
+	}
+	s.closed = true
+	close(s.trades.rows)
+	close(s.events.rows)
+	s.mu.Unlock()
+
+	<-s.trades.done
+	<-s.events.done
+	return s.db.Close()
+}
+
+// batchWriter inserts the rows queued for one table
+type batchWriter struct {
+	store   *SQLStore
+	table   string
+	columns []string
+	suffix  string // Appended to every INSERT, e.g. a conflict clause
+	rows    chan []any
+	done    chan struct{}
+}
+
+func (s *SQLStore) newWriter(table string, columns []string, suffix string) *batchWriter {
+	w := &batchWriter{
+		store:   s,
+		table:   table,
+		columns: columns,
+		suffix:  suffix,
+		rows:    make(chan []any, s.opts.QueueSize),
+		done:    make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// run writes a batch whenever it fills up or the oldest row has waited a flush interval
+func (w *batchWriter) run() {
+	defer close(w.done)
+	opts := w.store.opts
+	batch := make([][]any, 0, opts.BatchSize)
+	timer := time.NewTimer(opts.FlushInterval)
+	timer.Stop()
+
+	for {
+		select {
+		case row, ok := <-w.rows:
+			if !ok {
+				w.write(batch)
+				return
+			}
+			if len(batch) == 0 {
+				timer.Reset(opts.FlushInterval)
+			}
+			batch = append(batch, row)
+			if len(batch) < opts.BatchSize {
+				continue
+			}
+			timer.Stop()
+		case <-timer.C:
+		}
+		w.write(batch)
+		batch = batch[:0]
+	}
+}
+
+// write inserts the batch in as few statements as the dialect's parameter limit
+// allows, retrying each a few times before dropping it
+func (w *batchWriter) write(batch [][]any) {
+	per := max(w.store.dialect.maxParams/len(w.columns), 1)
+	for len(batch) > 0 {
+		chunk := batch[:min(len(batch), per)]
+		batch = batch[len(chunk):]
+
+		query, args := w.insert(chunk)
+		var err error
+		for attempt := 1; attempt <= sqlWriteAttempts; attempt++ {
+			if _, err = w.store.db.Exec(query, args...); err == nil {
+				break
+			}
+			if attempt < sqlWriteAttempts {
+				time.Sleep(w.store.opts.FlushInterval * time.Duration(attempt))
+			}
+		}
+		if err != nil && w.store.opts.OnError != nil {
+			w.store.opts.OnError(fmt.Errorf("%s: dropped %d rows after %d attempts: %w", w.table, len(chunk), sqlWriteAttempts, err))
+		}
+	}
+}
+
+// insert builds one multi-row INSERT for the rows
+func (w *batchWriter) insert(rows [][]any) (string, []any) {
+	var b strings.Builder
+	b.WriteString("INSERT INTO ")
+	b.WriteString(w.table)
+	b.WriteString(" (")
+	b.WriteString(strings.Join(w.columns, ", "))
+	b.WriteString(") VALUES ")
+
+	args := make([]any, 0, len(rows)*len(w.columns))
+	for i, row := range rows {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for j := range row {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			args = append(args, row[j])
+			b.WriteString(w.store.dialect.placeholder(len(args)))
+		}
+		b.WriteByte(')')
+	}
+	b.WriteString(w.suffix)
+	return b.String(), args
+}
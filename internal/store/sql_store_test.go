@@ -0,0 +1,304 @@
+package store
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aeromatch/internal/models"
+)
+
+// fakeSQL is a database/sql driver that records statements instead of running
+// them. It only understands the schema version query.
+type fakeSQL struct {
+	mu       sync.Mutex
+	execs    []fakeExec
+	version  int64
+	failures int // Upcoming trade INSERTs that fail
+}
+
+type fakeExec struct {
+	query string
+	args  []driver.Value
+}
+
+var fakeDBs sync.Map // DSN -> *fakeSQL
+
+func init() {
+	sql.Register("fakesql", fakeDriver{})
+}
+
+// openFake returns a database backed by a fresh recorder
+func openFake(t *testing.T) (*sql.DB, *fakeSQL) {
+	fake := &fakeSQL{}
+	fakeDBs.Store(t.Name(), fake)
+	db, err := sql.Open("fakesql", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, fake
+}
+
+// inserts returns the recorded INSERTs into table
+func (f *fakeSQL) inserts(table string) []fakeExec {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var execs []fakeExec
+	for _, e := range f.execs {
+		if strings.HasPrefix(e.query, "INSERT INTO "+table+" ") {
+			execs = append(execs, e)
+		}
+	}
+	return execs
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fake, _ := fakeDBs.Load(name)
+	return &fakeConn{fake.(*fakeSQL)}, nil
+}
+
+type fakeConn struct{ db *fakeSQL }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{db: c.db, query: query}, nil
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeStmt struct {
+	db    *fakeSQL
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if s.db.failures > 0 && strings.HasPrefix(s.query, "INSERT INTO trades ") {
+		s.db.failures--
+		return nil, errors.New("connection reset")
+	}
+	s.db.execs = append(s.db.execs, fakeExec{s.query, args})
+	if strings.HasPrefix(s.query, "INSERT INTO schema_migrations") {
+		s.db.version = args[0].(int64)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if strings.Contains(s.query, "MAX(version)") {
+		return &fakeRows{values: []driver.Value{s.db.version}}, nil
+	}
+	return &fakeRows{}, nil
+}
+
+type fakeRows struct{ values []driver.Value }
+
+func (r *fakeRows) Columns() []string { return make([]string, len(r.values)) }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.values == nil {
+		return io.EOF
+	}
+	copy(dest, r.values)
+	r.values = nil
+	return nil
+}
+
+func TestSQLStoreMigratesOnce(t *testing.T) {
+	for _, dialect := range []*Dialect{Postgres, SQLite} {
+		t.Run(dialect.String(), func(t *testing.T) {
+			db, fake := openFake(t)
+			s, err := NewSQLStore(db, dialect, SQLOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.Close()
+
+			var schema []string
+			for _, e := range fake.execs {
+				if strings.HasPrefix(e.query, "CREATE TABLE trades") || strings.HasPrefix(e.query, "CREATE TABLE order_events") {
+					schema = append(schema, e.query)
+				}
+			}
+			if len(schema) != 2 || strings.Contains(strings.Join(schema, ""), "{") {
+				t.Fatalf("created %q", schema)
+			}
+			wantID, wantDecimal := "BIGSERIAL PRIMARY KEY", "price          NUMERIC"
+			if dialect == SQLite {
+				wantID, wantDecimal = "INTEGER PRIMARY KEY AUTOINCREMENT", "price          TEXT"
+			}
+			if !strings.Contains(schema[1], wantID) || !strings.Contains(schema[0], wantDecimal) {
+				t.Fatalf("schema not written for %s:\n%s", dialect, strings.Join(schema, "\n"))
+			}
+			if fake.version != int64(migrations[len(migrations)-1].version) {
+				t.Fatalf("schema at version %d", fake.version)
+			}
+
+			applied := len(fake.execs)
+			db, err = sql.Open("fakesql", t.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			s, err = NewSQLStore(db, dialect, SQLOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.Close()
+			for _, e := range fake.execs[applied:] {
+				if !strings.HasPrefix(e.query, "CREATE TABLE IF NOT EXISTS schema_migrations") {
+					t.Fatalf("migrated again: %s", e.query)
+				}
+			}
+		})
+	}
+}
+
+func testTrade(id uint64) *models.Trade {
+	return &models.Trade{
+		TradeID:    id,
+		Price:      models.MustParseDecimal("100.25"),
+		Quantity:   models.MustParseDecimal("1.5"),
+		Instrument: "BTC-USD",
+		Timestamp:  time.Now().UnixNano(),
+	}
+}
+
+func TestSQLStoreBatchesRows(t *testing.T) {
+	db, fake := openFake(t)
+	s, err := NewSQLStore(db, Postgres, SQLOptions{BatchSize: 4, FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for id := uint64(1); id <= 10; id++ {
+		s.RecordTrade(testTrade(id))
+	}
+	order := &models.Order{ID: 7, Instrument: "BTC-USD", Remaining: models.MustParseDecimal("3"), Status: models.Rejected}
+	s.RecordOrderEvent(&models.OrderEvent{Order: order, OldStatus: models.New, Reason: models.ReasonPostOnlyWouldCross, Timestamp: time.Now()})
+	order.Remaining = models.MustParseDecimal("1") // Changed after the event: not recorded
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	s.RecordTrade(testTrade(11)) // Dropped after Close
+
+	trades := fake.inserts("trades")
+	if len(trades) != 3 || len(trades[0].args) != 48 || len(trades[2].args) != 24 {
+		t.Fatalf("got %d trade INSERTs, want batches of 4, 4 and the 2 left at Close", len(trades))
+	}
+	if !strings.Contains(trades[0].query, "($37, $38,") || !strings.HasSuffix(trades[0].query, "ON CONFLICT (trade_id) DO NOTHING") {
+		t.Fatalf("unexpected statement %s", trades[0].query)
+	}
+	for i, e := range trades {
+		for row := 0; row < len(e.args)/12; row++ {
+			if want := int64(i*4 + row + 1); e.args[row*12] != want || e.args[row*12+5] != "100.25" {
+				t.Fatalf("batch %d row %d: %v", i, row, e.args[row*12:row*12+12])
+			}
+		}
+	}
+
+	events := fake.inserts("order_events")
+	if len(events) != 1 {
+		t.Fatalf("got %d order event INSERTs", len(events))
+	}
+	if args := events[0].args; args[0] != int64(7) || args[8] != "3" || args[10] != int64(models.Rejected) || args[11] != int64(models.ReasonPostOnlyWouldCross) {
+		t.Fatalf("recorded event %v", args)
+	}
+}
+
+func TestSQLStoreSplitsBatchesAtParameterLimit(t *testing.T) {
+	db, fake := openFake(t)
+	s, err := NewSQLStore(db, SQLite, SQLOptions{BatchSize: 200, FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id := uint64(1); id <= 200; id++ {
+		s.RecordTrade(testTrade(id))
+	}
+	s.Close()
+
+	var rows []int
+	for _, e := range fake.inserts("trades") {
+		if len(e.args) > SQLite.maxParams || strings.Contains(e.query, "$") {
+			t.Fatalf("statement with %d parameters: %.80s", len(e.args), e.query)
+		}
+		rows = append(rows, len(e.args)/12)
+	}
+	if fmt.Sprint(rows) != "[83 83 34]" {
+		t.Fatalf("wrote rows in statements of %v", rows)
+	}
+}
+
+func TestSQLStoreRetriesThenDrops(t *testing.T) {
+	db, fake := openFake(t)
+	var dropped []error
+	s, err := NewSQLStore(db, Postgres, SQLOptions{
+		BatchSize:     1,
+		FlushInterval: time.Millisecond,
+		OnError:       func(err error) { dropped = append(dropped, err) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake.mu.Lock()
+	fake.failures = sqlWriteAttempts - 1
+	fake.mu.Unlock()
+	s.RecordTrade(testTrade(1))
+	for deadline := time.Now().Add(time.Second); len(fake.inserts("trades")) == 0; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("trade never written")
+		}
+	}
+
+	fake.mu.Lock()
+	fake.failures = sqlWriteAttempts
+	fake.mu.Unlock()
+	s.RecordTrade(testTrade(2))
+	s.Close()
+
+	if len(fake.inserts("trades")) != 1 || len(dropped) != 1 || !strings.Contains(dropped[0].Error(), "dropped 1 rows") {
+		t.Fatalf("got %d writes and drops %v", len(fake.inserts("trades")), dropped)
+	}
+}
+
+func TestCheckStorageType(t *testing.T) {
+	for _, kind := range []string{StorageMemory, StorageFile, StorageKV} {
+		if err := CheckStorageType(kind); err != nil {
+			t.Fatalf("%s: %v", kind, err)
+		}
+	}
+	if err := CheckStorageType("mysql"); err == nil {
+		t.Fatal("unknown storage type accepted")
+	}
+	// Which SQL drivers are built in depends on the build tags
+	for _, dialect := range []*Dialect{Postgres, SQLite} {
+		err := CheckStorageType(dialect.name)
+		if dialect.Linked() != (err == nil) || (err != nil && !errors.Is(err, ErrSQLDriver)) {
+			t.Fatalf("%s with driver linked %v: got %v", dialect, dialect.Linked(), err)
+		}
+	}
+	if !Postgres.Linked() {
+		if _, err := OpenSQLStore(StoragePostgres, "postgres://localhost/aeromatch", SQLOptions{}); !errors.Is(err, ErrSQLDriver) {
+			t.Fatalf("opening without the driver: got %v", err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package util
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Snowflake-style ID layout, from the most significant bit: one unused sign bit,
+// 41 bits of milliseconds since idEpoch, 10 bits of node ID and 12 bits of
+// sequence within the millisecond
+const (
+	idNodeBits     = 10
+	idSequenceBits = 12
+	idSequenceMask = 1<<idSequenceBits - 1
+
+	MaxNodeID = 1<<idNodeBits - 1
+)
+
+var idEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// IDGenerator hands out unique IDs that increase over time. Generators with
+// different node IDs never collide, so each process sharing an ID space needs its
+// own node ID. A generator that runs out of sequence numbers in a millisecond
+// borrows from the next one rather than waiting for the clock.
+type IDGenerator struct {
+	node  uint64
+	start time.Time     // Elapsed time is measured from here on the monotonic clock
+	base  int64         // Milliseconds from idEpoch to start
+	state atomic.Uint64 // Millisecond << idSequenceBits | sequence of the last ID
+}
+
+// NewIDGenerator creates a generator for the node, which must be in [0, MaxNodeID]
+func NewIDGenerator(node int) (*IDGenerator, error) {
+	if node < 0 || node > MaxNodeID {
+		return nil, fmt.Errorf("node ID %d out of range [0, %d]", node, MaxNodeID)
+	}
+	return newIDGenerator(uint64(node)), nil
+}
+
+// NewLocalIDGenerator creates a generator for node 0, for a process that does not
+// share its ID space
+func NewLocalIDGenerator() *IDGenerator {
+	return newIDGenerator(0)
+}
+
+func newIDGenerator(node uint64) *IDGenerator {
+	start := time.Now()
+	return &IDGenerator{node: node, start: start, base: start.Sub(idEpoch).Milliseconds()}
+}
+
+// Next returns a new ID
+func (g *IDGenerator) Next() uint64 {
+	for {
+		last := g.state.Load()
+		next := last + 1
+		// Steps of the wall clock do not affect time.Since, so IDs keep increasing
+		if now := uint64(g.base+time.Since(g.start).Milliseconds()) << idSequenceBits; now > next {
+			next = now
+		}
+		if g.state.CompareAndSwap(last, next) {
+			millis, sequence := next>>idSequenceBits, next&idSequenceMask
+			return millis<<(idNodeBits+idSequenceBits) | g.node<<idSequenceBits | sequence
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package util
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestIDGeneratorUniqueAcrossGoroutinesAndNodes(t *testing.T) {
+	if _, err := NewIDGenerator(MaxNodeID + 1); err == nil {
+		t.Fatal("node ID above MaxNodeID accepted")
+	}
+	first, _ := NewIDGenerator(1)
+	second, _ := NewIDGenerator(2)
+
+	const perGoroutine = 10000 // More than one millisecond's worth of sequence numbers
+	var mu sync.Mutex
+	seen := make(map[uint64]struct{})
+	var wg sync.WaitGroup
+	for _, g := range []*IDGenerator{first, first, second, second} {
+		wg.Add(1)
+		go func(g *IDGenerator) {
+			defer wg.Done()
+			ids := make([]uint64, perGoroutine)
+			for i := range ids {
+				ids[i] = g.Next()
+				if i > 0 && ids[i] <= ids[i-1] {
+					t.Errorf("ID %d not above the previous %d", ids[i], ids[i-1])
+					return
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, id := range ids {
+				seen[id] = struct{}{}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if len(seen) != 4*perGoroutine {
+		t.Fatalf("got %d distinct IDs, want %d", len(seen), 4*perGoroutine)
+	}
+}
+
+func TestLocalIDGeneratorUsesNodeZero(t *testing.T) {
+	id := NewLocalIDGenerator().Next()
+	if node := id >> idSequenceBits & MaxNodeID; node != 0 {
+		t.Fatalf("local ID %d carries node %d, want 0", id, node)
+	}
+}
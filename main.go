@@ -10,6 +10,7 @@ import (
 	"github.com/aeromatch/internal/config"
 	"github.com/aeromatch/internal/engine"
 	"github.com/aeromatch/internal/protocol"
+	"github.com/aeromatch/internal/store"
 	"github.com/aeromatch/internal/util"
 )
 
@@ -30,24 +31,102 @@ func main() {
 
 	// ----------CORE ENGINE SETUP----------
 	// Create matching engine
-	matchingEngine := engine.NewMatchingEngine(cfg.Engine.BufferSize)
+	waitStrategy, err := engine.ParseWaitStrategy(cfg.Engine.WaitStrategy)
+	if err != nil {
+		log.Fatalf("Invalid engine config: %v", err)
+	}
+	matchingEngine := engine.NewMatchingEngine(cfg.Engine.BufferSize, cfg.Engine.OrderBookBufferSize, waitStrategy)
+	orderIDs, err := util.NewIDGenerator(cfg.Engine.NodeID)
+	if err != nil {
+		log.Fatalf("Invalid engine config: %v", err)
+	}
+	matchingEngine.SetOrderIDs(orderIDs)
+	matchingEngine.SetClientOrderIDWindow(cfg.Engine.ClientOrderIDWindow)
 
 	// Create order books for supported instruments
-	instruments := []string{"BTC-USD", "ETH-USD", "AAPL", "GOOGL"}
+	instruments, err := config.LoadInstruments(cfg.Engine.InstrumentsFile)
+	if err != nil {
+		log.Fatalf("Failed to load instruments: %v", err)
+	}
 	for _, instrument := range instruments {
-		orderBook := engine.NewOrderBook(cfg.Engine.OrderBookBufferSize)
-		matchingEngine.RegisterOrderBook(instrument, orderBook)
+		if _, err := matchingEngine.AddInstrument(instrument); err != nil {
+			log.Fatalf("Failed to add instrument %s: %v", instrument.Symbol, err)
+		}
 	}
 
+	// Serve order book depth from periodic snapshots
+	depthSnapshots := engine.NewSnapshotManager(cfg.Engine.SnapshotInterval, cfg.Engine.MaxOrderBookDepth)
+	matchingEngine.SetSnapshotManager(depthSnapshots)
+
 	// ----------STORAGE & PERSISTENCE----------
-	// TODO: Initialize persistent storage
+	var journal *store.FileJournal
+	var snapshots store.SnapshotStore
+	if cfg.Storage.Enabled {
+		snapshotFormat, err := engine.ParseSnapshotFormat(cfg.Storage.SnapshotFormat)
+		if err != nil {
+			log.Fatalf("Invalid storage config: %v", err)
+		}
+		snapshotOptions := store.SnapshotOptions{
+			Retain: cfg.Storage.SnapshotRetain,
+			Format: snapshotFormat,
+		}
+		switch cfg.Storage.Type {
+		case store.StoragePostgres, store.StorageSQLite:
+			// SQL backends keep snapshots too, and record trades and order events
+			sqlStore, err := store.OpenSQLStore(cfg.Storage.Type, cfg.Storage.DSN, store.SQLOptions{
+				BatchSize:     cfg.Storage.SQLBatchSize,
+				FlushInterval: cfg.Storage.SQLFlushInterval,
+				Snapshots:     snapshotOptions,
+				OnError:       func(err error) { log.Printf("SQL store: %v", err) },
+			})
+			if err != nil {
+				log.Fatalf("Failed to open SQL store: %v", err)
+			}
+			matchingEngine.SetRecorder(sqlStore)
+			snapshots = sqlStore
+		default:
+			snapshots, err = store.OpenSnapshotStore(cfg.Storage.Type, cfg.Storage.SnapshotDir, snapshotOptions)
+			if err != nil {
+				log.Fatalf("Failed to open snapshot store: %v", err)
+			}
+		}
+		if cfg.Storage.LoadOnStartup {
+			// Restore books from their snapshots and the journal tail before the
+			// journal is reopened for appends
+			err := matchingEngine.Recover(snapshots, func(fn func(*engine.JournalEntry) error) error {
+				return store.ReadJournal(cfg.Storage.JournalFile, fn)
+			})
+			if err != nil {
+				log.Fatalf("Failed to recover engine state: %v", err)
+			}
+			log.Println("Engine state recovered", "snapshots", cfg.Storage.SnapshotDir, "journal", cfg.Storage.JournalFile)
+		} else if err := snapshots.Clear(); err != nil {
+			// This run starts over, so earlier runs' snapshots must go before
+			// SetJournal marks the start; they would outrank this run's own
+			log.Fatalf("Failed to clear snapshots: %v", err)
+		}
+		journal, err = store.OpenFileJournal(cfg.Storage.JournalFile, store.JournalOptions{
+			SyncInterval: cfg.Storage.JournalSyncInterval,
+			SyncBatch:    cfg.Storage.JournalSyncBatch,
+		})
+		if err != nil {
+			log.Fatalf("Failed to open journal: %v", err)
+		}
+		matchingEngine.SetJournal(journal)
+		log.Println("Journal opened", "path", cfg.Storage.JournalFile)
+	}
 
 	// NETWORK LAYER
 	// Initialize gRPC server
+	slowConsumerPolicy, err := engine.ParseSlowConsumerPolicy(cfg.Server.SlowConsumerPolicy)
+	if err != nil {
+		log.Fatalf("Invalid server config: %v", err)
+	}
 	grpcServer, err := protocol.NewGRPCServer(
 		matchingEngine,
 		cfg.Server.GRPCPort,
 		cfg.Server.MaxMessageSize,
+		engine.SubscriptionOptions{Buffer: cfg.Server.MarketDataBuffer, Policy: slowConsumerPolicy},
 	)
 	if err != nil {
 		log.Fatalf("Failed to create gRPC server: %v", err)
@@ -64,13 +143,12 @@ func main() {
 	// Start matching engine
 	matchingEngine.Start()
 	log.Println("Matching engine started")
+	depthSnapshots.Start()
 
 	// Start network servers
 	go grpcServer.Start()
 	log.Println("gRPC server started", "port", cfg.Server.GRPCPort)
 
-	// TODO: Load initial state if available
-
 	// ----------HEALTH CHECK & READINESS----------
 	// Perform health check
 	if err := performHealthCheck(matchingEngine, grpcServer); err != nil {
@@ -85,6 +163,26 @@ func main() {
 
 	<-sigChan
 	log.Println("Shutdown signal received, initiating graceful shutdown")
+	depthSnapshots.Stop()
+	matchingEngine.Stop() // Returns once the books are at rest
+	if lost := matchingEngine.LostTrades(); lost > 0 {
+		log.Printf("Journal failed to record %d trades", lost)
+	}
+	if snapshots != nil && cfg.Storage.SaveOnShutdown {
+		if err := matchingEngine.SaveSnapshots(snapshots); err != nil {
+			log.Printf("Failed to save snapshots: %v", err)
+		}
+	}
+	if snapshots != nil {
+		if err := snapshots.Close(); err != nil {
+			log.Printf("Failed to close snapshot store: %v", err)
+		}
+	}
+	if journal != nil {
+		if err := journal.Close(); err != nil {
+			log.Printf("Failed to close journal: %v", err)
+		}
+	}
 	// TODO: Implement graceful shutdown logic
 
 }
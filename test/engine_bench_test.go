@@ -0,0 +1,218 @@
+package test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/aeromatch/internal/engine"
+	"github.com/aeromatch/internal/models"
+)
+
+const benchQueueSize = 1024
+
+type benchItem struct {
+	order *models.Order
+	id    uint64
+}
+
+// Single producer, single consumer
+
+func BenchmarkChannelSPSC(b *testing.B) {
+	ch := make(chan benchItem, benchQueueSize)
+	done := make(chan struct{})
+	go func() {
+		for range b.N {
+			<-ch
+		}
+		close(done)
+	}()
+
+	b.ResetTimer()
+	for i := range b.N {
+		ch <- benchItem{id: uint64(i)}
+	}
+	<-done
+}
+
+func BenchmarkAtomicQueueSPSC(b *testing.B) {
+	for _, wait := range []engine.WaitStrategy{engine.WaitBusySpin, engine.WaitYield, engine.WaitPark} {
+		b.Run(wait.String(), func(b *testing.B) {
+			q := engine.NewAtomicQueue[benchItem](benchQueueSize, wait)
+			done := make(chan struct{})
+			go func() {
+				for range b.N {
+					q.Pop()
+				}
+				close(done)
+			}()
+
+			b.ResetTimer()
+			for i := range b.N {
+				q.Push(benchItem{id: uint64(i)})
+			}
+			<-done
+		})
+	}
+}
+
+// Many producers, one consumer: the engine's ingestion pattern
+
+func BenchmarkChannelMPSC(b *testing.B) {
+	ch := make(chan benchItem, benchQueueSize)
+	done := make(chan struct{})
+	b.SetParallelism(4)
+
+	go func() {
+		for range b.N {
+			<-ch
+		}
+		close(done)
+	}()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ch <- benchItem{}
+		}
+	})
+	<-done
+}
+
+func BenchmarkAtomicQueueMPSC(b *testing.B) {
+	for _, wait := range []engine.WaitStrategy{engine.WaitBusySpin, engine.WaitYield, engine.WaitPark} {
+		b.Run(wait.String(), func(b *testing.B) {
+			q := engine.NewAtomicQueue[benchItem](benchQueueSize, wait)
+			done := make(chan struct{})
+			b.SetParallelism(4)
+
+			go func() {
+				for range b.N {
+					q.Pop()
+				}
+				close(done)
+			}()
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					q.Push(benchItem{})
+				}
+			})
+			<-done
+		})
+	}
+}
+
+// End to end: submit resting orders and wait for the book to take them up
+
+func BenchmarkEngineSubmitOrder(b *testing.B) {
+	for _, wait := range []engine.WaitStrategy{engine.WaitYield, engine.WaitPark} {
+		b.Run(wait.String(), func(b *testing.B) {
+			m := engine.NewMatchingEngine(benchQueueSize, benchQueueSize, wait)
+			if _, err := m.AddInstrument(&models.Instrument{
+				Symbol:   "BTC-USD",
+				TickSize: models.MustParseDecimal("0.01"),
+				LotSize:  models.MustParseDecimal("1"),
+			}); err != nil {
+				b.Fatal(err)
+			}
+			m.Start()
+			defer m.Stop()
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+			b.ResetTimer()
+			go func() {
+				defer wg.Done()
+				for i := range b.N {
+					side, price := models.Buy, 1000-i%100
+					if i%2 == 1 {
+						side, price = models.Sell, 1001+i%100
+					}
+					order := &models.Order{
+						ID:         uint64(i + 1),
+						Price:      models.MustParseDecimal(strconv.Itoa(price)),
+						Quantity:   models.DecimalFromInt(1),
+						Remaining:  models.DecimalFromInt(1),
+						Side:       side,
+						Instrument: "BTC-USD",
+					}
+					if err := m.SubmitOrder(order); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			}()
+			wg.Wait()
+
+			// A cancel is sequenced behind every order above
+			m.CancelOrder("BTC-USD", "", 0)
+		})
+	}
+}
+
+// Snapshot encoding: a 100-level book on each side, as taken every snapshot interval
+
+func benchSnapshot() *engine.OrderBookSnapshot {
+	book := engine.NewOrderBook(&models.Instrument{
+		Symbol:   "BTC-USD",
+		TickSize: models.MustParseDecimal("0.01"),
+		LotSize:  models.MustParseDecimal("0.001"),
+	}, benchQueueSize, engine.WaitPark)
+	for i := range 100 {
+		for j := range 3 {
+			id := uint64(i*6 + j*2 + 1)
+			book.AddAsk(&models.Order{ID: id, Side: models.Sell, Instrument: "BTC-USD",
+				Price:    models.NewDecimal(int64(3000100+i), 2),
+				Quantity: models.NewDecimal(1250, 3), Remaining: models.NewDecimal(1250, 3)})
+			book.AddBid(&models.Order{ID: id + 1, Side: models.Buy, Instrument: "BTC-USD",
+				Price:    models.NewDecimal(int64(2999999-i), 2),
+				Quantity: models.NewDecimal(500, 3), Remaining: models.NewDecimal(500, 3)})
+		}
+	}
+	return book.Snapshot()
+}
+
+func BenchmarkSnapshotEncode(b *testing.B) {
+	snapshot := benchSnapshot()
+	b.Run("binary", func(b *testing.B) {
+		buf, _ := snapshot.AppendBinary(nil)
+		b.SetBytes(int64(len(buf)))
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			buf, _ = snapshot.AppendBinary(buf[:0])
+		}
+	})
+	b.Run("json", func(b *testing.B) {
+		data, _ := snapshot.Encode(engine.SnapshotJSON)
+		b.SetBytes(int64(len(data)))
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			snapshot.Encode(engine.SnapshotJSON)
+		}
+	})
+}
+
+func BenchmarkSnapshotDecode(b *testing.B) {
+	snapshot := benchSnapshot()
+	for _, format := range []engine.SnapshotFormat{engine.SnapshotBinary, engine.SnapshotJSON} {
+		b.Run(format.String(), func(b *testing.B) {
+			data, err := snapshot.Encode(format)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				var decoded engine.OrderBookSnapshot
+				if err := decoded.UnmarshalBinary(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}